@@ -0,0 +1,118 @@
+package bench
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Budgets is the maximum time each operation may take per 10,000 notes.
+// Budgets scale linearly with vault size.
+var Budgets = map[string]time.Duration{
+	"list":     2 * time.Second, // ListNotes over the whole vault
+	"search":   2 * time.Second, // SearchNotes keyword query
+	"tui-load": 3 * time.Second, // TUI initial load and first render
+}
+
+// Result is the outcome of a single timed operation
+type Result struct {
+	Name       string
+	Elapsed    time.Duration
+	Budget     time.Duration
+	Err        error
+	Detail     string
+	OverBudget bool
+}
+
+// Run times fn against the budget for name at the given vault size
+func Run(name string, notes int, fn func() (string, error)) Result {
+	start := time.Now()
+	detail, err := fn()
+	elapsed := time.Since(start)
+
+	budget := Budgets[name] * time.Duration(notes) / 10000
+	return Result{
+		Name:       name,
+		Elapsed:    elapsed,
+		Budget:     budget,
+		Err:        err,
+		Detail:     detail,
+		OverBudget: budget > 0 && elapsed > budget,
+	}
+}
+
+var (
+	words = strings.Fields(`alpha bravo charlie delta echo foxtrot golf hotel india juliet kilo lima
+mike november oscar papa quebec romeo sierra tango uniform victor whiskey xray yankee zulu
+meeting project idea draft review budget plan travel recipe book reading garden music code`)
+	tagPool = []string{"work", "personal", "idea", "todo", "done", "reading", "meeting", "project", "travel", "code"}
+	formats = []string{"txt", "org", "md"}
+)
+
+// GenerateVault writes n synthetic notes into dir using a fixed seed so runs are comparable
+func GenerateVault(dir string, n int, seed int64) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create vault directory: %w", err)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	base := time.Date(2015, 1, 1, 9, 0, 0, 0, time.Local)
+
+	for i := 0; i < n; i++ {
+		created := base.Add(time.Duration(i) * 17 * time.Minute)
+		title := fmt.Sprintf("%s %s %d", pick(rng, words), pick(rng, words), i)
+		format := formats[rng.Intn(len(formats))]
+
+		var tags []string
+		for t := rng.Intn(4); t > 0; t-- {
+			tags = append(tags, pick(rng, tagPool))
+		}
+
+		var body strings.Builder
+		for p := 1 + rng.Intn(5); p > 0; p-- {
+			for w := 20 + rng.Intn(60); w > 0; w-- {
+				body.WriteString(pick(rng, words))
+				body.WriteString(" ")
+			}
+			body.WriteString("\n\n")
+		}
+
+		id := fmt.Sprintf("%s_%s", created.Format("20060102_150405"), strings.ReplaceAll(title, " ", "_"))
+		path := filepath.Join(dir, id+"."+format)
+		if err := os.WriteFile(path, []byte(render(format, title, created, tags, body.String())), 0644); err != nil {
+			return fmt.Errorf("failed to write note: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// render produces a note file in the same layout burh writes
+func render(format, title string, created time.Time, tags []string, body string) string {
+	var sb strings.Builder
+	if format == "org" {
+		sb.WriteString(fmt.Sprintf("#+TITLE: %s\n", title))
+		sb.WriteString(fmt.Sprintf("#+DATE: %s\n", created.Format("2006-01-02")))
+		if len(tags) > 0 {
+			sb.WriteString(fmt.Sprintf("#+TAGS: %s\n", strings.Join(tags, " ")))
+		}
+		sb.WriteString("\n* CONTENT\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Title: %s\n", title))
+		sb.WriteString(fmt.Sprintf("Created: %s\n", created.Format("2006-01-02 15:04:05")))
+		if len(tags) > 0 {
+			sb.WriteString(fmt.Sprintf("Tags: %s\n", strings.Join(tags, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(body)
+	return sb.String()
+}
+
+// pick returns a random element of list
+func pick(rng *rand.Rand, list []string) string {
+	return list[rng.Intn(len(list))]
+}
@@ -0,0 +1,126 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// writeTimeout is how long Write waits for the clipboard tool to take the text
+const writeTimeout = 5 * time.Second
+
+// ErrNoImage is returned by ReadImage when the clipboard holds no image
+var ErrNoImage = errors.New("there is no image on the clipboard")
+
+// pngMagic starts every PNG file
+var pngMagic = []byte("\x89PNG\r\n\x1a\n")
+
+// Write puts text on the system clipboard using the platform's clipboard tool:
+// pbcopy on macOS, clip on Windows, and wl-copy, xclip, or xsel on Linux.
+//
+// wl-copy, xclip, and xsel leave a process in the background to hold the
+// clipboard, which keeps their output open, so Write waits for the tool itself
+// to exit and not for its output to close.
+func Write(text string) error {
+	cmd, err := command()
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = 100 * time.Millisecond
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err = <-done:
+	case <-time.After(writeTimeout):
+		cmd.Process.Kill()
+		return fmt.Errorf("failed to copy to clipboard: %s did not finish", filepath.Base(cmd.Path))
+	}
+	if err != nil && !errors.Is(err, exec.ErrWaitDelay) {
+		return fmt.Errorf("failed to copy to clipboard: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// command returns the clipboard tool for this system
+func command() (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("pbcopy"), nil
+	case "windows":
+		return exec.Command("clip"), nil
+	}
+
+	// Prefer the Wayland tool when running under Wayland
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if _, err := exec.LookPath("wl-copy"); err == nil {
+			return exec.Command("wl-copy"), nil
+		}
+	}
+	if _, err := exec.LookPath("xclip"); err == nil {
+		return exec.Command("xclip", "-selection", "clipboard"), nil
+	}
+	if _, err := exec.LookPath("xsel"); err == nil {
+		return exec.Command("xsel", "--clipboard", "--input"), nil
+	}
+	return nil, fmt.Errorf("no clipboard tool found: install wl-copy, xclip, or xsel")
+}
+
+// ReadImage returns the image on the system clipboard as PNG data, using osascript
+// on macOS, PowerShell on Windows, and wl-paste or xclip on Linux
+func ReadImage() ([]byte, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("osascript", "-e", "the clipboard as «class PNGf»")
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-Command",
+			"Add-Type -AssemblyName System.Windows.Forms; $i = [Windows.Forms.Clipboard]::GetImage(); "+
+				"if ($i) { $s = New-Object IO.MemoryStream; $i.Save($s, [Drawing.Imaging.ImageFormat]::Png); [Convert]::ToBase64String($s.ToArray()) }")
+	default:
+		if _, err := exec.LookPath("wl-paste"); err == nil && os.Getenv("WAYLAND_DISPLAY") != "" {
+			cmd = exec.Command("wl-paste", "--no-newline", "--type", "image/png")
+		} else if _, err := exec.LookPath("xclip"); err == nil {
+			cmd = exec.Command("xclip", "-selection", "clipboard", "-target", "image/png", "-out")
+		} else {
+			return nil, fmt.Errorf("no clipboard tool for images found: install wl-paste or xclip")
+		}
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, ErrNoImage
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		// osascript prints the data as «data PNGf89504E47...»
+		text := strings.TrimSpace(string(out))
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "«data PNGf"), "»")
+		if out, err = hex.DecodeString(text); err != nil {
+			return nil, ErrNoImage
+		}
+	case "windows":
+		if out, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(out))); err != nil {
+			return nil, ErrNoImage
+		}
+	}
+	if !bytes.HasPrefix(out, pngMagic) {
+		return nil, ErrNoImage
+	}
+	return out, nil
+}
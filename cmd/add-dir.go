@@ -28,7 +28,7 @@ func init() {
 func runAddDir(cmd *cobra.Command, args []string) {
 	if err := config.AddNotesDirectory(addDirPath); err != nil {
 		fmt.Printf("Error adding directory: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitIO)
 	}
 
 	fmt.Printf("Successfully added notes directory: %s\n", addDirPath)
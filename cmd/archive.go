@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+// archiveCmd represents the archive command
+var archiveCmd = &cobra.Command{
+	Use:   "archive [id...]",
+	Short: "Archive notes",
+	Long: `Archive one or more notes by ID, or every note matching --query.
+Archived notes are moved into an "` + notes.ArchiveDirName + `" subdirectory of their notes directory,
+so they no longer appear in lists and searches.`,
+	Run: runArchive,
+}
+
+func init() {
+	addQueryFlag(archiveCmd)
+	addYesFlag(archiveCmd)
+}
+
+func runArchive(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	selected, err := selectNotes(noteManager, args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	if !confirmBatch("archive", "Archive", selected) {
+		return
+	}
+
+	failed := 0
+	for _, note := range selected {
+		if err := noteManager.ArchiveNote(note); err != nil {
+			fmt.Printf("Error archiving %s: %v\n", note.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Archived %s\n", note.ID)
+	}
+
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"burh/bench"
+	"burh/config"
+	"burh/notes"
+	"burh/tui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchNotes int
+	benchDir   string
+	benchKeep  bool
+	benchQuery string
+)
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Benchmark note operations against a synthetic vault",
+	Long: `Generate a synthetic vault and time ListNotes, SearchNotes, and the TUI initial load.
+Each operation has a performance budget that scales with the vault size; the command
+exits with a non-zero status if any budget is exceeded.`,
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	Run:    runBench,
+}
+
+func init() {
+	benchCmd.Flags().IntVarP(&benchNotes, "notes", "n", 10000, "Number of synthetic notes to generate (e.g. 10000 or 50000)")
+	benchCmd.Flags().StringVar(&benchDir, "dir", "", "Vault directory (default is a temporary directory)")
+	benchCmd.Flags().BoolVar(&benchKeep, "keep", false, "Keep the generated vault after the run")
+	benchCmd.Flags().StringVar(&benchQuery, "query", "project meeting", "Keyword query used for the search benchmark")
+	skipsConfig(benchCmd)
+}
+
+func runBench(cmd *cobra.Command, args []string) {
+	// Exit only once the temporary vault is removed
+	failed, err := benchVault()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if failed {
+		os.Exit(exitIO)
+	}
+}
+
+// benchVault generates a vault, times the operations against it, and prints the
+// results. It reports if any operation failed or went over budget.
+func benchVault() (bool, error) {
+	dir := benchDir
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "burh-bench-")
+		if err != nil {
+			return false, fmt.Errorf("creating temporary vault: %w", err)
+		}
+		dir = filepath.Join(tmp, "notes")
+		if !benchKeep {
+			defer os.RemoveAll(tmp)
+		}
+	}
+
+	fmt.Printf("Generating %d notes in %s...\n", benchNotes, dir)
+	if err := bench.GenerateVault(dir, benchNotes, 1); err != nil {
+		return false, fmt.Errorf("generating vault: %w", err)
+	}
+
+	noteManager := notes.NewManagerWithDirs([]string{dir})
+	cfg := config.DefaultConfig()
+	cfg.NotesDirs = []string{dir}
+
+	results := []bench.Result{
+		bench.Run("list", benchNotes, func() (string, error) {
+			all, err := noteManager.ListNotes()
+			return fmt.Sprintf("%d notes", len(all)), err
+		}),
+		bench.Run("search", benchNotes, func() (string, error) {
+			found, err := noteManager.SearchNotes(benchQuery)
+			return fmt.Sprintf("%d matches", len(found)), err
+		}),
+		bench.Run("tui-load", benchNotes, func() (string, error) {
+			model := tui.NewModel(noteManager, cfg)
+			if err := model.LoadNotes(); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d bytes rendered", len(model.View())), nil
+		}),
+	}
+
+	failed := false
+	fmt.Printf("\n%-10s  %12s  %12s  %s\n", "Operation", "Elapsed", "Budget", "Result")
+	for _, r := range results {
+		status := "ok"
+		switch {
+		case r.Err != nil:
+			status = "error: " + r.Err.Error()
+			failed = true
+		case r.OverBudget:
+			status = "OVER BUDGET"
+			failed = true
+		}
+		fmt.Printf("%-10s  %12s  %12s  %s (%s)\n", r.Name, r.Elapsed.Round(time.Millisecond), r.Budget, status, r.Detail)
+	}
+
+	if benchKeep || benchDir != "" {
+		fmt.Printf("\nVault kept at %s\n", dir)
+	}
+
+	return failed, nil
+}
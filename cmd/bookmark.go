@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bookmarkTags    string
+	bookmarkTitle   string
+	bookmarkNoFetch bool
+	bookmarkTag     string
+)
+
+// bookmarkCmd represents the bookmark command
+var bookmarkCmd = &cobra.Command{
+	Use:   "bookmark",
+	Short: "Save and find links in bookmarks notes",
+	Long: `Collect links in bookmarks notes: notes tagged "bookmarks" holding one list item
+per link with its title, tags, and the date it was added, e.g.
+
+  - [Go Blog](https://go.dev/blog/) #go #reading (2024-06-01)
+
+New links go to a note titled "Bookmarks", or with bookmarks_note: monthly in the
+config to one note per month ("Bookmarks 2024-06"). Bookmarks notes are ordinary
+notes and can be edited like any other.`,
+}
+
+// bookmarkAddCmd represents the bookmark add command
+var bookmarkAddCmd = &cobra.Command{
+	Use:   "add [url]",
+	Short: "Add a link, fetching its page title",
+	Args:  cobra.ExactArgs(1),
+	Run:   runBookmarkAdd,
+}
+
+// bookmarkListCmd represents the bookmark list command
+var bookmarkListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List bookmarks, newest first",
+	Args:  cobra.NoArgs,
+	Run:   runBookmarkList,
+}
+
+// bookmarkSearchCmd represents the bookmark search command
+var bookmarkSearchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Find bookmarks whose title, URL, or tags contain every word of the query",
+	Args:  cobra.MinimumNArgs(1),
+	Run:   runBookmarkSearch,
+}
+
+func init() {
+	bookmarkAddCmd.Flags().StringVarP(&bookmarkTags, "tags", "t", "", "Comma-separated tags")
+	bookmarkAddCmd.Flags().StringVar(&bookmarkTitle, "title", "", "Title to use instead of the page title")
+	bookmarkAddCmd.Flags().BoolVar(&bookmarkNoFetch, "no-fetch", false, "Do not fetch the page title")
+	bookmarkListCmd.Flags().StringVar(&bookmarkTag, "tag", "", "Only bookmarks with this tag")
+	bookmarkSearchCmd.Flags().StringVar(&bookmarkTag, "tag", "", "Only bookmarks with this tag")
+
+	bookmarkCmd.AddCommand(bookmarkAddCmd)
+	bookmarkCmd.AddCommand(bookmarkListCmd)
+	bookmarkCmd.AddCommand(bookmarkSearchCmd)
+}
+
+func runBookmarkAdd(cmd *cobra.Command, args []string) {
+	link, err := normalizeBookmarkURL(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	existing, err := noteManager.Bookmarks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading bookmarks: %v\n", err)
+		os.Exit(exitIO)
+	}
+	for _, b := range existing {
+		if b.URL == link {
+			fmt.Fprintf(os.Stderr, "Error: %s is already bookmarked in %s\n", link, b.Note.ID)
+			os.Exit(exitUsage)
+		}
+	}
+
+	title := bookmarkTitle
+	if title == "" && !bookmarkNoFetch {
+		title, err = fetchPageTitle(link, 10*time.Second)
+		if err != nil && !quiet {
+			fmt.Fprintf(os.Stderr, "Warning: could not fetch the page title: %v\n", err)
+		}
+	}
+
+	var tagList []string
+	for _, tag := range strings.Split(bookmarkTags, ",") {
+		if tag = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "#")); tag != "" {
+			tagList = append(tagList, tag)
+		}
+	}
+
+	now := time.Now()
+	bookmark := notes.Bookmark{URL: link, Title: title, Tags: tagList, Added: now}
+	noteTitle := notes.BookmarksTitle(cfg.BookmarksNote == "monthly", now)
+	note, err := noteManager.AddBookmark(bookmark, noteTitle, cfg.BookmarksFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving bookmark: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if quiet {
+		fmt.Println(note.ID)
+		return
+	}
+	if title == "" {
+		title = link
+	}
+	fmt.Printf("Bookmarked: %s\nIn note: %s\n", title, note.ID)
+}
+
+func runBookmarkList(cmd *cobra.Command, args []string) {
+	printBookmarks(loadBookmarks(""))
+}
+
+func runBookmarkSearch(cmd *cobra.Command, args []string) {
+	printBookmarks(loadBookmarks(strings.Join(args, " ")))
+}
+
+// loadBookmarks reads every bookmark matching the query and the --tag filter
+func loadBookmarks(query string) []notes.Bookmark {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	bookmarks, err := noteManager.Bookmarks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading bookmarks: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	var matches []notes.Bookmark
+	for _, b := range bookmarks {
+		if bookmarkTag != "" && !bookmarkHasTag(b, bookmarkTag) {
+			continue
+		}
+		if notes.MatchesBookmark(b, query) {
+			matches = append(matches, b)
+		}
+	}
+	return matches
+}
+
+// bookmarkHasTag reports whether a bookmark has the tag, ignoring case and a leading #
+func bookmarkHasTag(b notes.Bookmark, tag string) bool {
+	tag = strings.TrimPrefix(tag, "#")
+	for _, t := range b.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// printBookmarks prints bookmarks with their URL, tags, and date added
+func printBookmarks(bookmarks []notes.Bookmark) {
+	if quiet {
+		for _, b := range bookmarks {
+			fmt.Println(b.URL)
+		}
+		return
+	}
+	if len(bookmarks) == 0 {
+		fmt.Println("No bookmarks found.")
+		return
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+	for _, b := range bookmarks {
+		fmt.Println(titleStyle.Render(b.Title))
+		fmt.Printf("   %s\n", b.URL)
+
+		var details []string
+		if !b.Added.IsZero() {
+			details = append(details, b.Added.Format("2006-01-02"))
+		}
+		for _, tag := range b.Tags {
+			details = append(details, "#"+tag)
+		}
+		if len(details) > 0 {
+			fmt.Printf("   %s\n", dimStyle.Render(strings.Join(details, "  ")))
+		}
+		fmt.Println()
+	}
+}
+
+// normalizeBookmarkURL adds https:// to bare hosts and checks the URL is a web link
+func normalizeBookmarkURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("%q is not an http or https URL", raw)
+	}
+	return u.String(), nil
+}
+
+var htmlTitle = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// fetchPageTitle downloads the start of a web page and returns its <title>,
+// giving up after timeout
+func fetchPageTitle(link string, timeout time.Duration) (string, error) {
+	client := &http.Client{Timeout: timeout}
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "burh")
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("server replied %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 512*1024))
+	if err != nil {
+		return "", err
+	}
+	m := htmlTitle.FindSubmatch(body)
+	if m == nil {
+		return "", fmt.Errorf("page has no title")
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(string(m[1]))), " "), nil
+}
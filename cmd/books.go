@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bookAuthor string
+	bookStatus string
+	bookFilter string
+	bookPages  int
+	bookPage   int
+	bookRating int
+)
+
+// booksCmd represents the books command
+var booksCmd = &cobra.Command{
+	Use:   "books",
+	Short: "Keep a reading list",
+	Long: `Keep a reading list in a library note: a note tagged "books" with a heading
+per book and its details as fields (an org property drawer, or "key: value" lines):
+
+  * Middlemarch
+  :PROPERTIES:
+  :AUTHOR: George Eliot
+  :STATUS: reading
+  :PAGES: 880
+  :PAGE: 312
+  :STARTED: 2024-05-20
+  :END:
+
+Statuses are to-read, reading, and finished. Books are records like any other, so
+declaring a "books" record type makes them available to 'burh query' as well.`,
+}
+
+// booksAddCmd represents the books add command
+var booksAddCmd = &cobra.Command{
+	Use:   "add [title]",
+	Short: "Add a book to the library",
+	Args:  cobra.ExactArgs(1),
+	Run:   runBooksAdd,
+}
+
+// booksListCmd represents the books list command
+var booksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List books by status",
+	Args:  cobra.NoArgs,
+	Run:   runBooksList,
+}
+
+// booksStartCmd represents the books start command
+var booksStartCmd = &cobra.Command{
+	Use:   "start [title]",
+	Short: "Mark a book as being read",
+	Long:  `Mark a book as being read, starting today. --page records how far you are.`,
+	Args:  cobra.ExactArgs(1),
+	Run:   runBooksStart,
+}
+
+// booksFinishCmd represents the books finish command
+var booksFinishCmd = &cobra.Command{
+	Use:   "finish [title]",
+	Short: "Mark a book as finished today",
+	Args:  cobra.ExactArgs(1),
+	Run:   runBooksFinish,
+}
+
+// booksStatsCmd represents the books stats command
+var booksStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show reading progress and totals",
+	Args:  cobra.NoArgs,
+	Run:   runBooksStats,
+}
+
+func init() {
+	booksCmd.AddCommand(booksAddCmd)
+	booksCmd.AddCommand(booksListCmd)
+	booksCmd.AddCommand(booksStartCmd)
+	booksCmd.AddCommand(booksFinishCmd)
+	booksCmd.AddCommand(booksStatsCmd)
+
+	booksAddCmd.Flags().StringVarP(&bookAuthor, "author", "a", "", "Author of the book")
+	booksAddCmd.Flags().StringVar(&bookStatus, "status", notes.BookToRead, "Status: "+strings.Join(notes.BookStatuses, ", "))
+	booksAddCmd.Flags().IntVar(&bookPages, "pages", 0, "Number of pages")
+	booksListCmd.Flags().StringVar(&bookFilter, "status", "", "Only books with this status")
+	booksStartCmd.Flags().IntVar(&bookPage, "page", 0, "Page reached")
+	booksFinishCmd.Flags().IntVarP(&bookRating, "rating", "r", 0, "Rating from 1 to 5")
+}
+
+// validBookStatus checks if a status is one of notes.BookStatuses
+func validBookStatus(status string) bool {
+	for _, s := range notes.BookStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// findBook loads the library and finds a book by title, exiting if there is no single match
+func findBook(noteManager *notes.Manager, title string) notes.Book {
+	books, err := noteManager.Books()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading library: %v\n", err)
+		os.Exit(exitIO)
+	}
+	book, err := notes.FindBook(books, title)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitNotFound)
+	}
+	return book
+}
+
+func runBooksAdd(cmd *cobra.Command, args []string) {
+	status := strings.ToLower(bookStatus)
+	if !validBookStatus(status) {
+		fmt.Fprintf(os.Stderr, "Error: unknown status %q (expected %s)\n", bookStatus, strings.Join(notes.BookStatuses, ", "))
+		os.Exit(exitUsage)
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	book := notes.Book{Title: args[0], Author: bookAuthor, Status: status, Pages: bookPages}
+	now := time.Now()
+	if status != notes.BookToRead {
+		book.Started = now
+	}
+	if status == notes.BookFinished {
+		book.Finished = now
+	}
+
+	if _, err := noteManager.AddBook(book, cfg.BooksFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	fmt.Printf("Added %s (%s)\n", book.Title, status)
+}
+
+func runBooksStart(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	book := findBook(noteManager, args[0])
+	if book.Status != notes.BookReading {
+		book.Status = notes.BookReading
+		book.Started = time.Now()
+		book.Finished = time.Time{}
+	}
+	if cmd.Flags().Changed("page") {
+		book.Page = bookPage
+	}
+
+	if err := noteManager.UpdateBook(book); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	fmt.Printf("Reading %s%s\n", book.Title, progressSuffix(book))
+}
+
+func runBooksFinish(cmd *cobra.Command, args []string) {
+	if bookRating < 0 || bookRating > 5 {
+		fmt.Fprintln(os.Stderr, "Error: --rating must be from 1 to 5")
+		os.Exit(exitUsage)
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	book := findBook(noteManager, args[0])
+	book.Status = notes.BookFinished
+	book.Finished = time.Now()
+	if book.Pages > 0 {
+		book.Page = 0 // Finished books need no bookmark
+	}
+	if bookRating > 0 {
+		book.Rating = bookRating
+	}
+
+	if err := noteManager.UpdateBook(book); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	fmt.Printf("Finished %s\n", book.Title)
+}
+
+// progressSuffix describes how far through a book its reader is, if known
+func progressSuffix(b notes.Book) string {
+	if b.Status != notes.BookReading || b.Pages <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (page %d of %d, %.0f%%)", b.Page, b.Pages, b.Progress()*100)
+}
+
+// ratingStars shows a rating as five stars, or nothing if the book is unrated
+func ratingStars(rating int) string {
+	if rating <= 0 {
+		return ""
+	}
+	rating = min(rating, 5)
+	return strings.Repeat("★", rating) + strings.Repeat("☆", 5-rating)
+}
+
+func runBooksList(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	books, err := noteManager.Books()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading library: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+
+	shown := 0
+	for _, status := range notes.BookStatuses {
+		if bookFilter != "" && !strings.EqualFold(bookFilter, status) {
+			continue
+		}
+		var group []notes.Book
+		for _, b := range books {
+			if b.Status == status {
+				group = append(group, b)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+
+		if shown > 0 {
+			fmt.Println()
+		}
+		fmt.Println(headerStyle.Render(fmt.Sprintf("%s (%d)", status, len(group))))
+		for _, b := range group {
+			line := "  " + b.Title
+			if b.Author != "" {
+				line += mutedStyle.Render(" by " + b.Author)
+			}
+			line += progressSuffix(b)
+			if stars := ratingStars(b.Rating); stars != "" {
+				line += "  " + stars
+			}
+			fmt.Println(line)
+		}
+		shown += len(group)
+	}
+
+	if shown == 0 {
+		fmt.Println("No books found.")
+	}
+}
+
+func runBooksStats(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	books, err := noteManager.Books()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading library: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if len(books) == 0 {
+		fmt.Println("No books yet. Add one with: burh books add TITLE --author NAME")
+		return
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	year := time.Now().Year()
+
+	counts := map[string]int{}
+	finishedThisYear, pagesThisYear, rated, ratingSum := 0, 0, 0, 0
+	var reading []notes.Book
+	for _, b := range books {
+		counts[b.Status]++
+		if b.Status == notes.BookReading {
+			reading = append(reading, b)
+		}
+		if b.Status == notes.BookFinished && b.Finished.Year() == year {
+			finishedThisYear++
+			pagesThisYear += b.Pages
+		}
+		if b.Rating > 0 {
+			rated++
+			ratingSum += b.Rating
+		}
+	}
+
+	fmt.Println(headerStyle.Render(fmt.Sprintf("Library: %d books", len(books))))
+	for _, status := range notes.BookStatuses {
+		fmt.Printf("  %-9s %d\n", status, counts[status])
+	}
+	fmt.Println()
+	fmt.Println(headerStyle.Render(fmt.Sprintf("%d", year)))
+	fmt.Printf("  Finished  %d books", finishedThisYear)
+	if pagesThisYear > 0 {
+		fmt.Printf(", %d pages", pagesThisYear)
+	}
+	fmt.Println()
+	if rated > 0 {
+		fmt.Printf("  Average rating %.1f of 5 (%d rated)\n", float64(ratingSum)/float64(rated), rated)
+	}
+
+	if len(reading) > 0 {
+		fmt.Println()
+		fmt.Println(headerStyle.Render("Reading"))
+		for _, b := range reading {
+			line := "  " + b.Title + progressSuffix(b)
+			if !b.Started.IsZero() {
+				line += fmt.Sprintf(", started %s", b.Started.Format("2006-01-02"))
+			}
+			fmt.Println(line)
+		}
+	}
+}
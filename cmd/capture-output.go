@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	captureTitle  string
+	captureTags   string
+	captureFormat string
+	captureEdit   bool
+	captureShell  bool
+)
+
+// captureOutputCmd represents the capture-output command
+var captureOutputCmd = &cobra.Command{
+	Use:   "capture-output -- <command> [args...]",
+	Short: "Run a command and keep its output in a note",
+	Long: `Run a command, showing its output as usual, and then create a note tagged
+"command" recording it: the command, the directory it ran in, when it started,
+how long it took, its exit status, and everything it printed to stdout and
+stderr. burh exits with the command's exit status.
+
+Put the command after "--" so its flags aren't taken for burh's. With --shell,
+the command is a single string run by $SHELL (or sh), for pipes and redirects.`,
+	Example: `  burh capture-output -- sudo apt upgrade -y
+  burh capture-output --tags backup --shell -- "restic backup ~ | tail -n 20"`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runCaptureOutput,
+}
+
+func init() {
+	captureOutputCmd.Flags().StringVar(&captureTitle, "title", "", "Note title (default the command)")
+	captureOutputCmd.Flags().StringVarP(&captureTags, "tags", "t", "", "Comma-separated tags besides command")
+	captureOutputCmd.Flags().StringVarP(&captureFormat, "format", "f", "md", "Note format ("+strings.Join(notes.FormatNames(), ", ")+")")
+	captureOutputCmd.Flags().BoolVarP(&captureEdit, "edit", "e", false, "Open the new note in the editor")
+	captureOutputCmd.Flags().BoolVarP(&captureShell, "shell", "s", false, "Run the command as one string with $SHELL -c")
+}
+
+// lockedBuffer collects a command's stdout and stderr, which are written at once
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// plainArg matches command arguments that need no quoting to be read back
+var plainArg = regexp.MustCompile(`^[\w@%+=:,./-]+$`)
+
+// quoteCommand joins a command's arguments into a command line, quoting those
+// with spaces or shell characters
+func quoteCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if plainArg.MatchString(arg) {
+			quoted[i] = arg
+		} else {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+func runCaptureOutput(cmd *cobra.Command, args []string) {
+	if !notes.WritableFormat(captureFormat) {
+		fmt.Fprintf(os.Stderr, "Error: format must be one of %s\n", strings.Join(notes.FormatNames(), ", "))
+		os.Exit(exitUsage)
+	}
+	// Load the config first, so a broken config doesn't lose the output
+	getConfig()
+
+	run := notes.CommandRun{Command: quoteCommand(args)}
+	var command *exec.Cmd
+	if captureShell {
+		shell := os.Getenv("SHELL")
+		if shell == "" {
+			shell = "sh"
+		}
+		run.Command = strings.Join(args, " ")
+		command = exec.Command(shell, "-c", run.Command)
+	} else {
+		command = exec.Command(args[0], args[1:]...)
+	}
+	run.Dir, _ = os.Getwd()
+
+	var output lockedBuffer
+	command.Stdin = os.Stdin
+	command.Stdout = io.MultiWriter(os.Stdout, &output)
+	command.Stderr = io.MultiWriter(os.Stderr, &output)
+
+	// Ctrl-C is for the command; burh stays to record how it ended
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	run.Started = time.Now()
+	err := command.Run()
+	run.Duration = time.Since(run.Started)
+	signal.Stop(interrupts)
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		run.Exit = exitErr.ExitCode()
+	default:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	run.Output = output.buf.String()
+	createCommandNote(run, captureTitle, captureTags, captureFormat, captureEdit)
+	if run.Exit != 0 {
+		os.Exit(max(run.Exit, 1))
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	clockWeek  bool
+	clockMonth bool
+)
+
+// clockCmd represents the clock command
+var clockCmd = &cobra.Command{
+	Use:   "clock",
+	Short: "Track time spent on notes",
+	Long: `Log time on notes with org mode CLOCK lines, kept in a LOGBOOK drawer in the
+note itself:
+
+  :LOGBOOK:
+  CLOCK: [2024-06-03 Mon 09:15]--[2024-06-03 Mon 10:45] =>  1:30
+  :END:
+
+Only one clock runs at a time; clocking in to a note stops the clock on any other.
+Without a subcommand, shows the running clock.`,
+	Args: cobra.NoArgs,
+	Run:  runClockStatus,
+}
+
+// clockInCmd represents the clock in command
+var clockInCmd = &cobra.Command{
+	Use:   "in [id]",
+	Short: "Start a clock on a note",
+	Args:  cobra.ExactArgs(1),
+	Run:   runClockIn,
+}
+
+// clockOutCmd represents the clock out command
+var clockOutCmd = &cobra.Command{
+	Use:   "out",
+	Short: "Stop the running clock",
+	Args:  cobra.NoArgs,
+	Run:   runClockOut,
+}
+
+// clockReportCmd represents the clock report command
+var clockReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize logged time per note and tag",
+	Long: `Summarize the time logged on notes, per note and per tag, most time first.
+--week covers the current week from Monday, --month the current month; without
+either, all logged time is counted. A running clock counts up to now.`,
+	Args: cobra.NoArgs,
+	Run:  runClockReport,
+}
+
+func init() {
+	clockCmd.AddCommand(clockInCmd)
+	clockCmd.AddCommand(clockOutCmd)
+	clockCmd.AddCommand(clockReportCmd)
+
+	clockReportCmd.Flags().BoolVar(&clockWeek, "week", false, "Only count this week")
+	clockReportCmd.Flags().BoolVar(&clockMonth, "month", false, "Only count this month")
+	clockReportCmd.MarkFlagsMutuallyExclusive("week", "month")
+}
+
+func runClockStatus(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	running, err := noteManager.RunningClock()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if running == nil {
+		fmt.Println("No clock is running.")
+		return
+	}
+	fmt.Printf("Clocked in to %s (%s) since %s, %s so far\n", running.Note.Title, running.Note.ID,
+		running.Start.Format("15:04"), notes.FormatClockDuration(running.Duration(time.Now())))
+}
+
+func runClockIn(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	stopped, err := noteManager.ClockIn(note, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if stopped != nil {
+		fmt.Printf("Clocked out of %s (%s)\n", stopped.Note.Title, notes.FormatClockDuration(stopped.Duration(time.Now())))
+	}
+	fmt.Printf("Clocked in to %s\n", note.Title)
+}
+
+func runClockOut(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	stopped, err := noteManager.ClockOut(time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	fmt.Printf("Clocked out of %s (%s)\n", stopped.Note.Title, notes.FormatClockDuration(stopped.Duration(time.Now())))
+}
+
+func runClockReport(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	entries, err := noteManager.ClockEntries()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var from, to time.Time
+	period := "all time"
+	switch {
+	case clockWeek:
+		// Weeks start on Monday
+		from = today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		to = from.AddDate(0, 0, 7)
+		period = "week of " + from.Format("2006-01-02")
+	case clockMonth:
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		to = from.AddDate(0, 1, 0)
+		period = from.Format("January 2006")
+	}
+
+	total, byNote, byTag := notes.ClockReport(entries, from, to, now)
+	if total == 0 {
+		fmt.Printf("No time logged (%s).\n", period)
+		return
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+
+	fmt.Println(headerStyle.Render(fmt.Sprintf("Time logged, %s: %s", period, notes.FormatClockDuration(total))))
+	fmt.Println()
+	fmt.Println(headerStyle.Render("By note"))
+	for _, t := range byNote {
+		fmt.Printf("  %7s  %s %s\n", notes.FormatClockDuration(t.Time), t.Name, mutedStyle.Render(t.ID))
+	}
+	if len(byTag) > 0 {
+		fmt.Println()
+		fmt.Println(headerStyle.Render("By tag"))
+		for _, t := range byTag {
+			fmt.Printf("  %7s  %s\n", notes.FormatClockDuration(t.Time), t.Name)
+		}
+	}
+}
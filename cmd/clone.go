@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var cloneTitle string
+
+// cloneCmd represents the clone command
+var cloneCmd = &cobra.Command{
+	Use:   "clone [id]",
+	Short: "Duplicate a note",
+	Long: `Create a new note with the same content, tags, format, and metadata as an existing one.
+The copy gets a new ID and is titled "Copy of <title>" unless --title is given.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runClone,
+}
+
+func init() {
+	cloneCmd.Flags().StringVarP(&cloneTitle, "title", "t", "", "Title of the new note")
+}
+
+func runClone(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	clone, err := noteManager.CloneNote(note, cloneTitle)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error cloning note: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if quiet {
+		fmt.Println(clone.ID)
+		return
+	}
+
+	fmt.Printf("Note cloned successfully!\n")
+	fmt.Printf("ID: %s\n", clone.ID)
+	fmt.Printf("Title: %s\n", clone.Title)
+	fmt.Printf("Format: %s\n", clone.Format)
+	fmt.Printf("Filename: %s\n", clone.Filename)
+	if len(clone.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(clone.Tags, ", "))
+	}
+}
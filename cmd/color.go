@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
+)
+
+var (
+	noColor bool
+	plain   bool
+
+	// plainLayout is set for --plain or when stdout isn't a terminal: lists print
+	// a line per note, in full, without headings or numbers
+	plainLayout bool
+)
+
+// applyColorMode turns off colors and text styling for --no-color, --plain, or a
+// set NO_COLOR (https://no-color.org/). Styling is already off when stdout isn't
+// a terminal.
+func applyColorMode() {
+	if noColor || plain || os.Getenv("NO_COLOR") != "" {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	plainLayout = plain || !term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// printPlainEntry prints a note on one line for plain output: its ID, creation
+// time, format, title, and all its tags. With withContent, the whole content
+// follows, indented.
+func printPlainEntry(note *notes.Note, withContent bool) {
+	line := fmt.Sprintf("%s  %s  [%s]  %s", note.ID, note.Created.Format("2006-01-02 15:04"), note.Format, note.Title)
+	var tagList []string
+	for _, tag := range note.Tags {
+		if tag != "" {
+			tagList = append(tagList, tag)
+		}
+	}
+	if len(tagList) > 0 {
+		line += "  tags: " + strings.Join(tagList, ", ")
+	}
+	fmt.Println(line)
+	if withContent && note.Content != "" {
+		for _, contentLine := range strings.Split(strings.TrimRight(note.Content, "\n"), "\n") {
+			fmt.Println("    " + contentLine)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"burh/config"
+
+	"github.com/spf13/cobra"
+)
+
+var configImportYes bool
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Export and import your burh setup",
+	Long: `Copy your burh setup to another machine with a config bundle: a single YAML file
+holding everything in the config file, from notes directories and the theme to
+record types, metadata fields, and kanban columns.
+
+Paths in your home directory are written as {home}/..., and imported with the
+home directory of the machine they are imported on. Other absolute paths are kept
+as they are, with a warning, as they may not exist on the other machine.`,
+}
+
+// configExportCmd represents the config export command
+var configExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write a config bundle to a file, or to stdout",
+	Example: `  burh config export burh-setup.yaml
+  burh config export | ssh laptop burh config import --yes -`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runConfigExport,
+}
+
+// configImportCmd represents the config import command
+var configImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Replace the config file with a config bundle's config",
+	Long: `Replace the config file with the config in a bundle made by 'burh config export',
+or read from stdin when the file is "-". The current config file is kept next to
+it with a .bak extension, and left in place if the bundle's config is invalid.
+--dry-run checks the bundle and prints the files the import would write, without
+writing them.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runConfigImport,
+}
+
+func init() {
+	honorsDryRun(configImportCmd)
+	configImportCmd.Flags().BoolVarP(&configImportYes, "yes", "y", false, "Replace an existing config file without asking")
+
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+}
+
+func runConfigExport(cmd *cobra.Command, args []string) {
+	bundle, fixed, err := config.ExportBundle()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	for _, path := range fixed {
+		fmt.Fprintf(os.Stderr, "Warning: %s is outside your home directory and is exported as is\n", path)
+	}
+
+	if len(args) == 0 {
+		data, err := config.MarshalBundle(bundle)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitIO)
+		}
+		os.Stdout.Write(data)
+		return
+	}
+	if err := config.WriteBundle(bundle, args[0]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if !quiet {
+		fmt.Printf("Exported config to %s\n", args[0])
+	}
+}
+
+func runConfigImport(cmd *cobra.Command, args []string) {
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		if !configImportYes && !dryRun {
+			fmt.Fprintln(os.Stderr, "Error: --yes is required when reading the bundle from stdin")
+			os.Exit(exitUsage)
+		}
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to read bundle: %v\n", err)
+		os.Exit(exitIO)
+	}
+	bundle, err := config.ParseBundle(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", args[0], err)
+		os.Exit(exitUsage)
+	}
+
+	configPath := config.Path()
+	if dryRun {
+		fmt.Printf("Would import config to %s\n", configPath)
+		if _, err := os.Stat(configPath); err == nil {
+			printDryRun("move", configPath, config.ImportBackupPath())
+		}
+		printDryRun("write", configPath)
+		return
+	}
+	if _, err := os.Stat(configPath); err == nil && !configImportYes {
+		// A config that no longer loads is always asked about
+		if current, err := config.LoadConfig(); err != nil || current.Confirmations.ImportOverwrite {
+			if !confirm(fmt.Sprintf("Replace %s with the bundle's config?", configPath)) {
+				fmt.Println("Import cancelled.")
+				return
+			}
+		}
+	}
+
+	cfg, err := config.ImportBundle(bundle)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if quiet {
+		return
+	}
+	fmt.Printf("Imported config to %s\n", configPath)
+	for _, dir := range cfg.NotesDirs {
+		if _, err := os.Stat(dir); err != nil {
+			fmt.Printf("  notes directory %s does not exist yet\n", dir)
+		}
+	}
+}
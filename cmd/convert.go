@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	convertTo   string
+	convertFrom string
+	convertAll  bool
+)
+
+// convertCmd represents the convert command
+var convertCmd = &cobra.Command{
+	Use:   "convert [id...]",
+	Short: "Convert notes to another format",
+	Long: `Convert notes to txt, md, or org. Headers, heading levels, checkboxes, and code
+blocks are mapped to the new format, and the file is renamed with the same ID.
+Use --all to convert every note, optionally only those in the --from format.
+--dry-run only prints the files that would be converted.`,
+	Run: runConvert,
+}
+
+func init() {
+	convertCmd.Flags().StringVar(&convertTo, "to", "", "Format to convert to: "+strings.Join(notes.FormatNames(), ", ")+" (required)")
+	convertCmd.Flags().StringVar(&convertFrom, "from", "", "Only convert notes in this format")
+	convertCmd.Flags().BoolVar(&convertAll, "all", false, "Convert every note")
+	convertCmd.MarkFlagRequired("to")
+	honorsDryRun(convertCmd)
+}
+
+func runConvert(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	if !notes.WritableFormat(convertTo) {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (expected %s)\n", convertTo, strings.Join(notes.FormatNames(), ", "))
+		os.Exit(exitUsage)
+	}
+	if _, ok := notes.LookupFormat(convertFrom); convertFrom != "" && !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (expected %s)\n", convertFrom, strings.Join(notes.FormatNames(), ", "))
+		os.Exit(exitUsage)
+	}
+	if !convertAll && len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: specify one or more note IDs or --all")
+		os.Exit(exitUsage)
+	}
+
+	var selected []*notes.Note
+	for _, id := range args {
+		note, err := noteManager.GetNote(id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		selected = append(selected, note)
+	}
+	if convertAll {
+		all, err := noteManager.ListNotes()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing notes: %v\n", err)
+			os.Exit(exitIO)
+		}
+		selected = append(selected, all...)
+	}
+
+	converted, failed := 0, 0
+	for _, note := range selected {
+		if note.Format == convertTo || (convertFrom != "" && note.Format != convertFrom) {
+			continue
+		}
+		// Binary notes such as PDFs are listed but have no text to convert
+		if convertAll && !notes.WritableFormat(note.Format) {
+			continue
+		}
+		from := note.Format
+		if dryRun {
+			printDryRun("convert", note.Path(), notes.ConvertedPath(note, convertTo))
+			converted++
+			continue
+		}
+		if err := noteManager.ConvertNote(note, convertTo); err != nil {
+			fmt.Printf("Error converting %s: %v\n", note.ID, err)
+			failed++
+			continue
+		}
+		converted++
+		if !quiet {
+			fmt.Printf("Converted %s (%s -> %s)\n", note.ID, from, convertTo)
+		}
+	}
+
+	if !quiet {
+		verb := "Converted"
+		if dryRun {
+			verb = "Would convert"
+		}
+		fmt.Printf("%s %d notes\n", verb, converted)
+	}
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
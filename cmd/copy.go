@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"burh/clipboard"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	copyPath bool
+	copyID   bool
+)
+
+// copyCmd represents the copy command
+var copyCmd = &cobra.Command{
+	Use:   "copy [id]",
+	Short: "Copy a note to the clipboard",
+	Long: `Put a note's content on the system clipboard.
+Use --path to copy the note's file path or --id to copy its full ID instead.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runCopy,
+}
+
+func init() {
+	copyCmd.Flags().BoolVar(&copyPath, "path", false, "Copy the note's file path")
+	copyCmd.Flags().BoolVar(&copyID, "id", false, "Copy the note's ID")
+	copyCmd.MarkFlagsMutuallyExclusive("path", "id")
+}
+
+func runCopy(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	text, what := note.Content, "content"
+	switch {
+	case copyPath:
+		text, what = note.Path(), "path"
+	case copyID:
+		text, what = note.ID, "ID"
+	}
+
+	if err := clipboard.Write(text); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if !quiet {
+		fmt.Printf("Copied %s of '%s' to the clipboard\n", what, note.Title)
+	}
+}
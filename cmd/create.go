@@ -8,6 +8,7 @@ import (
 	"burh/notes"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
@@ -15,6 +16,8 @@ var (
 	content string
 	tags    string
 	format  string
+
+	createForce bool
 )
 
 // createCmd represents the create command
@@ -22,7 +25,11 @@ var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new note",
 	Long: `Create a new note with the specified title, content, tags, and format.
-The note will be saved with a unique ID based on timestamp and title.`,
+The note will be saved with a unique ID based on timestamp and title.
+
+If a note with a closely matching title exists, such as "Docker tips" for "docker
+tip", create asks whether to open that note, add the content to it, or create the
+new note anyway. When not run from a terminal it warns and creates the note.`,
 	Run: runCreate,
 }
 
@@ -33,7 +40,8 @@ func init() {
 	createCmd.Flags().StringVarP(&title, "title", "t", "", "Note title (required)")
 	createCmd.Flags().StringVarP(&content, "content", "c", "", "Note content")
 	createCmd.Flags().StringVarP(&tags, "tags", "g", "", "Comma-separated tags")
-	createCmd.Flags().StringVarP(&format, "format", "f", "txt", "Note format (txt or org)")
+	createCmd.Flags().StringVarP(&format, "format", "f", "txt", "Note format ("+strings.Join(notes.FormatNames(), ", ")+")")
+	createCmd.Flags().BoolVar(&createForce, "force", false, "Create the note without checking for notes with a similar title")
 
 	createCmd.MarkFlagRequired("title")
 }
@@ -43,9 +51,9 @@ func runCreate(cmd *cobra.Command, args []string) {
 	cfg := getConfig()
 
 	// Validate format
-	if format != "txt" && format != "org" {
-		fmt.Println("Error: format must be 'txt' or 'org'")
-		os.Exit(1)
+	if !notes.WritableFormat(format) {
+		fmt.Printf("Error: format must be one of %s\n", strings.Join(notes.FormatNames(), ", "))
+		os.Exit(exitUsage)
 	}
 
 	// Parse tags
@@ -58,13 +66,22 @@ func runCreate(cmd *cobra.Command, args []string) {
 	}
 
 	// Create note manager with all directories
-	noteManager := notes.NewManagerWithDirs(cfg.NotesDirs)
+	noteManager := newNoteManager(cfg)
+
+	if !createForce && !checkSimilarTitles(noteManager) {
+		return
+	}
 
 	// Create note
 	note, err := noteManager.CreateNote(title, content, tagList, format)
 	if err != nil {
 		fmt.Printf("Error creating note: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitIO)
+	}
+
+	if quiet {
+		fmt.Println(note.ID)
+		return
 	}
 
 	fmt.Printf("Note created successfully!\n")
@@ -76,3 +93,50 @@ func runCreate(cmd *cobra.Command, args []string) {
 		fmt.Printf("Tags: %s\n", strings.Join(note.Tags, ", "))
 	}
 }
+
+// checkSimilarTitles looks for notes with a title like the new one's and, from a
+// terminal, asks what to do. It returns false when the user opened or added to an
+// existing note, or quit, instead of creating the new one.
+func checkSimilarTitles(noteManager *notes.Manager) bool {
+	similar, err := noteManager.SimilarTitles(title)
+	if err != nil || len(similar) == 0 {
+		return true
+	}
+	existing := similar[0]
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprintf(os.Stderr, "Warning: '%s' (%s) has a similar title\n", existing.Title, existing.ID)
+		return true
+	}
+
+	fmt.Println("Notes with a similar title:")
+	for _, note := range similar {
+		fmt.Printf("  %s  %s\n", note.ID, note.Title)
+	}
+	for {
+		fmt.Printf("[o]pen '%s', [a]ppend the content to it, [c]reate a new note, or [q]uit: ", existing.Title)
+		response, err := stdinReader.ReadString('\n')
+		if err != nil {
+			return false
+		}
+		switch strings.ToLower(strings.TrimSpace(response)) {
+		case "o", "open":
+			editNote(noteManager, existing, 0)
+			return false
+		case "a", "append":
+			if strings.TrimSpace(content) == "" {
+				fmt.Println("There is no content to append; use --content.")
+				continue
+			}
+			if _, err := noteManager.AppendContent(existing, content); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(exitIO)
+			}
+			fmt.Printf("Appended to %s (%s)\n", existing.Title, existing.ID)
+			return false
+		case "c", "create":
+			return true
+		case "q", "quit":
+			return false
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var deleteYes bool
+
+// deleteCmd represents the delete command
+var deleteCmd = &cobra.Command{
+	Use:   "delete [id...]",
+	Short: "Delete notes",
+	Long: `Delete one or more notes by ID, or every note matching --query.
+You are asked to confirm before more than one note is deleted. Deleting more than
+safety.bulk_delete notes at once (10 by default) takes typing DELETE, and deleting
+a note tagged safety.pinned_tag ("pinned") takes typing its title; --yes does not
+skip these. --dry-run only prints the files that would be deleted, without asking.`,
+	Run: runDelete,
+}
+
+func init() {
+	addQueryFlag(deleteCmd)
+	honorsDryRun(deleteCmd)
+	deleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Do not ask for confirmation")
+}
+
+func runDelete(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	selected, err := selectNotes(noteManager, args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	if len(selected) == 0 {
+		fmt.Println("No notes matched.")
+		return
+	}
+
+	if dryRun {
+		count := 0
+		for _, note := range selected {
+			if note.Locked {
+				fmt.Printf("Skipped %s: note is locked\n", note.ID)
+				continue
+			}
+			printDryRun("delete", note.Path())
+			count++
+		}
+		fmt.Printf("Would delete %d notes.\n", count)
+		return
+	}
+
+	safety := cfg.Safety
+	bulk := safety.IsBulkDelete(len(selected))
+	if bulk || (len(selected) > 1 && !deleteYes && cfg.Confirmations.ConfirmsBulk("delete")) {
+		for _, note := range selected {
+			pinned := ""
+			if safety.IsPinned(note.Tags) {
+				pinned = "  (pinned)"
+			}
+			fmt.Printf("  %s  %s%s\n", note.ID, note.Title, pinned)
+		}
+		var confirmed bool
+		if bulk {
+			confirmed = confirmTyped(fmt.Sprintf("Type DELETE to delete these %d notes", len(selected)), "DELETE")
+		} else {
+			confirmed = confirm(fmt.Sprintf("Delete %d notes?", len(selected)))
+		}
+		if !confirmed {
+			fmt.Println("Aborted.")
+			return
+		}
+	}
+
+	failed := 0
+	for _, note := range selected {
+		// Typing DELETE for the whole batch covers its pinned notes
+		if !bulk && safety.IsPinned(note.Tags) &&
+			!confirmTyped(fmt.Sprintf("'%s' is pinned. Type its title to delete it", note.Title), note.Title) {
+			fmt.Printf("Skipped %s\n", note.ID)
+			continue
+		}
+		if err := noteManager.RemoveNote(note); err != nil {
+			fmt.Printf("Error deleting %s: %v\n", note.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Deleted %s\n", note.ID)
+	}
+
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
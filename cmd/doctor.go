@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"burh/config"
+	"burh/editor"
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check burh's setup for problems",
+	Long: `Check the config file, the notes directories and their permissions, the editor,
+burh's state files (session, draft, undo journal, audit log, and flashcard
+schedules), OCR sidecar files left behind by deleted notes, and notes that share
+an ID. Each check passes, warns, or fails, with a suggested fix.
+
+Exits with status 1 if any check fails.`,
+	Args: cobra.NoArgs,
+	Run:  runDoctor,
+}
+
+// doctorStatus is the outcome of one check
+type doctorStatus int
+
+const (
+	doctorPass doctorStatus = iota
+	doctorWarn
+	doctorFail
+)
+
+// doctorReport prints check results as they come and counts the failures
+type doctorReport struct {
+	failed int
+	warned int
+}
+
+// add prints a check's result, and a suggested fix under it unless it passed
+func (r *doctorReport) add(status doctorStatus, check, fix string) {
+	mark := lipgloss.NewStyle().Foreground(lipgloss.Color("#A3BE8C")).Render("✓")
+	switch status {
+	case doctorWarn:
+		mark = lipgloss.NewStyle().Foreground(lipgloss.Color("#EBCB8B")).Render("!")
+		r.warned++
+	case doctorFail:
+		mark = lipgloss.NewStyle().Foreground(lipgloss.Color("#BF616A")).Render("✗")
+		r.failed++
+	}
+	fmt.Printf("%s %s\n", mark, check)
+	if status != doctorPass && fix != "" {
+		fmt.Printf("    %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A")).Render("fix: "+fix))
+	}
+}
+
+func runDoctor(cmd *cobra.Command, args []string) {
+	report := &doctorReport{}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		report.add(doctorFail, fmt.Sprintf("config %s: %v", config.Path(), err), "correct the setting in the config file, or delete the file to start over with defaults")
+		os.Exit(exitIO)
+	}
+	report.add(doctorPass, "config "+config.Path(), "")
+	globalConfig = cfg
+	noteManager := newNoteManager(cfg)
+
+	for _, dir := range cfg.NotesDirs {
+		checkNotesDir(report, dir, cfg.IsReadOnlyDir(dir))
+	}
+	checkEditor(report)
+	checkStateFiles(report)
+
+	orphans, err := noteManager.OrphanedSidecars()
+	switch {
+	case err != nil:
+		report.add(doctorFail, fmt.Sprintf("OCR sidecars: %v", err), "")
+	case len(orphans) > 0:
+		report.add(doctorWarn, fmt.Sprintf("OCR sidecars without a note (%d):\n      %s", len(orphans), strings.Join(orphans, "\n      ")), "delete them; they are only caches of text read from images")
+	default:
+		report.add(doctorPass, "no orphaned OCR sidecars", "")
+	}
+
+	noteList, err := noteManager.ListNotes()
+	if err != nil {
+		report.add(doctorFail, fmt.Sprintf("listing notes: %v", err), "")
+	} else if duplicates := notes.DuplicateIDs(noteList); len(duplicates) > 0 {
+		ids := make([]string, 0, len(duplicates))
+		for id := range duplicates {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			paths := duplicates[id]
+			report.add(doctorFail, fmt.Sprintf("ID %s is shared by:\n      %s", id, strings.Join(paths, "\n      ")), "rename or remove all but one of the files; burh only reaches the first by ID")
+		}
+	} else {
+		report.add(doctorPass, fmt.Sprintf("%d notes, no duplicate IDs", len(noteList)), "")
+	}
+
+	fmt.Printf("\n%d failed, %d warnings\n", report.failed, report.warned)
+	if report.failed > 0 {
+		os.Exit(exitIO)
+	}
+}
+
+// checkNotesDir checks that a notes directory exists and can be read, and written
+// unless it is read-only
+func checkNotesDir(report *doctorReport, dir string, readOnly bool) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		report.add(doctorFail, fmt.Sprintf("notes directory %s: %v", dir, err), "create it, or remove it with 'burh remove-dir "+dir+"'")
+		return
+	}
+	if !info.IsDir() {
+		report.add(doctorFail, fmt.Sprintf("notes directory %s is not a directory", dir), "point the config at a directory instead")
+		return
+	}
+	if _, err := os.ReadDir(dir); err != nil {
+		report.add(doctorFail, fmt.Sprintf("notes directory %s cannot be read: %v", dir, err), "give your user read permission on it")
+		return
+	}
+	if readOnly {
+		report.add(doctorPass, fmt.Sprintf("notes directory %s (read-only)", dir), "")
+		return
+	}
+	probe, err := os.CreateTemp(dir, ".burh-doctor-*")
+	if err != nil {
+		report.add(doctorFail, fmt.Sprintf("notes directory %s cannot be written: %v", dir, err), "give your user write permission on it, or list it in read_only_dirs")
+		return
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	report.add(doctorPass, "notes directory "+dir, "")
+}
+
+// checkEditor checks that $VISUAL or $EDITOR names a program that can be run
+func checkEditor(report *doctorReport) {
+	program := editor.Configured()
+	if program == "" {
+		fallback := "the system's default application"
+		if runtime.GOOS == "windows" {
+			fallback = "Notepad"
+		}
+		report.add(doctorWarn, "$EDITOR is not set; notes open in "+fallback, "set $EDITOR to your editor, e.g. export EDITOR=vim")
+		return
+	}
+	if _, err := exec.LookPath(program); err != nil {
+		report.add(doctorFail, fmt.Sprintf("editor %s not found", program), "install it, or set $EDITOR to an editor on your PATH")
+		return
+	}
+	report.add(doctorPass, "editor "+program, "")
+}
+
+// checkStateFiles checks that the files burh keeps next to the config can be read
+func checkStateFiles(report *doctorReport) {
+	checks := []struct {
+		name string
+		path string
+		load func() error
+	}{
+		{"session", config.SessionPath(), func() error { _, err := config.LoadSession(); return err }},
+		{"draft", config.DraftPath(), func() error { _, err := config.LoadDraft(); return err }},
+		{"undo journal", config.JournalPath(), func() error { _, err := notes.LoadJournal(config.JournalPath()); return err }},
+		{"audit log", config.AuditLogPath(), func() error { _, err := notes.ReadAuditLog(config.AuditLogPath()); return err }},
+		{"flashcard schedules", config.StudyStatePath(), func() error { _, err := notes.LoadCardStates(config.StudyStatePath()); return err }},
+	}
+	for _, check := range checks {
+		if err := check.load(); err != nil {
+			report.add(doctorFail, fmt.Sprintf("%s: %v", check.name, err), "delete "+check.path+"; burh starts a new one")
+			continue
+		}
+		report.add(doctorPass, check.name, "")
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// dryRun is set by the global --dry-run flag: commands that honor it print which
+// files they would change and leave the disk alone
+var dryRun bool
+
+// dryRunAnnotation marks the commands that honor --dry-run
+const dryRunAnnotation = "dry-run"
+
+// honorsDryRun marks a command as honoring --dry-run
+func honorsDryRun(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[dryRunAnnotation] = "true"
+}
+
+// checkDryRun refuses --dry-run on commands that don't honor it, rather than let
+// them change files anyway
+func checkDryRun(cmd *cobra.Command, args []string) error {
+	if dryRun && cmd.Annotations[dryRunAnnotation] == "" {
+		return fmt.Errorf("%s does not support --dry-run", cmd.CommandPath())
+	}
+	return nil
+}
+
+// printDryRun prints a file change held back by --dry-run, e.g. "delete" and a path,
+// or "move" and the paths before and after
+func printDryRun(action string, paths ...string) {
+	fmt.Printf("  would %s %s\n", action, strings.Join(paths, " -> "))
+}
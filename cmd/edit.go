@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"burh/editor"
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+// editCmd represents the edit command
+var editCmd = &cobra.Command{
+	Use:   "edit [id]",
+	Short: "Open a note in your editor",
+	Long:  `Open a note in $VISUAL or $EDITOR, falling back to the OS default application.`,
+	Args:  cobra.ExactArgs(1),
+	Run:   runEdit,
+}
+
+func runEdit(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	editNote(noteManager, note, 0)
+}
+
+// editNote opens a note in the editor with the cursor on a 1-based line (0 for the top),
+// journaling the edit so 'burh undo' reverts it. Locked notes and notes in read-only
+// directories are refused, as in the TUI. Binary notes, such as PDFs, open in their
+// default application.
+func editNote(noteManager *notes.Manager, note *notes.Note, line int) {
+	var editorCmd *exec.Cmd
+	var err error
+	if notes.WritableFormat(note.Format) {
+		if note.Locked {
+			fmt.Fprintf(os.Stderr, "Error: %v: %s (run 'burh unlock %s' first)\n", notes.ErrLocked, note.ID, note.ID)
+			os.Exit(exitUsage)
+		}
+		if noteManager.IsReadOnlyDir(note.Dir) {
+			fmt.Fprintf(os.Stderr, "Error: %v: %s is in %s\n", notes.ErrReadOnlyDir, note.ID, note.Dir)
+			os.Exit(exitUsage)
+		}
+		editorCmd, err = editor.CommandAt(note.Path(), line)
+	} else {
+		editorCmd, err = editor.OpenCommand(note.Path())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	run := editorCmd.Run
+	if notes.WritableFormat(note.Format) {
+		run = func() error { return noteManager.JournalEdit(note, editorCmd.Run) }
+	}
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running editor: %v\n", err)
+		os.Exit(exitIO)
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"burh/config"
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	excludeTags []string
+	excludeDirs []string
+)
+
+// addExcludeFlags registers the flags that keep notes out of a command's lists
+func addExcludeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&excludeTags, "exclude-tags", nil, "Leave out notes with any of these comma-separated tags")
+	cmd.Flags().StringSliceVar(&excludeDirs, "exclude-dir", nil, "Leave out notes in this directory, a path or a subdirectory name of the notes directories (repeatable)")
+}
+
+// applyDefaultView narrows what the note manager lists to the config's
+// default_view, unless --exclude-tags or --exclude-dir were given instead. It
+// reports whether it did.
+func applyDefaultView(noteManager *notes.Manager, cfg *config.Config) bool {
+	if len(excludeTags) > 0 || len(excludeDirs) > 0 {
+		return false
+	}
+	noteManager.SetExclusions(cfg.ViewExclusions())
+	return true
+}
+
+// applyExclusions hands --exclude-tags and --exclude-dir to the note manager
+func applyExclusions(noteManager *notes.Manager) {
+	if len(excludeTags) == 0 && len(excludeDirs) == 0 {
+		return
+	}
+	dirs := make([]string, len(excludeDirs))
+	for i, dir := range excludeDirs {
+		if dir == "~" || strings.HasPrefix(dir, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				dir = filepath.Join(home, dir[1:])
+			}
+		}
+		dirs[i] = dir
+	}
+	noteManager.SetExclusions(excludeTags, dirs)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"errors"
+
+	"burh/notes"
+)
+
+// Exit codes used by commands so burh behaves well in shell conditionals
+const (
+	exitOK       = 0 // Success, or at least one note found
+	exitNotFound = 1 // No matching note
+	exitUsage    = 2 // Invalid arguments or flags
+	exitIO       = 3 // Reading or writing notes or config failed
+)
+
+// errNoSelection is returned when a batch command is given neither IDs nor --query
+var errNoSelection = errors.New("specify one or more note IDs or --query")
+
+// exitCode returns the exit code for an error finding or selecting notes
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, notes.ErrInvalidQuery), errors.Is(err, errNoSelection):
+		return exitUsage
+	case errors.Is(err, notes.ErrNotFound):
+		return exitNotFound
+	}
+	return exitIO
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/clipboard"
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOut  string
+	exportAs   string
+	exportCopy bool
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export [id...]",
+	Short: "Export notes to a directory or another markup",
+	Long: `Copy one or more notes by ID, or every note matching --query, into an output directory.
+
+With --as, a single note is instead converted for pasting into another tool and printed,
+or put on the clipboard with --copy: plain text, sanitized HTML, Slack mrkdwn, or Jira
+wiki markup.`,
+	Run: runExport,
+}
+
+func init() {
+	addQueryFlag(exportCmd)
+	exportCmd.Flags().StringVarP(&exportOut, "out", "o", "", "Directory to export the notes into")
+	exportCmd.Flags().StringVar(&exportAs, "as", "", "Convert one note to "+strings.Join(notes.RenderTargets, ", "))
+	exportCmd.Flags().BoolVar(&exportCopy, "copy", false, "Copy the --as output to the clipboard instead of printing it")
+	exportCmd.MarkFlagsMutuallyExclusive("out", "as")
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	if exportAs != "" {
+		exportRendered(noteManager, args)
+		return
+	}
+	if exportOut == "" {
+		fmt.Println("Error: specify an output directory with --out, or a markup with --as")
+		os.Exit(exitUsage)
+	}
+
+	selected, err := selectNotes(noteManager, args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	failed := 0
+	for _, note := range selected {
+		if err := noteManager.ExportNote(note, exportOut); err != nil {
+			fmt.Printf("Error exporting %s: %v\n", note.ID, err)
+			failed++
+			continue
+		}
+	}
+
+	fmt.Printf("Exported %d notes to %s\n", len(selected)-failed, exportOut)
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
+
+// exportRendered prints one note converted to the --as markup, or copies it to the clipboard
+func exportRendered(noteManager *notes.Manager, args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Error: --as takes exactly one note ID")
+		os.Exit(exitUsage)
+	}
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	if err := noteManager.LoadContent(note); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading note: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	text, err := notes.RenderNote(note, exportAs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	if !exportCopy {
+		fmt.Print(text)
+		return
+	}
+	if err := clipboard.Write(text); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if !quiet {
+		fmt.Printf("Copied '%s' as %s to the clipboard\n", note.Title, exportAs)
+	}
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	fromCmdInit     string
+	fromCmdTitle    string
+	fromCmdTags     string
+	fromCmdFormat   string
+	fromCmdEdit     bool
+	fromCmdNoOutput bool
+)
+
+// fromCmdCmd represents the fromcmd command
+var fromCmdCmd = &cobra.Command{
+	Use:   "fromcmd",
+	Short: "Turn the last shell command into a note",
+	Long: `Create a note from the last command you ran in your shell: the command, the
+directory it ran in, and its exit status, tagged "command". Run a command through
+burh_rec to also keep what it printed, e.g. 'burh_rec make deploy'.
+
+This needs the shell integration, which records each command in a capture
+directory ($BURH_CAPTURE, by default burh-capture-<uid> in the temp directory).
+Add it to your shell's startup file:
+
+  eval "$(burh fromcmd --init bash)"     # ~/.bashrc
+  eval "$(burh fromcmd --init zsh)"      # ~/.zshrc
+  burh fromcmd --init fish | source      # ~/.config/fish/config.fish`,
+	Example: `  burh_rec sudo systemctl restart nginx
+  burh fromcmd --title "Fixed nginx after the upgrade" --edit`,
+	Args: cobra.NoArgs,
+	Run:  runFromCmd,
+}
+
+func init() {
+	fromCmdCmd.Flags().StringVar(&fromCmdInit, "init", "", "Print the shell integration for bash, zsh, or fish")
+	fromCmdCmd.Flags().StringVar(&fromCmdTitle, "title", "", "Note title (default the command)")
+	fromCmdCmd.Flags().StringVarP(&fromCmdTags, "tags", "t", "", "Comma-separated tags besides command")
+	fromCmdCmd.Flags().StringVarP(&fromCmdFormat, "format", "f", "md", "Note format ("+strings.Join(notes.FormatNames(), ", ")+")")
+	fromCmdCmd.Flags().BoolVarP(&fromCmdEdit, "edit", "e", false, "Open the new note in the editor")
+	fromCmdCmd.Flags().BoolVar(&fromCmdNoOutput, "no-output", false, "Leave out the command's output")
+}
+
+// shellIntegrations are the snippets recording each command for fromcmd. The
+// command, its exit status, and its directory are written when the prompt comes
+// back; output is only there when burh_rec ran the command.
+var shellIntegrations = map[string]string{
+	"bash": `# burh: record the last command for 'burh fromcmd'
+export BURH_CAPTURE="${BURH_CAPTURE:-${TMPDIR:-/tmp}/burh-capture-$(id -u)}"
+__burh_capture() {
+  local exit_status=$? entry cmd
+  entry=$(HISTTIMEFORMAT= builtin history 1)
+  [ "$entry" = "$__burh_last" ] && return $exit_status
+  __burh_last=$entry
+  cmd=$(printf '%s\n' "$entry" | sed 's/^ *[0-9]*\*\{0,1\} *//')
+  case "$cmd" in ""|"burh fromcmd"*) rm -f "$BURH_CAPTURE/output.new"; return $exit_status ;; esac
+  mkdir -p -m 700 "$BURH_CAPTURE"
+  printf '%s\n' "$cmd" > "$BURH_CAPTURE/command"
+  printf '%s\n' "$exit_status" > "$BURH_CAPTURE/status"
+  printf '%s\n' "$PWD" > "$BURH_CAPTURE/dir"
+  if [ -f "$BURH_CAPTURE/output.new" ]; then mv -f "$BURH_CAPTURE/output.new" "$BURH_CAPTURE/output"; else rm -f "$BURH_CAPTURE/output"; fi
+  return $exit_status
+}
+burh_rec() { mkdir -p -m 700 "$BURH_CAPTURE"; "$@" 2>&1 | tee "$BURH_CAPTURE/output.new"; return "${PIPESTATUS[0]}"; }
+PROMPT_COMMAND="__burh_capture${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+`,
+	"zsh": `# burh: record the last command for 'burh fromcmd'
+export BURH_CAPTURE="${BURH_CAPTURE:-${TMPDIR:-/tmp}/burh-capture-$(id -u)}"
+__burh_preexec() { __burh_cmd=$1 }
+__burh_precmd() {
+  local exit_status=$?
+  if [[ -z $__burh_cmd || $__burh_cmd == "burh fromcmd"* ]]; then
+    rm -f "$BURH_CAPTURE/output.new"; __burh_cmd=; return
+  fi
+  mkdir -p -m 700 "$BURH_CAPTURE"
+  print -r -- "$__burh_cmd" > "$BURH_CAPTURE/command"
+  print -r -- "$exit_status" > "$BURH_CAPTURE/status"
+  print -r -- "$PWD" > "$BURH_CAPTURE/dir"
+  if [[ -f $BURH_CAPTURE/output.new ]]; then mv -f "$BURH_CAPTURE/output.new" "$BURH_CAPTURE/output"; else rm -f "$BURH_CAPTURE/output"; fi
+  __burh_cmd=
+}
+burh_rec() { mkdir -p -m 700 "$BURH_CAPTURE"; "$@" 2>&1 | tee "$BURH_CAPTURE/output.new"; return ${pipestatus[1]} }
+autoload -Uz add-zsh-hook
+add-zsh-hook preexec __burh_preexec
+add-zsh-hook precmd __burh_precmd
+`,
+	"fish": `# burh: record the last command for 'burh fromcmd'
+set -q BURH_CAPTURE; or set -gx BURH_CAPTURE (set -q TMPDIR; and echo $TMPDIR; or echo /tmp)/burh-capture-(id -u)
+function __burh_capture --on-event fish_postexec
+    set -l exit_status $status
+    if test -z "$argv[1]"; or string match -q -- 'burh fromcmd*' $argv[1]
+        rm -f $BURH_CAPTURE/output.new
+        return
+    end
+    mkdir -p -m 700 $BURH_CAPTURE
+    printf '%s\n' $argv[1] > $BURH_CAPTURE/command
+    printf '%s\n' $exit_status > $BURH_CAPTURE/status
+    printf '%s\n' $PWD > $BURH_CAPTURE/dir
+    if test -f $BURH_CAPTURE/output.new
+        mv -f $BURH_CAPTURE/output.new $BURH_CAPTURE/output
+    else
+        rm -f $BURH_CAPTURE/output
+    end
+end
+function burh_rec
+    mkdir -p -m 700 $BURH_CAPTURE
+    $argv 2>&1 | tee $BURH_CAPTURE/output.new
+    return $pipestatus[1]
+end
+`,
+}
+
+// captureDir returns the directory the shell integration records commands in
+func captureDir() string {
+	if dir := os.Getenv("BURH_CAPTURE"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "burh-capture-"+strconv.Itoa(os.Getuid()))
+}
+
+// readCapture reads the last command the shell integration recorded
+func readCapture(dir string) (notes.CommandRun, error) {
+	read := func(name string) (string, error) {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		return strings.TrimRight(string(data), "\n"), err
+	}
+
+	var run notes.CommandRun
+	command, err := read("command")
+	if errors.Is(err, os.ErrNotExist) {
+		return run, fmt.Errorf("no command recorded in %s; is the shell integration set up? (see 'burh fromcmd --help')", dir)
+	}
+	if err != nil {
+		return run, err
+	}
+	run.Command = strings.TrimPrefix(command, "burh_rec ")
+	if status, err := read("status"); err == nil {
+		run.Exit, _ = strconv.Atoi(strings.TrimSpace(status))
+	}
+	run.Dir, _ = read("dir")
+	if output, err := read("output"); err == nil {
+		run.Output = output
+	}
+	return run, nil
+}
+
+func runFromCmd(cmd *cobra.Command, args []string) {
+	if fromCmdInit != "" {
+		snippet, ok := shellIntegrations[fromCmdInit]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: no shell integration for %q (expected bash, zsh, or fish)\n", fromCmdInit)
+			os.Exit(exitUsage)
+		}
+		fmt.Print(snippet)
+		return
+	}
+	if !notes.WritableFormat(fromCmdFormat) {
+		fmt.Fprintf(os.Stderr, "Error: format must be one of %s\n", strings.Join(notes.FormatNames(), ", "))
+		os.Exit(exitUsage)
+	}
+
+	run, err := readCapture(captureDir())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitNotFound)
+	}
+	if fromCmdNoOutput {
+		run.Output = ""
+	}
+	createCommandNote(run, fromCmdTitle, fromCmdTags, fromCmdFormat, fromCmdEdit)
+}
+
+// createCommandNote creates a note recording a command run, tagged "command" and
+// titled after the command unless a title is given
+func createCommandNote(run notes.CommandRun, title, tags, format string, edit bool) {
+	if strings.TrimSpace(title) == "" {
+		title = notes.CommandTitle(run.Command)
+	}
+	tagList := []string{notes.CommandTag}
+	for _, tag := range splitTags(tags) {
+		if !containsFold(tagList, tag) {
+			tagList = append(tagList, tag)
+		}
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+	note, err := noteManager.CreateNote(title, notes.CommandNote(format, run), tagList, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating note: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if edit {
+		editNote(noteManager, note, 0)
+		return
+	}
+	if quiet {
+		fmt.Println(note.ID)
+		return
+	}
+	fmt.Printf("Note created: %s\n", note.Title)
+	fmt.Printf("ID: %s\n", note.ID)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// gcCmd represents the gc command
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Archive expired notes",
+	Long: `Archive notes that have expired, and report what was moved. A note expires on the
+date in its expires field (burh meta set ID expires 2026-12-31), or when it matches a
+retention rule from the config, such as notes tagged scratch that have gone unchanged
+for 30 days:
+
+  retention:
+    - tag: scratch
+      after: 30d
+
+A note's own expires date takes precedence over the rules. Locked notes are never
+archived. Archived notes are moved into the "` + notes.ArchiveDirName + `" subdirectory of their notes
+directory. --dry-run only prints the report and the files that would move.`,
+	Args: cobra.NoArgs,
+	Run:  runGC,
+}
+
+func init() {
+	honorsDryRun(gcCmd)
+}
+
+func runGC(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	now := time.Now()
+	var rules []notes.RetentionRule
+	for _, rule := range cfg.Retention {
+		cutoff, err := notes.AgeCutoff(rule.After, now)
+		if err != nil || rule.Tag == "" {
+			fmt.Fprintf(os.Stderr, "Error: invalid retention rule {tag: %q, after: %q} in config\n", rule.Tag, rule.After)
+			os.Exit(exitUsage)
+		}
+		rules = append(rules, notes.RetentionRule{Tag: rule.Tag, After: rule.After, Cutoff: cutoff})
+	}
+
+	expired, problems, err := noteManager.ExpiredNotes(rules, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing notes: %v\n", err)
+		os.Exit(exitIO)
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", problem)
+	}
+
+	if len(expired) == 0 {
+		fmt.Println("No expired notes.")
+		return
+	}
+
+	idStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#88C0D0"))
+	reasonStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+
+	archived, failed := 0, 0
+	for _, e := range expired {
+		if !dryRun {
+			if err := noteManager.ArchiveNote(e.Note); err != nil {
+				fmt.Printf("Error archiving %s: %v\n", e.Note.ID, err)
+				failed++
+				continue
+			}
+		}
+		fmt.Printf("%s  %s\n", idStyle.Render(e.Note.ID), e.Note.Title)
+		fmt.Printf("    %s\n", reasonStyle.Render(e.Reason))
+		if dryRun {
+			printDryRun("move", e.Note.Path(), filepath.Join(e.Note.Dir, notes.ArchiveDirName, e.Note.Filename))
+		}
+		archived++
+	}
+
+	verb := "Archived"
+	if dryRun {
+		verb = "Would archive"
+	}
+	fmt.Printf("\n%s %d notes.\n", verb, archived)
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
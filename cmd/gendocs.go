@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+var genDocsDir string
+
+// genDocsCmd represents the gen-docs command
+var genDocsCmd = &cobra.Command{
+	Use:   "gen-docs",
+	Short: "Generate man pages and markdown command reference",
+	Long: `Generate man(1) pages and a markdown command reference for every burh command.
+Man pages are written to <dir>/man1 and markdown files to <dir>/md.`,
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	Run:    runGenDocs,
+}
+
+func init() {
+	genDocsCmd.Flags().StringVarP(&genDocsDir, "dir", "d", "docs", "Output directory")
+	skipsConfig(genDocsCmd)
+}
+
+func runGenDocs(cmd *cobra.Command, args []string) {
+	manDir := filepath.Join(genDocsDir, "man1")
+	mdDir := filepath.Join(genDocsDir, "md")
+
+	for _, dir := range []string{manDir, mdDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating %s: %v\n", dir, err)
+			os.Exit(exitIO)
+		}
+	}
+
+	header := &doc.GenManHeader{
+		Title:   "BURH",
+		Section: "1",
+		Source:  "burh",
+		Manual:  "Burh Manual",
+	}
+
+	rootCmd.DisableAutoGenTag = true
+
+	if err := doc.GenManTree(rootCmd, header, manDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating man pages: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if err := doc.GenMarkdownTree(rootCmd, mdDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating markdown reference: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if !quiet {
+		fmt.Printf("Man pages written to %s\n", manDir)
+		fmt.Printf("Markdown reference written to %s\n", mdDir)
+	}
+}
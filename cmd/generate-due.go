@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"burh/config"
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateAhead  string
+	generateSeries string
+)
+
+// generateDueCmd represents the generate-due command
+var generateDueCmd = &cobra.Command{
+	Use:   "generate-due",
+	Short: "Create the upcoming notes of recurring series",
+	Long: `Create the next note of each series in the config's series list, such as a
+weekly review every Monday, unless it already exists. Each note is tagged with the
+series name, starts with its date and a link to the note before it, and is filled
+from the series' template. --ahead also creates the notes due within a period,
+e.g. 4w; run it from cron to keep the next notes ready.`,
+	Example: `  burh generate-due
+  burh generate-due --ahead 4w
+  burh generate-due --series "1:1 with Sam"`,
+	Args: cobra.NoArgs,
+	Run:  runGenerateDue,
+}
+
+func init() {
+	generateDueCmd.Flags().StringVar(&generateAhead, "ahead", "", "Also create the notes due within this period, e.g. 2w or 1m")
+	generateDueCmd.Flags().StringVar(&generateSeries, "series", "", "Only the series with this name")
+}
+
+func runGenerateDue(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	now := time.Now()
+	through := now
+	if generateAhead != "" {
+		var err error
+		if through, err = notes.AddPeriod(generateAhead, now); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	found := false
+	created := 0
+	for _, entry := range cfg.Series {
+		if generateSeries != "" && !strings.EqualFold(entry.Name, generateSeries) {
+			continue
+		}
+		found = true
+		series, err := seriesFromConfig(entry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+
+		list, err := noteManager.GenerateDue(series, now, through)
+		for _, note := range list {
+			if quiet {
+				fmt.Println(note.ID)
+			} else {
+				fmt.Printf("Created %s (%s)\n", note.Title, note.ID)
+			}
+		}
+		created += len(list)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating %s notes: %v\n", series.Name, err)
+			os.Exit(exitIO)
+		}
+	}
+
+	if !found {
+		if generateSeries != "" {
+			fmt.Fprintf(os.Stderr, "Error: no series named %q in the config\n", generateSeries)
+			os.Exit(exitNotFound)
+		}
+		fmt.Fprintln(os.Stderr, "No series in the config; add them under series: (see the README).")
+		return
+	}
+	if created == 0 && !quiet {
+		fmt.Println("Nothing due; the next notes already exist.")
+	}
+}
+
+// seriesFromConfig checks a series from the config and fills in its defaults
+func seriesFromConfig(entry config.Series) (notes.Series, error) {
+	if strings.TrimSpace(entry.Name) == "" {
+		return notes.Series{}, fmt.Errorf("a series in the config has no name")
+	}
+	schedule, err := notes.ParseSchedule(entry.Every, entry.Start)
+	if err != nil {
+		return notes.Series{}, fmt.Errorf("series %q: %w", entry.Name, err)
+	}
+	series := notes.Series{Name: entry.Name, Schedule: schedule, Title: entry.Title, Tags: entry.Tags, Format: entry.Format, Template: entry.Template}
+	if series.Title == "" {
+		series.Title = entry.Name + " {date}"
+	}
+	if series.Format == "" {
+		series.Format = "md"
+	}
+	if !notes.WritableFormat(series.Format) {
+		return notes.Series{}, fmt.Errorf("series %q: format must be one of %s", entry.Name, strings.Join(notes.FormatNames(), ", "))
+	}
+	return series, nil
+}
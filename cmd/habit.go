@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	habitDays int
+	habitDate string
+	habitNew  bool
+)
+
+// habitCmd represents the habit command
+var habitCmd = &cobra.Command{
+	Use:   "habit",
+	Short: "Track habits and show streaks",
+	Long: `Track daily habits in a habits note: a note tagged "habits" with a heading per
+habit and a dated list item for each day it was done, e.g.
+
+  * water
+  - 2024-06-01
+  - 2024-06-02
+
+Without a subcommand, shows each habit's current and longest streak and a grid of
+the last --days days. Habits listed under habits in the config are shown even
+before they are first logged.`,
+	Args: cobra.NoArgs,
+	Run:  runHabitStatus,
+}
+
+// habitDoneCmd represents the habit done command
+var habitDoneCmd = &cobra.Command{
+	Use:   "done [habit]",
+	Short: "Log a habit as done today",
+	Args:  cobra.ExactArgs(1),
+	Run:   runHabitDone,
+}
+
+func init() {
+	habitCmd.AddCommand(habitDoneCmd)
+
+	habitCmd.Flags().IntVar(&habitDays, "days", 28, "Number of days shown in the grid")
+	habitDoneCmd.Flags().StringVar(&habitDate, "date", "", "Day the habit was done, YYYY-MM-DD (default today)")
+	habitDoneCmd.Flags().BoolVar(&habitNew, "new", false, "Start tracking a habit that is not in the config or the habits note")
+}
+
+// trackedHabits returns the logged habits plus the configured ones not logged yet
+func trackedHabits(noteManager *notes.Manager, configured []string) ([]notes.Habit, error) {
+	habits, err := noteManager.Habits()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range configured {
+		if _, ok := findHabit(habits, name); !ok {
+			habits = append(habits, notes.Habit{Name: name})
+		}
+	}
+	return habits, nil
+}
+
+// findHabit finds a habit by name, ignoring case
+func findHabit(habits []notes.Habit, name string) (notes.Habit, bool) {
+	for _, habit := range habits {
+		if strings.EqualFold(habit.Name, strings.TrimSpace(name)) {
+			return habit, true
+		}
+	}
+	return notes.Habit{}, false
+}
+
+func runHabitDone(cmd *cobra.Command, args []string) {
+	day := time.Now()
+	if habitDate != "" {
+		var err error
+		day, err = time.ParseInLocation("2006-01-02", habitDate, time.Local)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid date %q (expected YYYY-MM-DD)\n", habitDate)
+			os.Exit(exitUsage)
+		}
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	habits, err := trackedHabits(noteManager, cfg.Habits)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading habits: %v\n", err)
+		os.Exit(exitIO)
+	}
+	name := args[0]
+	if habit, ok := findHabit(habits, name); ok {
+		name = habit.Name
+	} else if !habitNew {
+		fmt.Fprintf(os.Stderr, "Error: unknown habit %q (add it to habits in the config, or pass --new)\n", name)
+		os.Exit(exitNotFound)
+	}
+
+	if _, err := noteManager.LogHabit(name, day, cfg.HabitsFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	habits, err = noteManager.Habits()
+	if err == nil {
+		if habit, ok := findHabit(habits, name); ok {
+			fmt.Printf("Logged %s for %s (%d-day streak)\n", habit.Name, day.Format("2006-01-02"), habit.Streak(time.Now()))
+			return
+		}
+	}
+	fmt.Printf("Logged %s for %s\n", name, day.Format("2006-01-02"))
+}
+
+func runHabitStatus(cmd *cobra.Command, args []string) {
+	if habitDays < 1 {
+		fmt.Fprintln(os.Stderr, "Error: --days must be at least 1")
+		os.Exit(exitUsage)
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	habits, err := trackedHabits(noteManager, cfg.Habits)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading habits: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if len(habits) == 0 {
+		fmt.Println("No habits yet. Log one with: burh habit done NAME --new")
+		return
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	doneStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#A3BE8C"))
+	missedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+
+	nameWidth := 5
+	for _, habit := range habits {
+		nameWidth = max(nameWidth, len([]rune(habit.Name)))
+	}
+
+	today := time.Now()
+	first := today.AddDate(0, 0, -(habitDays - 1))
+	fmt.Println(headerStyle.Render(fmt.Sprintf("%-*s  %6s  %4s  %s to %s", nameWidth, "Habit", "Streak", "Best",
+		first.Format("Jan 2"), today.Format("Jan 2"))))
+	for _, habit := range habits {
+		var grid strings.Builder
+		for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+			if habit.DoneOn(day) {
+				grid.WriteString(doneStyle.Render("■"))
+			} else {
+				grid.WriteString(missedStyle.Render("·"))
+			}
+		}
+		fmt.Printf("%-*s  %6d  %4d  %s\n", nameWidth, habit.Name, habit.Streak(today), habit.LongestStreak(), grid.String())
+	}
+}
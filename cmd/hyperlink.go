@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// hyperlinkTerminals are the TERM_PROGRAM values of terminals known to open OSC 8
+// hyperlinks
+var hyperlinkTerminals = []string{"iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper", "rio"}
+
+// hyperlinksOn checks if note titles are printed as links to their files: never
+// for plain or piped output, always with hyperlinks: always, and with auto only
+// in terminals known to support them
+func hyperlinksOn() bool {
+	mode := getConfig().Hyperlinks
+	if plainLayout || mode == "never" {
+		return false
+	}
+	if mode == "always" {
+		return true
+	}
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return false
+	}
+
+	for _, program := range hyperlinkTerminals {
+		if os.Getenv("TERM_PROGRAM") == program {
+			return true
+		}
+	}
+	if vte, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && vte >= 5000 {
+		return true // GNOME Terminal and other VTE terminals
+	}
+	if os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("WT_SESSION") != "" || os.Getenv("KONSOLE_VERSION") != "" {
+		return true
+	}
+	termName := os.Getenv("TERM")
+	return strings.Contains(termName, "kitty") || strings.HasPrefix(termName, "foot") || termName == "alacritty"
+}
+
+// hyperlink makes text an OSC 8 link to a file, which supporting terminals open on
+// click, when hyperlinks are on
+func hyperlink(text, path string) string {
+	if !hyperlinksOn() {
+		return text
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return text
+	}
+	target := url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}
+	if !strings.HasPrefix(target.Path, "/") {
+		target.Path = "/" + target.Path // Windows drive letters, e.g. /C:/notes
+	}
+	if host, err := os.Hostname(); err == nil {
+		target.Host = host
+	}
+	return "\x1b]8;;" + target.String() + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"burh/notes"
+	"burh/share"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	icalOut         string
+	icalIncludeDone bool
+	icalServe       string
+	icalToken       string
+)
+
+// icalCmd represents the ical command
+var icalCmd = &cobra.Command{
+	Use:   "ical",
+	Short: "Export deadlines and scheduled items as an iCalendar feed",
+	Long: `Collect the dated items in your notes and write them as an iCalendar (.ics) feed
+that calendar apps can import or subscribe to. Items are:
+
+  - org DEADLINE: and SCHEDULED: timestamps, titled after their heading
+  - due:YYYY-MM-DD, scheduled:YYYY-MM-DD (optionally with THH:MM), and @due(YYYY-MM-DD)
+    in Markdown and text lines, titled after the rest of the line
+  - a "due" metadata field, titled after the note
+
+Finished items (org DONE headings, checked [x] tasks) are left out unless --include-done
+is given.
+
+With --serve, the feed is served at a random, unguessable URL ending in /ical, and
+rebuilt on every request. It only listens on this machine unless the address names
+another interface, e.g. 0.0.0.0:8080; since the URL is all that protects the feed,
+give --token to keep the same one when subscribing from another device.`,
+	Run: runICal,
+}
+
+func init() {
+	icalCmd.Flags().StringVarP(&icalOut, "out", "o", "", "File to write the feed to (default stdout)")
+	icalCmd.Flags().BoolVar(&icalIncludeDone, "include-done", false, "Include finished items")
+	icalCmd.Flags().StringVar(&icalServe, "serve", "", "Serve the feed at ADDR instead, e.g. :8080 (on 127.0.0.1 unless a host is given)")
+	icalCmd.Flags().StringVar(&icalToken, "token", "", "Secret path the served feed is at (default random)")
+	icalCmd.MarkFlagsMutuallyExclusive("out", "serve")
+}
+
+func runICal(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	if icalServe != "" {
+		serveICal(noteManager)
+		return
+	}
+
+	feed, err := buildICal(noteManager)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if icalOut == "" {
+		fmt.Print(feed)
+		return
+	}
+	if err := os.WriteFile(icalOut, []byte(feed), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", icalOut, err)
+		os.Exit(exitIO)
+	}
+}
+
+// serveICal serves the feed at a secret path until interrupted
+func serveICal(noteManager *notes.Manager) {
+	host, port, err := net.SplitHostPort(icalServe)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid --serve address %q: %v\n", icalServe, err)
+		os.Exit(exitUsage)
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	token := icalToken
+	if token == "" {
+		if token, err = share.NewToken(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitIO)
+		}
+	}
+	path := "/" + url.PathEscape(token) + "/ical"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path || r.Method != http.MethodGet {
+			http.NotFound(w, r)
+			return
+		}
+		feed, err := buildICal(noteManager)
+		if err != nil {
+			http.Error(w, "failed to build the calendar", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		io.WriteString(w, feed)
+	})
+
+	addr := net.JoinHostPort(host, port)
+	if !quiet {
+		fmt.Printf("Serving the calendar at http://%s%s (ctrl+c to stop)\n", addr, path)
+	}
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	if err := server.ListenAndServe(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+}
+
+// buildICal reads every note and renders its dated items as an iCalendar feed
+func buildICal(noteManager *notes.Manager) (string, error) {
+	all, err := noteManager.ListNotes()
+	if err != nil {
+		return "", fmt.Errorf("failed to list notes: %w", err)
+	}
+	if err := noteManager.LoadContents(all); err != nil {
+		return "", fmt.Errorf("failed to read notes: %w", err)
+	}
+
+	var items []notes.Item
+	for _, item := range notes.ScheduledItems(all) {
+		if item.Done && !icalIncludeDone {
+			continue
+		}
+		items = append(items, item)
+	}
+	return notes.ICal(items, time.Now()), nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var insertHeading string
+
+// insertCmd represents the insert command
+var insertCmd = &cobra.Command{
+	Use:   "insert [id] [text]",
+	Short: "Insert text into an existing note",
+	Long: `Insert text into an existing note.
+Without --heading the text is prepended to the note content.
+With --heading the text is added to the end of that section, using org
+headings (*) for .org notes and markdown headings (#) otherwise.
+The heading is created at the end of the note if it is missing.
+With link_titles on in the config, bare URLs in the text are written as links
+titled after their pages.`,
+	Args: cobra.ExactArgs(2),
+	Run:  runInsert,
+}
+
+func init() {
+	insertCmd.Flags().StringVar(&insertHeading, "heading", "", "Heading to insert the text under")
+}
+
+func runInsert(cmd *cobra.Command, args []string) {
+	id, text := args[0], args[1]
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+	note, err := noteManager.GetNote(id)
+	if err != nil {
+		fmt.Printf("Error inserting into note: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	if cfg.LinkTitles {
+		text = withLinkTitles(cfg, text, note.Format)
+	}
+
+	if insertHeading != "" {
+		_, err = noteManager.InsertUnderHeading(note, insertHeading, text)
+	} else {
+		_, err = noteManager.PrependContent(note, text)
+	}
+	if err != nil {
+		fmt.Printf("Error inserting into note: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	if insertHeading != "" {
+		fmt.Printf("Inserted text under '%s' in note %s\n", insertHeading, note.ID)
+		return
+	}
+	fmt.Printf("Prepended text to note %s\n", note.ID)
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	launcherFormat string
+	launcherRun    string
+	launcherLimit  int
+)
+
+// Prefixes of the arguments launcher items pass back to --run
+const (
+	launcherOpen   = "note:"
+	launcherCreate = "new:"
+)
+
+// launcherCmd represents the launcher command
+var launcherCmd = &cobra.Command{
+	Use:   "launcher [query]",
+	Short: "Search and capture notes from Alfred, Raycast, or rofi",
+	Long: `Print the notes matching a query, or the most recent ones without a query, in the
+format a keyboard launcher reads, followed by an item that creates a note from the
+query as 'burh --quick' would. Each item carries an argument to pass back to
+'burh launcher --run': "note:ID" opens the note in the editor, "new:TEXT" creates it.
+
+  alfred   Script Filter JSON; connect the Script Filter to a Run Script action
+           running: burh launcher --run "{query}"
+  raycast  a JSON array of items with title, subtitle, path, and arg, for a
+           Raycast script command or extension to show and pass back
+  rofi     rofi script mode; rofi -show burh -modi "burh:burh launcher --format rofi"
+           handles selecting and creating by itself`,
+	Example: `  burh launcher --format alfred "meeting notes"
+  burh launcher --run note:20241201_143022_meeting_notes
+  rofi -show burh -modi "burh:burh launcher --format rofi"`,
+	Run: runLauncher,
+}
+
+func init() {
+	launcherCmd.Flags().StringVar(&launcherFormat, "format", "alfred", "Output format: alfred, raycast, or rofi")
+	launcherCmd.Flags().StringVar(&launcherRun, "run", "", "Run the action of a selected item (note:ID or new:TEXT)")
+	launcherCmd.Flags().IntVarP(&launcherLimit, "limit", "n", 50, "Maximum number of notes to list")
+}
+
+// launcherItem is a note, or the create action, to show in a launcher
+type launcherItem struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+	Arg      string `json:"arg"`
+	Path     string `json:"path,omitempty"`
+}
+
+func runLauncher(cmd *cobra.Command, args []string) {
+	query := strings.TrimSpace(strings.Join(args, " "))
+
+	// rofi runs the script again with the selected line, and says how in ROFI_RETV:
+	// 1 for an entry, whose argument is in ROFI_INFO, and 2 for typed text
+	if launcherFormat == "rofi" && launcherRun == "" {
+		switch os.Getenv("ROFI_RETV") {
+		case "1":
+			launcherRun = os.Getenv("ROFI_INFO")
+		case "2":
+			launcherRun = launcherCreate + query
+		}
+	}
+	if launcherRun != "" {
+		runLauncherAction(launcherRun)
+		return
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	var noteList []*notes.Note
+	var err error
+	if query == "" {
+		noteList, err = noteManager.ListNotes()
+		sort.SliceStable(noteList, func(i, j int) bool { return noteList[i].Modified.After(noteList[j].Modified) })
+	} else {
+		noteList, err = noteManager.SearchNotes(query)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	if launcherLimit > 0 && len(noteList) > launcherLimit {
+		noteList = noteList[:launcherLimit]
+	}
+
+	items := make([]launcherItem, 0, len(noteList)+1)
+	for _, note := range noteList {
+		subtitle := note.Created.Format("2006-01-02") + "  " + note.Format
+		if tags := strings.TrimSpace(strings.Join(note.Tags, ", ")); tags != "" {
+			subtitle += "  " + tags
+		}
+		items = append(items, launcherItem{Title: note.Title, Subtitle: subtitle, Arg: launcherOpen + note.ID, Path: note.Path()})
+	}
+	if query != "" {
+		title, _, _ := notes.ParseQuickCapture(query)
+		items = append(items, launcherItem{Title: "Create note: " + title, Subtitle: "New note in the inbox", Arg: launcherCreate + query})
+	}
+
+	if err := printLauncherItems(items); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+}
+
+// printLauncherItems writes items in the --format a launcher reads
+func printLauncherItems(items []launcherItem) error {
+	switch launcherFormat {
+	case "alfred":
+		type alfredItem struct {
+			UID          string `json:"uid,omitempty"`
+			Title        string `json:"title"`
+			Subtitle     string `json:"subtitle"`
+			Arg          string `json:"arg"`
+			Autocomplete string `json:"autocomplete,omitempty"`
+		}
+		out := struct {
+			Items []alfredItem `json:"items"`
+		}{Items: []alfredItem{}}
+		for _, item := range items {
+			ai := alfredItem{Title: item.Title, Subtitle: item.Subtitle, Arg: item.Arg}
+			if item.Path != "" {
+				ai.UID = strings.TrimPrefix(item.Arg, launcherOpen) // Lets Alfred learn which notes are picked
+				ai.Autocomplete = item.Title
+			}
+			out.Items = append(out.Items, ai)
+		}
+		return json.NewEncoder(os.Stdout).Encode(out)
+	case "raycast":
+		return json.NewEncoder(os.Stdout).Encode(items)
+	case "rofi":
+		// Each entry's argument rides along as its info, which rofi hands back in ROFI_INFO
+		fmt.Print("\x00prompt\x1fnote\n")
+		for _, item := range items {
+			if strings.HasPrefix(item.Arg, launcherCreate) {
+				continue // rofi creates from typed text itself (ROFI_RETV=2)
+			}
+			fmt.Printf("%s\x00info\x1f%s\n", strings.ReplaceAll(item.Title, "\n", " "), item.Arg)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown launcher format %q (use alfred, raycast, or rofi)", launcherFormat)
+}
+
+// runLauncherAction opens or creates the note a launcher item's argument names
+func runLauncherAction(arg string) {
+	switch {
+	case strings.HasPrefix(arg, launcherOpen):
+		cfg := getConfig()
+		noteManager := newNoteManager(cfg)
+		note, err := noteManager.GetNote(strings.TrimPrefix(arg, launcherOpen))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		editNote(noteManager, note, 0)
+	case strings.HasPrefix(arg, launcherCreate):
+		text := strings.TrimSpace(strings.TrimPrefix(arg, launcherCreate))
+		if text == "" {
+			fmt.Fprintln(os.Stderr, "Error: nothing to capture")
+			os.Exit(exitUsage)
+		}
+		runQuickCapture(text)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown launcher action %q (expected note:ID or new:TEXT)\n", arg)
+		os.Exit(exitUsage)
+	}
+}
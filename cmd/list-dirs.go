@@ -29,6 +29,10 @@ func runListDirs(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Notes directories (%d total):\n", len(cfg.NotesDirs))
 	for i, dir := range cfg.NotesDirs {
+		if cfg.IsReadOnlyDir(dir) {
+			fmt.Printf("  %d. %s (read-only)\n", i+1, dir)
+			continue
+		}
 		fmt.Printf("  %d. %s\n", i+1, dir)
 	}
 }
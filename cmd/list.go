@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"burh/i18n"
 	"burh/notes"
 
 	"github.com/charmbracelet/lipgloss"
@@ -14,6 +15,8 @@ import (
 var (
 	showContent bool
 	showTags    bool
+	groupBy     string
+	listAll     bool
 )
 
 // listCmd represents the list command
@@ -21,7 +24,10 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all notes",
 	Long: `List all notes in the notes directory.
-You can optionally show content and tags for each note.`,
+You can optionally show content and tags for each note.
+
+default_view in the config can leave notes out and set their order; --all lists
+every note in the order they are read.`,
 	Run: runList,
 }
 
@@ -31,58 +37,140 @@ func init() {
 	// Local flags
 	listCmd.Flags().BoolVarP(&showContent, "content", "c", false, "Show note content")
 	listCmd.Flags().BoolVarP(&showTags, "tags", "t", false, "Show note tags")
+	addOutputFlags(listCmd)
+	addPageFlags(listCmd)
+	addExcludeFlags(listCmd)
+	listCmd.Flags().StringVar(&groupBy, "group-by", "", "Group notes under headers by tag, month, dir, or format")
+	listCmd.Flags().BoolVarP(&listAll, "all", "a", false, "List every note, ignoring default_view in the config")
 }
 
 func runList(cmd *cobra.Command, args []string) {
 	// Get config
 	cfg := getConfig()
+	checkOutputFields(cfg)
 
 	// Create note manager with all directories
-	noteManager := notes.NewManagerWithDirs(cfg.NotesDirs)
+	noteManager := newNoteManager(cfg)
+	splitFormatFlag()
+
+	viewApplied := !listAll && applyDefaultView(noteManager, cfg)
 
 	// List notes
-	notes, err := noteManager.ListNotes()
+	noteList, err := noteManager.ListNotes()
 	if err != nil {
-		fmt.Printf("Error listing notes: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "Error listing notes: %v\n", err)
+		os.Exit(exitIO)
 	}
 
-	if len(notes) == 0 {
-		fmt.Println("No notes found.")
+	noteList = filterNoteFormats(noteList)
+	if viewApplied && cfg.DefaultView.Sort != "" {
+		notes.SortNotes(noteList, cfg.DefaultView.Sort, cfg.DefaultView.Descending)
+	}
+	total := len(noteList)
+	noteList = paginate(noteList)
+
+	if showContent || outputNeedsContent() {
+		if err := noteManager.LoadContents(noteList); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading notes: %v\n", err)
+			os.Exit(exitIO)
+		}
+	}
+
+	if printMachineReadable(noteList) {
 		return
 	}
 
-	heading := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).Render(fmt.Sprintf("Found %d notes", len(notes)))
-	fmt.Printf("%s\n\n", heading)
+	if quiet {
+		printIDs(noteList)
+		return
+	}
 
-	for i, note := range notes {
-		ts := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render(note.Created.Format("2006-01-02 15:04"))
-		fmtTag := lipgloss.NewStyle().Foreground(lipgloss.Color("#81A1C1")).Render("[" + note.Format + "]")
-		title := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true).Render(note.Title)
-		fmt.Printf("%2d. %s  %s  %s\n", i+1, ts, fmtTag, title)
+	if len(noteList) == 0 {
+		fmt.Println(i18n.T("No notes found."))
+		return
+	}
+
+	var groups []notes.Group
+	if groupBy != "" {
+		groups, err = notes.GroupNotes(noteList, groupBy)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	finishPager := startPager()
+	defer finishPager()
 
-		if showTags && len(note.Tags) > 0 {
-			// Truncate tags to show only first 6
-			tagsToShow := note.Tags
-			if len(note.Tags) > 6 {
-				tagsToShow = note.Tags[:6]
+	headingText := i18n.T("Found %d notes", total)
+	if len(noteList) < total {
+		headingText = i18n.T("Showing %d-%d of %d notes", pageOffset+1, pageOffset+len(noteList), total)
+	}
+	heading := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).Render(headingText)
+	if !plainLayout {
+		fmt.Printf("%s\n\n", heading)
+	}
+
+	if groupBy != "" {
+		for _, group := range groups {
+			groupHeading := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0")).Render(fmt.Sprintf("%s: %s (%d)", groupBy, group.Name, len(group.Notes)))
+			if plainLayout {
+				fmt.Println(groupHeading)
+			} else {
+				fmt.Printf("%s\n\n", groupHeading)
 			}
-			tagsStr := strings.Join(tagsToShow, ", ")
-			if len(note.Tags) > 6 {
-				tagsStr += "..."
+			for i, note := range group.Notes {
+				printListEntry(i+1, note)
 			}
-			fmt.Printf("    %s %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("Tags:"), tagsStr)
 		}
+		return
+	}
 
-		if showContent && note.Content != "" {
-			// Truncate content if too long
-			content := note.Content
-			if len(content) > 100 {
-				content = content[:100] + "..."
-			}
-			fmt.Printf("    %s %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("Content:"), content)
+	for i, note := range noteList {
+		printListEntry(pageOffset+i+1, note)
+	}
+}
+
+// printListEntry prints a single note in the list output
+func printListEntry(n int, note *notes.Note) {
+	if plainLayout {
+		printPlainEntry(note, showContent)
+		return
+	}
+	ts := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render(note.Created.Format("2006-01-02 15:04"))
+	display := getConfig().Display
+	fmtTag := lipgloss.NewStyle().Foreground(lipgloss.Color("#81A1C1")).Render("[" + display.FormatLabel(note.Format) + "]")
+	title := hyperlink(lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true).Render(note.Title), note.Path())
+	if note.Locked {
+		title = "🔒 " + title
+	}
+	fmt.Printf("%2d. %s  %s  %s\n", n, ts, fmtTag, title)
+
+	if showTags && len(note.Tags) > 0 {
+		// Truncate tags to show only first 6
+		tagsToShow := note.Tags
+		if len(note.Tags) > 6 {
+			tagsToShow = note.Tags[:6]
+		}
+		tagLabels := make([]string, len(tagsToShow))
+		for i, tag := range tagsToShow {
+			tagLabels[i] = display.TagLabel(tag)
 		}
+		tagsStr := strings.Join(tagLabels, ", ")
+		if len(note.Tags) > 6 {
+			tagsStr += "..."
+		}
+		fmt.Printf("    %s %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("Tags:"), tagsStr)
+	}
 
-		fmt.Printf("    %s %s\n\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("ID:"), note.ID)
+	if showContent && note.Content != "" {
+		// Truncate content if too long
+		content := note.Content
+		if len(content) > 100 {
+			content = content[:100] + "..."
+		}
+		fmt.Printf("    %s %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("Content:"), content)
 	}
+
+	fmt.Printf("    %s %s\n\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("ID:"), note.ID)
 }
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// lockCmd represents the lock command
+var lockCmd = &cobra.Command{
+	Use:   "lock [id...]",
+	Short: "Lock notes against changes",
+	Long: `Lock one or more notes by ID, or every note matching --query.
+Locked notes cannot be updated or deleted by burh until they are unlocked.`,
+	Run: func(cmd *cobra.Command, args []string) { runLock(args, true) },
+}
+
+// unlockCmd represents the unlock command
+var unlockCmd = &cobra.Command{
+	Use:   "unlock [id...]",
+	Short: "Unlock notes",
+	Long:  `Unlock one or more notes by ID, or every note matching --query, so they can be changed again.`,
+	Run:   func(cmd *cobra.Command, args []string) { runLock(args, false) },
+}
+
+func init() {
+	addQueryFlag(lockCmd)
+	addQueryFlag(unlockCmd)
+}
+
+func runLock(args []string, locked bool) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	selected, err := selectNotes(noteManager, args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	action := "Locked"
+	if !locked {
+		action = "Unlocked"
+	}
+
+	failed := 0
+	for _, note := range selected {
+		if err := noteManager.SetLocked(note, locked); err != nil {
+			fmt.Printf("Error updating %s: %v\n", note.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s %s\n", action, note.ID)
+	}
+
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
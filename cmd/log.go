@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/config"
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logAction string
+	logLimit  int
+)
+
+// logCmd represents the log command
+var logCmd = &cobra.Command{
+	Use:   "log [id]",
+	Short: "Show the audit log of changes to notes",
+	Long: `Show who changed which note when, most recent first: every create, update, delete,
+and move made by burh, and every undo and redo. Retitles show the old title and ID,
+so a note that seems to have disappeared can be followed. Give a note ID (or the
+start of one) to see only its history, under its current or an earlier ID.
+
+The log is appended to .burh_audit.log next to the config file; set audit_log to
+false to stop it.`,
+	Example: `  burh log
+  burh log 20240101_120000
+  burh log --action delete --limit 10`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runLog,
+}
+
+func init() {
+	logCmd.Flags().StringVarP(&logAction, "action", "a", "", "Only this kind of change: create, update, delete, or move (undos and redos of it too)")
+	logCmd.Flags().IntVarP(&logLimit, "limit", "n", 50, "Most entries to show (0 for no limit)")
+}
+
+func runLog(cmd *cobra.Command, args []string) {
+	entries, err := notes.ReadAuditLog(config.AuditLogPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	var shown []notes.AuditEntry
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if len(args) == 1 && !entry.Matches(args[0]) {
+			continue
+		}
+		if logAction != "" && entry.Action != logAction && !strings.HasSuffix(entry.Action, " "+logAction) {
+			continue
+		}
+		shown = append(shown, entry)
+		if logLimit > 0 && len(shown) == logLimit {
+			break
+		}
+	}
+	if len(shown) == 0 {
+		fmt.Fprintln(os.Stderr, "No changes logged.")
+		os.Exit(exitNotFound)
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+	removedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#BF616A"))
+	for _, entry := range shown {
+		action := fmt.Sprintf("%-11s", entry.Action)
+		if strings.HasSuffix(entry.Action, notes.OpDelete) {
+			action = removedStyle.Render(action)
+		}
+		fmt.Printf("%s  %s  %s  %s  %s\n", mutedStyle.Render(entry.Time.Format("2006-01-02 15:04:05")),
+			mutedStyle.Render(entry.User), action, entry.NoteID, entry.Title)
+		if entry.OldID != "" {
+			fmt.Println(mutedStyle.Render(fmt.Sprintf("    was %s", entry.OldID)))
+		}
+		if entry.OldTitle != "" {
+			fmt.Println(mutedStyle.Render(fmt.Sprintf("    title was '%s'", entry.OldTitle)))
+		}
+	}
+}
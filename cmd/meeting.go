@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	meetingAttendees string
+	meetingDate      string
+	meetingFormat    string
+	meetingTags      string
+	meetingEdit      bool
+	followupPerson   string
+)
+
+// meetingCmd represents the meeting command
+var meetingCmd = &cobra.Command{
+	Use:   "meeting [title]",
+	Short: "Start a meeting note from the meeting template",
+	Long: `Create a note tagged "meeting" with the date and attendees filled in and headings
+for the agenda, notes, and action items. Action items are "- [ ]" items, or lines
+starting with "@name:" to assign them; 'burh followups' collects the open ones.`,
+	Example: `  burh meeting "Standup" --attendees alice,bob --edit`,
+	Args:    cobra.ExactArgs(1),
+	Run:     runMeeting,
+}
+
+// followupsCmd represents the followups command
+var followupsCmd = &cobra.Command{
+	Use:   "followups",
+	Short: "List open action items from meeting notes by person",
+	Long: `List the open action items of all meeting notes, grouped by the person they are
+assigned to: the name in "@name: ..." lines, or the first @name a "- [ ]" item
+mentions. Items naming nobody are listed as unassigned.`,
+	Args: cobra.NoArgs,
+	Run:  runFollowups,
+}
+
+func init() {
+	meetingCmd.Flags().StringVarP(&meetingAttendees, "attendees", "a", "", "Comma-separated attendees")
+	meetingCmd.Flags().StringVar(&meetingDate, "date", "", "Date of the meeting, YYYY-MM-DD (default today)")
+	meetingCmd.Flags().StringVarP(&meetingFormat, "format", "f", "md", "Note format ("+strings.Join(notes.FormatNames(), ", ")+")")
+	meetingCmd.Flags().StringVarP(&meetingTags, "tags", "g", "", "Comma-separated tags besides meeting")
+	meetingCmd.Flags().BoolVarP(&meetingEdit, "edit", "e", false, "Open the new meeting note in the editor")
+	followupsCmd.Flags().StringVarP(&followupPerson, "person", "p", "", "Only items assigned to this person")
+}
+
+func runMeeting(cmd *cobra.Command, args []string) {
+	if !notes.WritableFormat(meetingFormat) {
+		fmt.Fprintf(os.Stderr, "Error: format must be one of %s\n", strings.Join(notes.FormatNames(), ", "))
+		os.Exit(exitUsage)
+	}
+	date := time.Now()
+	if meetingDate != "" {
+		var err error
+		date, err = time.ParseInLocation("2006-01-02", meetingDate, time.Local)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid date %q (expected YYYY-MM-DD)\n", meetingDate)
+			os.Exit(exitUsage)
+		}
+	}
+
+	var attendees []string
+	for _, name := range strings.Split(meetingAttendees, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			attendees = append(attendees, name)
+		}
+	}
+	tagList := []string{notes.MeetingTag}
+	for _, tag := range strings.Split(meetingTags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" && tag != notes.MeetingTag {
+			tagList = append(tagList, tag)
+		}
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	content := notes.MeetingTemplate(meetingFormat, date, attendees)
+	note, err := noteManager.CreateNote(args[0], content, tagList, meetingFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating meeting note: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if meetingEdit {
+		editNote(noteManager, note, 0)
+		return
+	}
+	if quiet {
+		fmt.Println(note.ID)
+		return
+	}
+	fmt.Printf("Meeting note created: %s (%s)\n", note.Title, date.Format("2006-01-02"))
+	fmt.Printf("ID: %s\n", note.ID)
+}
+
+func runFollowups(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	items, err := noteManager.FollowUps()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading meeting notes: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	// Group by person, ignoring case; unassigned items go last
+	byPerson := map[string][]notes.ActionItem{}
+	names := map[string]string{}
+	var people []string
+	for _, item := range items {
+		key := strings.ToLower(item.Person)
+		if followupPerson != "" && key != strings.ToLower(strings.TrimPrefix(followupPerson, "@")) {
+			continue
+		}
+		if _, ok := byPerson[key]; !ok {
+			names[key] = item.Person
+			if key != "" {
+				people = append(people, key)
+			}
+		}
+		byPerson[key] = append(byPerson[key], item)
+	}
+	sort.Strings(people)
+	if _, ok := byPerson[""]; ok {
+		people = append(people, "")
+	}
+	if len(people) == 0 {
+		fmt.Println("No open action items.")
+		return
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+
+	for i, key := range people {
+		if i > 0 {
+			fmt.Println()
+		}
+		name := "@" + names[key]
+		if key == "" {
+			name = "unassigned"
+		}
+		fmt.Println(headerStyle.Render(fmt.Sprintf("%s (%d)", name, len(byPerson[key]))))
+		for _, item := range byPerson[key] {
+			meeting := fmt.Sprintf("%s, %s", item.Note.Title, notes.MeetingDate(item.Note).Format("2006-01-02"))
+			fmt.Printf("  %s  %s\n", item.Text, mutedStyle.Render(meeting+" "+item.Note.ID))
+		}
+	}
+}
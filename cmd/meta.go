@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// metaCmd represents the meta command
+var metaCmd = &cobra.Command{
+	Use:   "meta",
+	Short: "View and edit custom metadata fields",
+	Long: `View and edit user-defined metadata fields on notes.
+Fields must be declared under metadata_fields in the config file, for example:
+
+  metadata_fields:
+    - name: project
+      type: string
+    - name: source_url
+      type: url
+
+Supported types are string, number, bool, date (YYYY-MM-DD), and url.
+Declared fields can be used as search filters, e.g. burh search "project:acme".`,
+}
+
+// metaSetCmd represents the meta set command
+var metaSetCmd = &cobra.Command{
+	Use:   "set [id] [key] [value]",
+	Short: "Set a metadata field on a note",
+	Long:  `Set a metadata field on a note. Pass an empty value ("") to remove the field.`,
+	Args:  cobra.ExactArgs(3),
+	Run:   runMetaSet,
+}
+
+// metaShowCmd represents the meta show command
+var metaShowCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "Show the metadata fields of a note",
+	Args:  cobra.ExactArgs(1),
+	Run:   runMetaShow,
+}
+
+// metaFieldsCmd represents the meta fields command
+var metaFieldsCmd = &cobra.Command{
+	Use:   "fields",
+	Short: "List the metadata fields declared in the config",
+	Run:   runMetaFields,
+}
+
+func init() {
+	metaCmd.AddCommand(metaSetCmd)
+	metaCmd.AddCommand(metaShowCmd)
+	metaCmd.AddCommand(metaFieldsCmd)
+}
+
+func runMetaSet(cmd *cobra.Command, args []string) {
+	id, key, value := args[0], args[1], args[2]
+
+	cfg := getConfig()
+
+	field, ok := cfg.LookupMetadataField(key)
+	if !ok {
+		fmt.Printf("Error: unknown metadata field '%s' (declare it under metadata_fields in the config)\n", key)
+		os.Exit(exitUsage)
+	}
+
+	if value != "" {
+		if err := field.Validate(value); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.SetMeta(id, field.Name, value)
+	if err != nil {
+		fmt.Printf("Error updating note: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	if value == "" {
+		fmt.Printf("Removed %s from note %s\n", field.Name, note.ID)
+		return
+	}
+	fmt.Printf("Set %s = %s on note %s\n", field.Name, value, note.ID)
+}
+
+func runMetaShow(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	if len(note.Meta) == 0 {
+		fmt.Printf("Note %s has no metadata fields set.\n", note.ID)
+		return
+	}
+
+	fmt.Printf("Metadata for %s:\n", note.ID)
+	for _, field := range cfg.Fields() {
+		if value, ok := note.Meta[strings.ToLower(field.Name)]; ok {
+			fmt.Printf("  %s: %s\n", field.Name, value)
+		}
+	}
+}
+
+func runMetaFields(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+
+	fields := cfg.Fields()
+	fmt.Printf("Metadata fields (%d total):\n", len(fields))
+	for _, field := range fields {
+		fieldType := field.Type
+		if fieldType == "" {
+			fieldType = "string"
+		}
+		fmt.Printf("  %s (%s)\n", field.Name, fieldType)
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"burh/paths"
+
+	"github.com/spf13/cobra"
+)
+
+var moveTo string
+
+// moveCmd represents the move command
+var moveCmd = &cobra.Command{
+	Use:   "move [id...]",
+	Short: "Move notes to another notes directory",
+	Long: `Move one or more notes by ID, or every note matching --query, into another
+of the configured notes directories.`,
+	Run: runMove,
+}
+
+func init() {
+	addQueryFlag(moveCmd)
+	addYesFlag(moveCmd)
+	moveCmd.Flags().StringVar(&moveTo, "to", "", "Configured notes directory to move the notes into (required)")
+	moveCmd.MarkFlagRequired("to")
+}
+
+func runMove(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	// Only allow moving between configured directories so notes stay visible
+	target := ""
+	for _, dir := range cfg.NotesDirs {
+		if paths.Same(dir, moveTo) {
+			target = dir
+			break
+		}
+	}
+	if target == "" {
+		fmt.Printf("Error: %s is not a configured notes directory (see burh list-dirs)\n", moveTo)
+		os.Exit(exitUsage)
+	}
+
+	selected, err := selectNotes(noteManager, args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	if !confirmBatch("move", "Move", selected) {
+		return
+	}
+
+	failed := 0
+	for _, note := range selected {
+		if paths.Same(note.Dir, target) {
+			continue
+		}
+		if err := noteManager.MoveNote(note, target); err != nil {
+			fmt.Printf("Error moving %s: %v\n", note.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Moved %s to %s\n", note.ID, target)
+	}
+
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
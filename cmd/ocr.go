@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var ocrForce bool
+
+// ocrCmd represents the ocr command
+var ocrCmd = &cobra.Command{
+	Use:   "ocr [id...]",
+	Short: "Make text in image notes searchable",
+	Long: `Run the configured OCR command (ocr_command, tesseract by default) on image notes
+and store the text next to each image in a <file>.ocr sidecar, which search reads as
+the note's content. Without IDs every image note is processed. Images whose sidecar
+is newer than the image are skipped unless --force is given.
+
+Image notes are only listed when binary_notes is enabled in the config.`,
+	Run: runOCR,
+}
+
+func init() {
+	ocrCmd.Flags().BoolVar(&ocrForce, "force", false, "Run OCR again even if the stored text is up to date")
+}
+
+func runOCR(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	if !cfg.BinaryNotes {
+		fmt.Fprintln(os.Stderr, "Error: image notes are not listed; set binary_notes: true in the config")
+		os.Exit(exitUsage)
+	}
+	if cfg.OCRCommand == "" {
+		fmt.Fprintln(os.Stderr, "Error: no ocr_command configured")
+		os.Exit(exitUsage)
+	}
+	noteManager := newNoteManager(cfg)
+
+	var selected []*notes.Note
+	for _, id := range args {
+		note, err := noteManager.GetNote(id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		if !notes.IsImage(note) {
+			fmt.Fprintf(os.Stderr, "Error: %s is not an image\n", note.ID)
+			os.Exit(exitUsage)
+		}
+		selected = append(selected, note)
+	}
+	if len(args) == 0 {
+		all, err := noteManager.ListNotes()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing notes: %v\n", err)
+			os.Exit(exitIO)
+		}
+		for _, note := range all {
+			if notes.IsImage(note) {
+				selected = append(selected, note)
+			}
+		}
+	}
+
+	processed, failed := 0, 0
+	for _, note := range selected {
+		ran, err := noteManager.OCRNote(note, cfg.OCRCommand, ocrForce)
+		if err != nil {
+			fmt.Printf("Error reading %s: %v\n", note.ID, err)
+			failed++
+			continue
+		}
+		if !ran {
+			continue
+		}
+		processed++
+		if !quiet {
+			fmt.Printf("Read %s (%d characters)\n", note.ID, len(note.Content))
+		}
+	}
+
+	if !quiet {
+		fmt.Printf("Ran OCR on %d images\n", processed)
+	}
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
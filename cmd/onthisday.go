@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var onThisDayDate string
+
+// onThisDayCmd represents the onthisday command
+var onThisDayCmd = &cobra.Command{
+	Use:   "onthisday",
+	Short: "Show notes created on this day in earlier years",
+	Long: `Show notes created on today's date in earlier years, most recent year first,
+for looking back through journals. --date looks back from another day.`,
+	Args: cobra.NoArgs,
+	Run:  runOnThisDay,
+}
+
+func init() {
+	onThisDayCmd.Flags().StringVar(&onThisDayDate, "date", "", "Day to look back from, YYYY-MM-DD (default today)")
+	addQueryFlag(onThisDayCmd)
+	addOutputFlags(onThisDayCmd)
+	addExcludeFlags(onThisDayCmd)
+}
+
+func runOnThisDay(cmd *cobra.Command, args []string) {
+	day := time.Now()
+	if onThisDayDate != "" {
+		var err error
+		day, err = time.ParseInLocation("2006-01-02", onThisDayDate, time.Local)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid date %q (expected YYYY-MM-DD)\n", onThisDayDate)
+			os.Exit(exitUsage)
+		}
+	}
+
+	cfg := getConfig()
+	checkOutputFields(cfg)
+	noteManager := newNoteManager(cfg)
+	splitFormatFlag()
+
+	var pool []*notes.Note
+	var err error
+	if batchQuery == "" {
+		pool, err = noteManager.ListNotes()
+	} else {
+		pool, err = noteManager.SearchNotes(batchQuery)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing notes: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	found := notes.OnThisDay(filterNoteFormats(pool), day)
+
+	if outputNeedsContent() {
+		if err := noteManager.LoadContents(found); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading notes: %v\n", err)
+			os.Exit(exitIO)
+		}
+	}
+	if printMachineReadable(found) {
+		return
+	}
+	if quiet {
+		printIDs(found)
+		return
+	}
+
+	if len(found) == 0 {
+		fmt.Printf("No notes from %s in earlier years.\n", day.Format("January 2"))
+		return
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+
+	year := 0
+	for _, note := range found {
+		if y := note.Created.Year(); y != year {
+			if year != 0 {
+				fmt.Println()
+			}
+			year = y
+			ago := day.Year() - y
+			label := fmt.Sprintf("%d years ago", ago)
+			if ago == 1 {
+				label = "1 year ago"
+			}
+			fmt.Println(headerStyle.Render(fmt.Sprintf("%s, %s", note.Created.Format("January 2, 2006"), label)))
+		}
+		line := "  " + note.Title
+		if len(note.Tags) > 0 {
+			line += mutedStyle.Render(" [" + strings.Join(note.Tags, ", ") + "]")
+		}
+		fmt.Println(line + " " + mutedStyle.Render(note.ID))
+	}
+}
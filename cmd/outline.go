@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var outlineOpen string
+
+// outlineCmd represents the outline command
+var outlineCmd = &cobra.Command{
+	Use:   "outline [id]",
+	Short: "Show the headings of a note",
+	Long: `Show the heading structure of a note, numbered and indented by level, with the
+line each heading is on. Org notes use "*" headings and other formats "#" headings.
+
+--open opens the note in the editor at a heading, given by its number or text
+(a prefix is enough). Editors such as vim, nano, emacs, helix, and VS Code land on
+the heading's line; others open the note at the top.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runOutline,
+}
+
+func init() {
+	outlineCmd.Flags().StringVar(&outlineOpen, "open", "", "Open the note in the editor at this heading (number or text)")
+}
+
+func runOutline(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	headings, err := noteManager.NoteOutline(note)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if outlineOpen != "" {
+		heading, err := notes.FindHeading(headings, outlineOpen)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitNotFound)
+		}
+		editNote(noteManager, note, heading.Line)
+		return
+	}
+
+	if quiet {
+		for _, h := range headings {
+			fmt.Printf("%d\t%d\t%s\n", h.Line, h.Level, h.Text)
+		}
+		return
+	}
+
+	fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0")).Render(note.Title))
+	if len(headings) == 0 {
+		fmt.Println("  No headings.")
+		return
+	}
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+	for i, h := range headings {
+		indent := strings.Repeat("  ", h.Level-1)
+		fmt.Printf("%3d. %s%s  %s\n", i+1, indent, h.Text, lineStyle.Render(fmt.Sprintf("line %d", h.Line)))
+	}
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"burh/config"
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	outputFormat   string
+	outputFields   string
+	outputNoHeader bool
+
+	// noteFormats are the note formats given to --format, to show only notes in them
+	noteFormats []string
+)
+
+// tsvEscaper keeps every value on a single tab-free line
+var tsvEscaper = strings.NewReplacer("\t", " ", "\n", " ", "\r", "")
+
+// defaultOutputFields are the columns written when --fields is not given
+const defaultOutputFields = "id,created,format,title,tags"
+
+// outputFieldNames are the columns --fields takes besides metadata fields
+var outputFieldNames = []string{"id", "title", "tags", "format", "created", "modified", "filename", "dir", "path", "locked", "content"}
+
+// addOutputFlags registers the machine-readable output flags on a command
+func addOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&outputFormat, "format", "", "Output format: tsv, csv, or json (default is styled text); note formats, e.g. org or md,txt, show only those notes")
+	cmd.Flags().StringVar(&outputFields, "fields", defaultOutputFields, "Comma-separated columns for tsv/csv output")
+	cmd.Flags().BoolVar(&outputNoHeader, "no-header", false, "Omit the header row in tsv/csv output")
+}
+
+// splitFormatFlag separates the note formats in --format, a comma-separated mix
+// of them and an output format, from the output format. Call it after
+// newNoteManager, which registers the binary note formats.
+func splitFormatFlag() {
+	var output []string
+	for _, name := range strings.Split(outputFormat, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := notes.LookupFormat(name); ok {
+			noteFormats = append(noteFormats, name)
+		} else if name != "" {
+			output = append(output, name)
+		}
+	}
+	outputFormat = strings.Join(output, ",")
+}
+
+// inNoteFormats checks if a note is in one of the note formats given to --format,
+// or if none were given
+func inNoteFormats(note *notes.Note) bool {
+	if len(noteFormats) == 0 {
+		return true
+	}
+	for _, name := range noteFormats {
+		if note.Format == name {
+			return true
+		}
+	}
+	return false
+}
+
+// filterNoteFormats keeps the notes in the note formats given to --format
+func filterNoteFormats(list []*notes.Note) []*notes.Note {
+	if len(noteFormats) == 0 {
+		return list
+	}
+	var kept []*notes.Note
+	for _, note := range list {
+		if inNoteFormats(note) {
+			kept = append(kept, note)
+		}
+	}
+	return kept
+}
+
+// printMachineReadable writes notes in the requested output format.
+// It returns false if no machine-readable format was requested.
+func printMachineReadable(results []*notes.Note) bool {
+	if outputFormat == "" {
+		return false
+	}
+
+	var err error
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if results == nil {
+			results = []*notes.Note{}
+		}
+		err = enc.Encode(results)
+	case "tsv":
+		err = writeDelimited(results, func(w []string) error {
+			for i, v := range w {
+				w[i] = tsvEscaper.Replace(v)
+			}
+			_, err := fmt.Println(strings.Join(w, "\t"))
+			return err
+		})
+	case "csv":
+		cw := csv.NewWriter(os.Stdout)
+		err = writeDelimited(results, cw.Write)
+		cw.Flush()
+		if err == nil {
+			err = cw.Error()
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown output format '%s' (use tsv, csv, or json)\n", outputFormat)
+		os.Exit(exitUsage)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		os.Exit(exitIO)
+	}
+	return true
+}
+
+// checkOutputFields exits with a usage error if --fields names a column that is
+// neither a note field nor a metadata field
+func checkOutputFields(cfg *config.Config) {
+	valid := append(append([]string(nil), outputFieldNames...), cfg.MetadataKeys()...)
+	for _, field := range strings.Split(outputFields, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		known := false
+		for _, name := range valid {
+			if field == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			fmt.Fprintf(os.Stderr, "Error: unknown field '%s' in --fields (use %s)\n", field, strings.Join(valid, ", "))
+			os.Exit(exitUsage)
+		}
+	}
+}
+
+// outputNeedsContent reports whether the requested output includes note bodies
+func outputNeedsContent() bool {
+	switch strings.ToLower(outputFormat) {
+	case "json":
+		return true
+	case "tsv", "csv":
+		for _, field := range strings.Split(outputFields, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), "content") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// printIDs writes one note ID per line, for --quiet output
+func printIDs(results []*notes.Note) {
+	for _, note := range results {
+		fmt.Println(note.ID)
+	}
+}
+
+// writeDelimited writes the header and one row per note using the given row writer
+func writeDelimited(results []*notes.Note, writeRow func([]string) error) error {
+	fields := strings.Split(outputFields, ",")
+	for i, field := range fields {
+		fields[i] = strings.ToLower(strings.TrimSpace(field))
+	}
+
+	if !outputNoHeader {
+		if err := writeRow(append([]string(nil), fields...)); err != nil {
+			return err
+		}
+	}
+
+	for _, note := range results {
+		row := make([]string, len(fields))
+		for i, field := range fields {
+			row[i] = noteField(note, field)
+		}
+		if err := writeRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// noteField returns the value of a named column for a note.
+// Names other than outputFieldNames are looked up as metadata fields.
+func noteField(note *notes.Note, field string) string {
+	switch field {
+	case "id":
+		return note.ID
+	case "title":
+		return note.Title
+	case "tags":
+		return strings.Join(note.Tags, ",")
+	case "format":
+		return note.Format
+	case "created":
+		return note.Created.Format("2006-01-02 15:04:05")
+	case "modified":
+		return note.Modified.Format("2006-01-02 15:04:05")
+	case "filename":
+		return note.Filename
+	case "dir":
+		return note.Dir
+	case "path":
+		return note.Path()
+	case "locked":
+		return strconv.FormatBool(note.Locked)
+	case "content":
+		return note.Content
+	default:
+		return note.Meta[field]
+	}
+}
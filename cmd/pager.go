@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	pageLimit  int
+	pageOffset int
+	usePager   bool
+)
+
+// addPageFlags registers the pagination flags on a command
+func addPageFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&pageLimit, "limit", 0, "Show at most this many notes (0 for no limit)")
+	cmd.Flags().IntVar(&pageOffset, "offset", 0, "Skip this many notes before showing results")
+	cmd.Flags().BoolVar(&usePager, "pager", false, "Pipe output through $PAGER when it is taller than the terminal")
+}
+
+// paginate applies --offset and --limit to a list of notes
+func paginate(results []*notes.Note) []*notes.Note {
+	if pageOffset > 0 {
+		if pageOffset >= len(results) {
+			return nil
+		}
+		results = results[pageOffset:]
+	}
+	if pageLimit > 0 && len(results) > pageLimit {
+		results = results[:pageLimit]
+	}
+	return results
+}
+
+// startPager captures stdout when --pager is set. The returned function must be called
+// once output is complete; it sends the captured text through $PAGER if it does not fit
+// on screen, or prints it directly otherwise.
+func startPager() func() {
+	if !usePager || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return func() {}
+	}
+
+	realStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		return func() {}
+	}
+	os.Stdout = w
+
+	var buf bytes.Buffer
+	copied := make(chan struct{})
+	go func() {
+		io.Copy(&buf, r)
+		close(copied)
+	}()
+
+	return func() {
+		w.Close()
+		<-copied
+		os.Stdout = realStdout
+
+		_, height, err := term.GetSize(int(realStdout.Fd()))
+		if err != nil || strings.Count(buf.String(), "\n") < height {
+			realStdout.Write(buf.Bytes())
+			return
+		}
+
+		pager := os.Getenv("PAGER")
+		if pager == "" {
+			pager = "less -R"
+		}
+		fields := strings.Fields(pager)
+		cmd := exec.Command(fields[0], fields[1:]...)
+		cmd.Stdin = &buf
+		cmd.Stdout = realStdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			realStdout.Write(buf.Bytes())
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"burh/clipboard"
+
+	"github.com/spf13/cobra"
+)
+
+// pasteImageCmd represents the paste-image command
+var pasteImageCmd = &cobra.Command{
+	Use:   "paste-image [id]",
+	Short: "Add the image on the clipboard to a note",
+	Long: `Save the image on the clipboard, such as a screenshot, as a PNG file in the
+attachments folder next to the note, and link it at the end of the note: ![](...)
+in markdown, [[file:...]] in org, and the file's path in text notes.`,
+	Example: `  burh paste-image 20260301_091500_design_review`,
+	Args:    cobra.ExactArgs(1),
+	Run:     runPasteImage,
+}
+
+func runPasteImage(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	if _, err := noteManager.GetNote(args[0]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	data, err := clipboard.ReadImage()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, clipboard.ErrNoImage) {
+			os.Exit(exitNotFound)
+		}
+		os.Exit(exitUsage)
+	}
+
+	note, path, err := noteManager.AddImage(args[0], data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if quiet {
+		fmt.Println(path)
+		return
+	}
+	fmt.Printf("Saved %s and linked it in '%s'\n", path, note.Title)
+}
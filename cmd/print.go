@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/notes"
+	"burh/printer"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	printPDF     string
+	printPrinter string
+)
+
+// printCmd represents the print command
+var printCmd = &cobra.Command{
+	Use:   "print [id]",
+	Short: "Print a note",
+	Long: `Send a note to the system printer (via lp) with its title, dates, and tags in a header.
+With --pdf the formatted note is written to a PDF file instead.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runPrint,
+}
+
+func init() {
+	printCmd.Flags().StringVar(&printPDF, "pdf", "", "Write the note to this PDF file instead of printing it")
+	printCmd.Flags().StringVarP(&printPrinter, "printer", "P", "", "Printer to use (default the system's default printer)")
+	printCmd.MarkFlagsMutuallyExclusive("pdf", "printer")
+}
+
+func runPrint(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	if err := noteManager.LoadContent(note); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading note: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	body, _ := notes.RenderContent(note, "plain")
+	doc := printer.Document{
+		Title: note.Title,
+		Header: []string{fmt.Sprintf("Created %s   Modified %s",
+			note.Created.Format("2006-01-02 15:04"), note.Modified.Format("2006-01-02 15:04"))},
+		Body: body,
+	}
+	if len(note.Tags) > 0 {
+		doc.Header = append(doc.Header, "Tags: "+strings.Join(note.Tags, ", "))
+	}
+
+	if printPDF != "" {
+		if err := os.WriteFile(printPDF, doc.PDF(), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing PDF: %v\n", err)
+			os.Exit(exitIO)
+		}
+		if !quiet {
+			fmt.Printf("Wrote '%s' to %s\n", note.Title, printPDF)
+		}
+		return
+	}
+
+	if err := printer.Print(doc, printPrinter); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if !quiet {
+		fmt.Printf("Sent '%s' to the printer\n", note.Title)
+	}
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"burh/config"
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectTag      string
+	projectBranches bool
+	projectContent  string
+	projectTags     string
+	projectFormat   string
+	projectEdit     bool
+	projectBranch   bool
+)
+
+// projectCmd represents the project command
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Keep notes for the git repository you are in",
+	Long: `Associate a git repository with a tag, then create and list its notes from anywhere
+inside it. 'burh project init' picks the tag, the repository's name by default;
+'burh project note' creates notes with the tag, and with --branches also tagged
+with the branch checked out; 'burh project list' lists the notes with the tag.
+The notes are ordinary notes in the notes directories.`,
+}
+
+// projectInitCmd represents the project init command
+var projectInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Associate the current git repository with a tag",
+	Example: `  burh project init
+  burh project init --tag burh-dev --branches`,
+	Args: cobra.NoArgs,
+	Run:  runProjectInit,
+}
+
+// projectNoteCmd represents the project note command
+var projectNoteCmd = &cobra.Command{
+	Use:     "note [title]",
+	Short:   "Create a note tagged for the current repository",
+	Example: `  burh project note "Why the cache is per user" --edit`,
+	Args:    cobra.MinimumNArgs(1),
+	Run:     runProjectNote,
+}
+
+// projectListCmd represents the project list command
+var projectListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the current repository's notes",
+	Args:  cobra.NoArgs,
+	Run:   runProjectList,
+}
+
+func init() {
+	projectInitCmd.Flags().StringVarP(&projectTag, "tag", "g", "", "Tag for the repository's notes (default the repository's name)")
+	projectInitCmd.Flags().BoolVar(&projectBranches, "branches", false, "Also tag notes with the branch checked out")
+	projectNoteCmd.Flags().StringVarP(&projectContent, "content", "c", "", "Note content")
+	projectNoteCmd.Flags().StringVarP(&projectTags, "tags", "t", "", "Comma-separated tags besides the project's")
+	projectNoteCmd.Flags().StringVarP(&projectFormat, "format", "f", "md", "Note format ("+strings.Join(notes.FormatNames(), ", ")+")")
+	projectNoteCmd.Flags().BoolVarP(&projectEdit, "edit", "e", false, "Open the new note in the editor")
+	projectListCmd.Flags().BoolVarP(&projectBranch, "branch", "b", false, "Only notes tagged with the branch checked out")
+
+	projectCmd.AddCommand(projectInitCmd)
+	projectCmd.AddCommand(projectNoteCmd)
+	projectCmd.AddCommand(projectListCmd)
+}
+
+// nonTagChars matches runs of characters left out of tags made from names
+var nonTagChars = regexp.MustCompile(`[^a-z0-9_.]+`)
+
+// nameTag makes a tag from a repository or branch name, e.g. "feature/Login" to
+// "feature-login"
+func nameTag(name string) string {
+	return strings.Trim(nonTagChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// gitOutput runs git in the working directory and returns its trimmed output
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// currentProject returns the root of the git repository around the working
+// directory and its project, exiting if it has none
+func currentProject() (string, config.Project) {
+	root, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: not in a git repository (%v)\n", err)
+		os.Exit(exitUsage)
+	}
+	projects, err := config.LoadProjects()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	project, ok := projects[filepath.Clean(root)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: %s is not a burh project (run 'burh project init')\n", root)
+		os.Exit(exitNotFound)
+	}
+	return root, project
+}
+
+// branchTag returns the tag for the branch checked out, or "" when HEAD is detached
+func branchTag() string {
+	branch, err := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil || branch == "HEAD" {
+		return ""
+	}
+	return nameTag(branch)
+}
+
+func runProjectInit(cmd *cobra.Command, args []string) {
+	root, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: not in a git repository (%v)\n", err)
+		os.Exit(exitUsage)
+	}
+	root = filepath.Clean(root)
+
+	tag := strings.TrimSpace(projectTag)
+	if tag == "" {
+		tag = nameTag(filepath.Base(root))
+	}
+	if tag == "" || strings.ContainsAny(tag, ", ") {
+		fmt.Fprintf(os.Stderr, "Error: invalid tag %q\n", tag)
+		os.Exit(exitUsage)
+	}
+	if err := config.SaveProject(root, config.Project{Tag: tag, Branches: projectBranches}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if !quiet {
+		fmt.Printf("Notes for %s will be tagged %q\n", root, tag)
+	}
+}
+
+func runProjectNote(cmd *cobra.Command, args []string) {
+	if !notes.WritableFormat(projectFormat) {
+		fmt.Fprintf(os.Stderr, "Error: format must be one of %s\n", strings.Join(notes.FormatNames(), ", "))
+		os.Exit(exitUsage)
+	}
+	_, project := currentProject()
+
+	tagList := []string{project.Tag}
+	if project.Branches {
+		if branch := branchTag(); branch != "" && branch != project.Tag {
+			tagList = append(tagList, branch)
+		}
+	}
+	for _, tag := range splitTags(projectTags) {
+		if !containsFold(tagList, tag) {
+			tagList = append(tagList, tag)
+		}
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+	note, err := noteManager.CreateNote(strings.Join(args, " "), projectContent, tagList, projectFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating note: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if projectEdit {
+		editNote(noteManager, note, 0)
+		return
+	}
+	if quiet {
+		fmt.Println(note.ID)
+		return
+	}
+	fmt.Printf("Note created: %s [%s]\n", note.Title, strings.Join(note.Tags, ", "))
+	fmt.Printf("ID: %s\n", note.ID)
+}
+
+func runProjectList(cmd *cobra.Command, args []string) {
+	root, project := currentProject()
+
+	query := "tag:" + project.Tag
+	if projectBranch {
+		branch := branchTag()
+		if branch == "" {
+			fmt.Fprintln(os.Stderr, "Error: no branch is checked out")
+			os.Exit(exitUsage)
+		}
+		query += " tag:" + branch
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+	noteList, err := noteManager.SearchNotes(query)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	if quiet {
+		for _, note := range noteList {
+			fmt.Println(note.ID)
+		}
+		return
+	}
+	if len(noteList) == 0 {
+		fmt.Printf("No notes for %s yet.\n", filepath.Base(root))
+		return
+	}
+	if !plainLayout {
+		fmt.Printf("Found %d notes for %s\n\n", len(noteList), filepath.Base(root))
+	}
+	for i, note := range noteList {
+		printListEntry(i+1, note)
+	}
+}
+
+// containsFold checks if list holds s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
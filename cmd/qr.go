@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"burh/notes"
+	"burh/qr"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	qrShare  bool
+	qrInvert bool
+)
+
+// qrCmd represents the qr command
+var qrCmd = &cobra.Command{
+	Use:   "qr [id]",
+	Short: "Show a note as a QR code",
+	Long: `Render a note's title and content as a QR code in the terminal, so a short note
+can be scanned straight onto a phone. Notes longer than ` + fmt.Sprint(qr.MaxBytes) + ` bytes do not fit;
+use --share to encode the URL of a temporary read-only page instead (see 'burh share').`,
+	Args: cobra.ExactArgs(1),
+	Run:  runQR,
+}
+
+func init() {
+	qrCmd.Flags().BoolVar(&qrShare, "share", false, "Serve the note and encode its URL instead of its text")
+	qrCmd.Flags().BoolVar(&qrInvert, "invert", false, "Draw dark modules as blocks, for terminals with a light background")
+	qrCmd.Flags().DurationVar(&shareTTL, "ttl", time.Hour, "With --share, how long to serve the note")
+	qrCmd.Flags().StringVar(&shareAddr, "addr", ":0", "With --share, address to listen on")
+	qrCmd.Flags().BoolVar(&shareOnce, "once", false, "With --share, stop after the note has been viewed once")
+}
+
+func runQR(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	if err := noteManager.LoadContent(note); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading note: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if !qrShare {
+		text, _ := notes.RenderNote(note, "plain")
+		code, err := qr.Encode([]byte(text))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v; try --share\n", err)
+			os.Exit(exitUsage)
+		}
+		fmt.Print(code.Terminal(qrInvert))
+		return
+	}
+
+	server := newShareServer(note)
+	url := server.URLs()[0]
+	code, err := qr.Encode([]byte(url))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	fmt.Print(code.Terminal(qrInvert))
+	if !quiet {
+		fmt.Printf("Sharing '%s' at %s until %s (ctrl+c to stop)\n", note.Title, url, time.Now().Add(shareTTL).Format("15:04"))
+	}
+	serveShared(server)
+}
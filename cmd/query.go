@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+// batchQuery holds the --query selector shared by the batch commands
+var batchQuery string
+
+// batchYes is set by --yes on the batch commands that confirmations.bulk can make ask
+var batchYes bool
+
+// addQueryFlag registers the --query selector on a batch command
+func addQueryFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&batchQuery, "query", "", `Select notes by query, e.g. "tag:done before:2023-01-01"`)
+}
+
+// addYesFlag registers --yes on a batch command that confirmations.bulk can make ask
+func addYesFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&batchYes, "yes", "y", false, "Do not ask for confirmation (see confirmations.bulk in the config)")
+}
+
+// confirmBatch lists the notes and asks before a batch command changes several of
+// them, when confirmations.bulk names the command
+func confirmBatch(command, verb string, selected []*notes.Note) bool {
+	if len(selected) < 2 || batchYes || !getConfig().Confirmations.ConfirmsBulk(command) {
+		return true
+	}
+	for _, note := range selected {
+		fmt.Printf("  %s  %s\n", note.ID, note.Title)
+	}
+	if !confirm(fmt.Sprintf("%s %d notes?", verb, len(selected))) {
+		fmt.Println("Aborted.")
+		return false
+	}
+	return true
+}
+
+// selectNotes resolves the notes a batch command operates on, from IDs or --query.
+// A note named more than once, or both by ID and by the query, is selected once.
+func selectNotes(noteManager *notes.Manager, ids []string) ([]*notes.Note, error) {
+	if batchQuery == "" && len(ids) == 0 {
+		return nil, errNoSelection
+	}
+
+	var selected []*notes.Note
+	seen := make(map[string]bool)
+	add := func(note *notes.Note) {
+		if !seen[note.Path()] {
+			seen[note.Path()] = true
+			selected = append(selected, note)
+		}
+	}
+
+	for _, id := range ids {
+		note, err := noteManager.GetNote(id)
+		if err != nil {
+			return nil, err
+		}
+		add(note)
+	}
+
+	if batchQuery != "" {
+		results, err := noteManager.SearchNotes(batchQuery)
+		if err != nil {
+			return nil, err
+		}
+		for _, note := range results {
+			add(note)
+		}
+	}
+
+	return selected, nil
+}
+
+// stdinReader reads answers to prompts; one reader is shared so answers piped in
+// for several prompts are not lost to another reader's buffer
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// confirm asks a yes/no question on stdin
+func confirm(prompt string) bool {
+	fmt.Printf("%s (y/n): ", prompt)
+	response, err := stdinReader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes"
+}
+
+// confirmTyped asks for want to be typed on stdin, exactly, to go ahead
+func confirmTyped(prompt, want string) bool {
+	fmt.Printf("%s: ", prompt)
+	response, err := stdinReader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(response) == strings.TrimSpace(want)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"burh/config"
+	"burh/notes"
+)
+
+// linkTitleTimeout is how long fetching a page title for link_titles may take,
+// short so captures stay quick when offline
+const linkTitleTimeout = 3 * time.Second
+
+// runQuickCapture creates a note straight from the --quick text without loading the note list
+func runQuickCapture(text string) {
+	cfg := getConfig()
+
+	title, content, tagList := notes.ParseQuickCapture(text)
+	content = withLinkTitles(cfg, content, "txt")
+	if cfg.LinkTitles && notes.IsBareURL(title) {
+		// A captured link is titled after its page and kept in the content
+		if pageTitle, err := fetchPageTitle(title, linkTitleTimeout); err == nil && pageTitle != "" {
+			content = strings.TrimSpace(title + "\n" + content)
+			title = pageTitle
+		}
+	}
+
+	noteManager := newNoteManager(cfg)
+
+	// Captures wait in the inbox until they are refiled
+	note, err := noteManager.CreateNote(title, content, noteManager.WithInboxTag(tagList), "txt")
+	if err != nil {
+		fmt.Printf("Error creating note: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if quiet {
+		fmt.Println(note.ID)
+		return
+	}
+
+	fmt.Printf("Captured: %s", note.Title)
+	if len(note.Tags) > 0 {
+		fmt.Printf(" [%s]", strings.Join(note.Tags, ", "))
+	}
+	fmt.Printf("\nID: %s\n", note.ID)
+}
+
+// withLinkTitles writes the bare URLs in text as links titled after their pages
+// when link_titles is on. Once a page can't be reached, the rest are left as
+// they are, so being offline costs one timeout.
+func withLinkTitles(cfg *config.Config, text, format string) string {
+	if !cfg.LinkTitles {
+		return text
+	}
+	offline := false
+	return notes.LinkURLs(text, format, func(link string) (string, error) {
+		if offline {
+			return "", errors.New("offline")
+		}
+		title, err := fetchPageTitle(link, linkTitleTimeout)
+		var netErr interface{ Timeout() bool }
+		if errors.As(err, &netErr) {
+			offline = true
+		}
+		return title, err
+	})
+}
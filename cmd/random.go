@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	randomTag   string
+	randomPrint bool
+)
+
+// randomCmd represents the random command
+var randomCmd = &cobra.Command{
+	Use:   "random",
+	Short: "Open a random note",
+	Long: `Open a random note in the editor, to resurface forgotten notes for review.
+--tag and --query narrow the notes picked from, e.g. --query "stale:1y" for notes
+untouched for a year; --print shows the note's ID and title instead of opening it.`,
+	Example: `  burh random
+  burh random --tag ideas
+  burh random --query "stale:6m" --print`,
+	Args: cobra.NoArgs,
+	Run:  runRandom,
+}
+
+func init() {
+	randomCmd.Flags().StringVarP(&randomTag, "tag", "g", "", "Only notes with this tag")
+	randomCmd.Flags().BoolVarP(&randomPrint, "print", "p", false, "Print the note's ID and title instead of opening it")
+	addQueryFlag(randomCmd)
+	addExcludeFlags(randomCmd)
+}
+
+func runRandom(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	query := strings.TrimSpace(batchQuery)
+	if randomTag != "" {
+		query = strings.TrimSpace(query + " tag:" + randomTag)
+	}
+
+	var pool []*notes.Note
+	var err error
+	if query == "" {
+		pool, err = noteManager.ListNotes()
+	} else {
+		pool, err = noteManager.SearchNotes(query)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	if len(pool) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no notes match")
+		os.Exit(exitNotFound)
+	}
+
+	note := pool[rand.Intn(len(pool))]
+	if randomPrint {
+		if quiet {
+			fmt.Println(note.ID)
+			return
+		}
+		fmt.Printf("%s  %s\n", note.ID, note.Title)
+		return
+	}
+	editNote(noteManager, note, 0)
+}
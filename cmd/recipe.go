@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recipeServings int
+	recipeFormat   string
+	recipeTags     string
+	recipeEdit     bool
+	recipeHave     string
+	recipeTag      string
+	recipeMissing  int
+)
+
+// recipeCmd represents the recipe command
+var recipeCmd = &cobra.Command{
+	Use:   "recipe",
+	Short: "Keep recipes, scale them, and find what you can cook",
+	Long: `Keep recipes as notes tagged "recipe", with a servings line and the ingredients
+as a list under an "Ingredients" heading:
+
+  servings: 4
+
+  ## Ingredients
+  - 1 1/2 cups flour
+  - 2 eggs
+  - salt
+
+Quantities at the start of an ingredient (2, 1.5, 1/2, 1 1/2, ½, 2-3) are scaled.`,
+}
+
+// recipeNewCmd represents the recipe new command
+var recipeNewCmd = &cobra.Command{
+	Use:   "new [title]",
+	Short: "Create a recipe note from the recipe template",
+	Args:  cobra.ExactArgs(1),
+	Run:   runRecipeNew,
+}
+
+// recipeScaleCmd represents the recipe scale command
+var recipeScaleCmd = &cobra.Command{
+	Use:   "scale [id] [factor]",
+	Short: "Print a recipe's ingredients scaled",
+	Long: `Print a recipe's ingredient list with quantities scaled by a factor such as 2x,
+0.5x, or 1/2, or to a number of servings with --servings.`,
+	Example: `  burh recipe scale 20240101_120000_pancakes 2x
+  burh recipe scale 20240101_120000_pancakes --servings 6`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runRecipeScale,
+}
+
+// recipeListCmd represents the recipe list command
+var recipeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recipes, or those you can cook with what's on hand",
+	Long: `List recipes, optionally only those with a tag. With --have, only recipes whose
+ingredients are all on hand are listed; --missing allows that many to be missing,
+and shows them. Staples under pantry in the config count as always on hand.`,
+	Example: `  burh recipe list --tag dinner
+  burh recipe list --have eggs,flour,milk,butter --missing 1`,
+	Args: cobra.NoArgs,
+	Run:  runRecipeList,
+}
+
+func init() {
+	recipeCmd.AddCommand(recipeNewCmd)
+	recipeCmd.AddCommand(recipeScaleCmd)
+	recipeCmd.AddCommand(recipeListCmd)
+
+	recipeNewCmd.Flags().IntVar(&recipeServings, "servings", 0, "Number of servings the recipe makes")
+	recipeNewCmd.Flags().StringVarP(&recipeFormat, "format", "f", "md", "Note format ("+strings.Join(notes.FormatNames(), ", ")+")")
+	recipeNewCmd.Flags().StringVarP(&recipeTags, "tags", "g", "", "Comma-separated tags besides recipe")
+	recipeNewCmd.Flags().BoolVarP(&recipeEdit, "edit", "e", false, "Open the new recipe in the editor")
+	recipeScaleCmd.Flags().IntVar(&recipeServings, "servings", 0, "Scale to this many servings")
+	recipeListCmd.Flags().StringVar(&recipeHave, "have", "", "Comma-separated ingredients on hand")
+	recipeListCmd.Flags().StringVar(&recipeTag, "tag", "", "Only recipes with this tag")
+	recipeListCmd.Flags().IntVar(&recipeMissing, "missing", 0, "Ingredients a recipe may be missing with --have")
+}
+
+func runRecipeNew(cmd *cobra.Command, args []string) {
+	if !notes.WritableFormat(recipeFormat) {
+		fmt.Fprintf(os.Stderr, "Error: format must be one of %s\n", strings.Join(notes.FormatNames(), ", "))
+		os.Exit(exitUsage)
+	}
+
+	tagList := []string{notes.RecipeTag}
+	for _, tag := range strings.Split(recipeTags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" && tag != notes.RecipeTag {
+			tagList = append(tagList, tag)
+		}
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.CreateNote(args[0], notes.RecipeTemplate(recipeFormat, recipeServings), tagList, recipeFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating recipe: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if recipeEdit {
+		editNote(noteManager, note, 0)
+		return
+	}
+	if quiet {
+		fmt.Println(note.ID)
+		return
+	}
+	fmt.Printf("Recipe created: %s\n", note.Title)
+	fmt.Printf("ID: %s\n", note.ID)
+}
+
+func runRecipeScale(cmd *cobra.Command, args []string) {
+	if (len(args) == 2) == (recipeServings > 0) {
+		fmt.Fprintln(os.Stderr, "Error: give either a scale factor or --servings")
+		os.Exit(exitUsage)
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	if err := noteManager.LoadContent(note); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	recipe := notes.ParseRecipe(note)
+	if len(recipe.Ingredients) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: %s has no ingredient list under an Ingredients heading\n", note.ID)
+		os.Exit(exitNotFound)
+	}
+
+	var factor float64
+	if len(args) == 2 {
+		factor, err = notes.ParseScale(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	} else {
+		if recipe.Servings == 0 {
+			fmt.Fprintf(os.Stderr, "Error: %s does not say how many servings it makes (add a \"servings: N\" line)\n", note.ID)
+			os.Exit(exitUsage)
+		}
+		factor = float64(recipeServings) / float64(recipe.Servings)
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	heading := fmt.Sprintf("%s ×%s", note.Title, notes.FormatQuantity(factor, false))
+	if recipe.Servings > 0 {
+		heading += fmt.Sprintf(" (%s servings)", notes.FormatQuantity(float64(recipe.Servings)*factor, false))
+	}
+	if !quiet {
+		fmt.Println(headerStyle.Render(heading))
+	}
+	for _, ing := range recipe.Ingredients {
+		fmt.Printf("- %s\n", ing.Scale(factor))
+	}
+}
+
+func runRecipeList(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	recipes, err := noteManager.Recipes(recipeTag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading recipes: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	var onHand []string
+	if recipeHave != "" {
+		onHand = append(strings.Split(recipeHave, ","), cfg.Pantry...)
+	}
+
+	type match struct {
+		recipe  notes.Recipe
+		missing []notes.Ingredient
+	}
+	var matches []match
+	for _, recipe := range recipes {
+		var missing []notes.Ingredient
+		if onHand != nil {
+			missing = recipe.Missing(onHand)
+			if len(recipe.Ingredients) == 0 || len(missing) > recipeMissing {
+				continue
+			}
+		}
+		matches = append(matches, match{recipe, missing})
+	}
+	if len(matches) == 0 {
+		fmt.Println("No recipes found.")
+		return
+	}
+
+	// Fewest missing ingredients first, then by title
+	sort.SliceStable(matches, func(i, j int) bool {
+		if len(matches[i].missing) != len(matches[j].missing) {
+			return len(matches[i].missing) < len(matches[j].missing)
+		}
+		return strings.ToLower(matches[i].recipe.Note.Title) < strings.ToLower(matches[j].recipe.Note.Title)
+	})
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+	missingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#BF616A"))
+	for _, m := range matches {
+		if quiet {
+			fmt.Println(m.recipe.Note.ID)
+			continue
+		}
+		line := m.recipe.Note.Title + " " + mutedStyle.Render(m.recipe.Note.ID)
+		if len(m.missing) > 0 {
+			var names []string
+			for _, ing := range m.missing {
+				names = append(names, strings.TrimSpace(ing.Rest))
+			}
+			line += missingStyle.Render("  missing: " + strings.Join(names, "; "))
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"burh/config"
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recordMonth    int
+	recordField    string
+	recordWhere    []string
+	recordValidate bool
+)
+
+// queryCmd represents the query command
+var queryCmd = &cobra.Command{
+	Use:   "query [type|field]",
+	Short: "Query the records of structured notes, such as contacts",
+	Long: `Query structured notes declared under record_types in the config. A structured
+note is tagged with its type's tag and holds one record per heading, with fields
+written as "key: value" lines (or an org property drawer):
+
+  ## Ada Lovelace
+  email: ada@example.com
+  birthday: 1815-12-10
+
+The argument is a record type, or a field name (singular or plural) to list every
+record that has it:
+
+  burh query contacts --where city=Berlin
+  burh query birthdays --month 6
+
+--month keeps records whose date field falls in the month, ignoring the year, and
+sorts them by day. --validate checks records against their type's fields and exits
+with status 1 if any are invalid. Without an argument the record types are listed.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runQuery,
+}
+
+func init() {
+	queryCmd.Flags().IntVar(&recordMonth, "month", 0, "Only records whose date field falls in this month (1-12)")
+	queryCmd.Flags().StringVar(&recordField, "field", "", "Date field for --month (default the queried field, or every date field)")
+	queryCmd.Flags().StringArrayVar(&recordWhere, "where", nil, "Only records with field=value (case-insensitive, repeatable)")
+	queryCmd.Flags().BoolVar(&recordValidate, "validate", false, "Check records against their type instead of listing them")
+}
+
+func runQuery(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+
+	if len(args) == 0 {
+		printRecordTypes(cfg.RecordTypes)
+		return
+	}
+	if recordMonth < 0 || recordMonth > 12 {
+		fmt.Fprintf(os.Stderr, "Error: --month must be between 1 and 12\n")
+		os.Exit(exitUsage)
+	}
+
+	types, field := queryTypes(cfg, args[0])
+	if len(types) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: %q is neither a record type nor a record field in the config\n", args[0])
+		os.Exit(exitUsage)
+	}
+	if recordField != "" {
+		field = strings.ToLower(recordField)
+	}
+
+	noteManager := newNoteManager(cfg)
+	noteList, err := noteManager.ListNotes()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing notes: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if err := noteManager.LoadContents(noteList); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading notes: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if recordValidate {
+		if !validateRecords(types, noteList) {
+			os.Exit(exitIO)
+		}
+		return
+	}
+
+	var matches []notes.Record
+	for _, t := range types {
+		for _, record := range notes.RecordsWithTag(noteList, t.NoteTag()) {
+			if field != "" && record.Fields[field] == "" {
+				continue
+			}
+			if !recordMatchesWhere(record) {
+				continue
+			}
+			if recordMonth != 0 && !recordInMonth(t, record, field) {
+				continue
+			}
+			matches = append(matches, record)
+		}
+	}
+	if recordMonth != 0 {
+		sort.SliceStable(matches, func(i, j int) bool {
+			return recordDay(matches[i], field) < recordDay(matches[j], field)
+		})
+	}
+
+	if quiet {
+		for _, record := range matches {
+			fmt.Println(record.Name)
+		}
+		return
+	}
+	if len(matches) == 0 {
+		fmt.Println("No matching records.")
+		return
+	}
+	for _, record := range matches {
+		printRecord(record, field)
+	}
+}
+
+// queryTypes resolves a query argument: a record type, or a field name (optionally
+// plural) that returns every type declaring it along with the lowercase field name
+func queryTypes(cfg *config.Config, name string) ([]config.RecordType, string) {
+	if t, ok := cfg.LookupRecordType(name); ok {
+		return []config.RecordType{t}, ""
+	}
+
+	for _, candidate := range []string{name, strings.TrimSuffix(name, "s")} {
+		var types []config.RecordType
+		for _, t := range cfg.RecordTypes {
+			if _, ok := t.Field(candidate); ok {
+				types = append(types, t)
+			}
+		}
+		if len(types) > 0 {
+			return types, strings.ToLower(candidate)
+		}
+	}
+	return nil, ""
+}
+
+// recordMatchesWhere reports whether a record satisfies every --where condition
+func recordMatchesWhere(record notes.Record) bool {
+	for _, cond := range recordWhere {
+		key, value, _ := strings.Cut(cond, "=")
+		if !strings.EqualFold(record.Fields[strings.ToLower(strings.TrimSpace(key))], strings.TrimSpace(value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordInMonth reports whether the record's date field (or any of its type's date
+// fields when field is empty) falls in the --month month
+func recordInMonth(t config.RecordType, record notes.Record, field string) bool {
+	for _, name := range recordDateFields(t, field) {
+		if date, err := time.Parse("2006-01-02", record.Fields[name]); err == nil && int(date.Month()) == recordMonth {
+			return true
+		}
+	}
+	return false
+}
+
+// recordDateFields returns the lowercase names of the date fields to check
+func recordDateFields(t config.RecordType, field string) []string {
+	if field != "" {
+		return []string{field}
+	}
+	var names []string
+	for _, f := range t.Fields {
+		if strings.EqualFold(f.Type, "date") {
+			names = append(names, strings.ToLower(f.Name))
+		}
+	}
+	return names
+}
+
+// recordDay returns the MM-DD part of the record's date field, for sorting by day of the year
+func recordDay(record notes.Record, field string) string {
+	if value := record.Fields[field]; len(value) >= 10 {
+		return value[5:10]
+	}
+	for _, value := range record.Fields {
+		if date, err := time.Parse("2006-01-02", value); err == nil && int(date.Month()) == recordMonth {
+			return value[5:10]
+		}
+	}
+	return ""
+}
+
+// validateRecords prints the problems of every record of the types and reports whether all were valid
+func validateRecords(types []config.RecordType, noteList []*notes.Note) bool {
+	valid, checked := true, 0
+	for _, t := range types {
+		for _, record := range notes.RecordsWithTag(noteList, t.NoteTag()) {
+			checked++
+			for _, err := range t.Validate(record.Fields) {
+				valid = false
+				fmt.Printf("%s: %s (%s): %v\n", t.Name, record.Name, record.Note.ID, err)
+			}
+		}
+	}
+	if valid && !quiet {
+		fmt.Printf("%d records valid.\n", checked)
+	}
+	return valid
+}
+
+// printRecord prints a record's name and note, then its fields; with a queried field
+// only that field is shown on the same line
+func printRecord(record notes.Record, field string) {
+	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+
+	if field != "" {
+		fmt.Printf("%s  %s  %s\n", record.Fields[field], nameStyle.Render(record.Name), dimStyle.Render(record.Note.ID))
+		return
+	}
+
+	fmt.Printf("%s  %s\n", nameStyle.Render(record.Name), dimStyle.Render(record.Note.ID))
+	keys := make([]string, 0, len(record.Fields))
+	for key := range record.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("  %s: %s\n", key, record.Fields[key])
+	}
+	fmt.Println()
+}
+
+// printRecordTypes lists the record types declared in the config
+func printRecordTypes(types []config.RecordType) {
+	if len(types) == 0 {
+		fmt.Println("No record types configured. Declare them under record_types in the config.")
+		return
+	}
+	for _, t := range types {
+		var fields []string
+		for _, f := range t.Fields {
+			desc := f.Name
+			if f.Type != "" {
+				desc += " (" + f.Type + ")"
+			}
+			if f.Required {
+				desc += "*"
+			}
+			fields = append(fields, desc)
+		}
+		fmt.Printf("%s  tag:%s  %s\n", t.Name, t.NoteTag(), strings.Join(fields, ", "))
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"burh/paths"
+
+	"github.com/spf13/cobra"
+)
+
+var refileTo string
+
+// refileCmd represents the refile command
+var refileCmd = &cobra.Command{
+	Use:   "refile [id...]",
+	Short: "Move notes out of the inbox",
+	Long: `Move one or more notes by ID, or every note matching --query, into a notes
+directory (or one of its subdirectories when recursive is set) and remove the inbox tag.
+Quick captures get the inbox tag (inbox_tag in the config, "inbox" by default), so
+burh refile --query "tag:inbox" --to DIR files them all at once.`,
+	Run: runRefile,
+}
+
+func init() {
+	addQueryFlag(refileCmd)
+	refileCmd.Flags().StringVar(&refileTo, "to", "", "Directory to file the notes in (required)")
+	refileCmd.MarkFlagRequired("to")
+}
+
+func runRefile(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	target := ""
+	for _, dir := range noteManager.RefileTargets() {
+		if paths.Same(dir, refileTo) {
+			target = dir
+			break
+		}
+	}
+	if target == "" {
+		fmt.Printf("Error: %s is not a notes directory (see burh list-dirs)\n", refileTo)
+		os.Exit(exitUsage)
+	}
+
+	selected, err := selectNotes(noteManager, args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	failed := 0
+	for _, note := range selected {
+		if err := noteManager.RefileNote(note, target); err != nil {
+			fmt.Printf("Error refiling %s: %v\n", note.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Refiled %s to %s\n", note.ID, target)
+	}
+
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
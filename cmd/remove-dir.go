@@ -28,7 +28,7 @@ func init() {
 func runRemoveDir(cmd *cobra.Command, args []string) {
 	if err := config.RemoveNotesDirectory(removeDirPath); err != nil {
 		fmt.Printf("Error removing directory: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitIO)
 	}
 
 	fmt.Printf("Successfully removed notes directory: %s\n", removeDirPath)
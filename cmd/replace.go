@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	replaceRegex       bool
+	replaceIgnoreCase  bool
+	replaceInteractive bool
+)
+
+// replaceCmd represents the replace command
+var replaceCmd = &cobra.Command{
+	Use:   "replace [old] [new]",
+	Short: "Find and replace text across notes",
+	Long: `Replace text in the content of every note, or of the notes selected by --query,
+and print a diff of what changed. With --regex, old is a regular expression and new
+may refer to its groups as $1 or ${name}.
+
+--interactive asks about each occurrence: y replaces it, n skips it, a replaces the
+rest in the note, s skips the rest of the note, and q stops, keeping the answers
+given so far. --dry-run only prints the diff and the files that would change. Locked and read-only notes are skipped.`,
+	Args: cobra.ExactArgs(2),
+	Run:  runReplace,
+}
+
+func init() {
+	replaceCmd.Flags().BoolVar(&replaceRegex, "regex", false, "Treat old as a regular expression")
+	replaceCmd.Flags().BoolVar(&replaceIgnoreCase, "ignore-case", false, "Match old regardless of case")
+	replaceCmd.Flags().BoolVarP(&replaceInteractive, "interactive", "i", false, "Confirm each occurrence")
+	honorsDryRun(replaceCmd)
+	addQueryFlag(replaceCmd)
+}
+
+func runReplace(cmd *cobra.Command, args []string) {
+	replacement, err := notes.NewReplacement(args[0], args[1], replaceRegex, replaceIgnoreCase)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	var noteList []*notes.Note
+	if batchQuery != "" {
+		noteList, err = noteManager.SearchNotes(batchQuery)
+	} else {
+		noteList, err = noteManager.ListNotes()
+	}
+	if err != nil {
+		fmt.Printf("Error listing notes: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	changedNotes, replaced, failed := 0, 0, 0
+	for _, note := range noteList {
+		if !notes.WritableFormat(note.Format) {
+			continue
+		}
+		if err := noteManager.LoadContent(note); err != nil {
+			fmt.Printf("Error reading %s: %v\n", note.ID, err)
+			failed++
+			continue
+		}
+		matches := replacement.Find(note.Content)
+		if len(matches) == 0 {
+			continue
+		}
+		if note.Locked {
+			fmt.Printf("Skipped %s: note is locked (%d occurrences)\n", note.ID, len(matches))
+			continue
+		}
+
+		accepted := matches
+		stop := false
+		if replaceInteractive {
+			accepted, stop = confirmMatches(reader, note, matches)
+		}
+		if len(accepted) > 0 {
+			printReplaceDiff(note, notes.ReplaceHunks(note.Content, accepted))
+			if dryRun {
+				printDryRun("write", note.Path())
+			} else {
+				if err := noteManager.ReplaceContent(note, notes.ApplyMatches(note.Content, accepted)); err != nil {
+					fmt.Printf("Error saving %s: %v\n", note.ID, err)
+					failed++
+					continue
+				}
+			}
+			changedNotes++
+			replaced += len(accepted)
+		}
+		if stop {
+			break
+		}
+	}
+
+	verb := "Replaced"
+	if dryRun {
+		verb = "Would replace"
+	}
+	fmt.Printf("%s %d occurrences in %d notes.\n", verb, replaced, changedNotes)
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
+
+// confirmMatches asks about each occurrence in a note and returns the accepted ones,
+// and whether the user asked to stop altogether
+func confirmMatches(reader *bufio.Reader, note *notes.Note, matches []notes.Match) ([]notes.Match, bool) {
+	var accepted []notes.Match
+	for i, match := range matches {
+		printReplaceDiff(note, notes.ReplaceHunks(note.Content, []notes.Match{match}))
+		fmt.Printf("Replace? (%d/%d) [y]es/[n]o/[a]ll in note/[s]kip note/[q]uit: ", i+1, len(matches))
+
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return accepted, true
+		}
+		switch strings.ToLower(strings.TrimSpace(response)) {
+		case "y", "yes":
+			accepted = append(accepted, match)
+		case "a", "all":
+			return append(accepted, matches[i:]...), false
+		case "s", "skip":
+			return accepted, false
+		case "q", "quit":
+			return accepted, true
+		}
+	}
+	return accepted, false
+}
+
+// printReplaceDiff prints changed lines of a note as removed and added lines
+func printReplaceDiff(note *notes.Note, hunks []notes.Hunk) {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6"))
+	removed := lipgloss.NewStyle().Foreground(lipgloss.Color("#BF616A"))
+	added := lipgloss.NewStyle().Foreground(lipgloss.Color("#A3BE8C"))
+
+	fmt.Println(headerStyle.Render(fmt.Sprintf("%s  %s", note.ID, note.Title)))
+	for _, hunk := range hunks {
+		fmt.Println(lineStyle.Render(fmt.Sprintf("@@ line %d", hunk.Line)))
+		for _, line := range hunk.Before {
+			fmt.Println(removed.Render("- " + line))
+		}
+		for _, line := range hunk.After {
+			fmt.Println(added.Render("+ " + line))
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"burh/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	retitleMatch         string
+	retitleReplace       string
+	retitleCaseSensitive bool
+)
+
+// retitleCmd represents the retitle command
+var retitleCmd = &cobra.Command{
+	Use:   "retitle",
+	Short: "Find and replace text in note titles",
+	Long: `Replace text in the titles of every note, or of the notes selected by --query.
+Only titles change: the note's content is left alone, and its file is renamed so the
+ID follows the new title.
+
+--match is found regardless of case, and the replacement takes the case of each
+occurrence, so --match "mtg:" --replace "meeting:" turns "Mtg:" into "Meeting:" and
+"MTG:" into "MEETING:". --case-sensitive matches and replaces exactly as typed.
+--dry-run only prints the new titles and the files that would be renamed. Locked and read-only notes are skipped.`,
+	Args: cobra.NoArgs,
+	Run:  runRetitle,
+}
+
+func init() {
+	retitleCmd.Flags().StringVar(&retitleMatch, "match", "", "Text to find in titles")
+	retitleCmd.Flags().StringVar(&retitleReplace, "replace", "", "Text to put in its place")
+	retitleCmd.Flags().BoolVar(&retitleCaseSensitive, "case-sensitive", false, "Match and replace exactly as typed")
+	honorsDryRun(retitleCmd)
+	retitleCmd.MarkFlagRequired("match")
+	addQueryFlag(retitleCmd)
+}
+
+func runRetitle(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	var noteList []*notes.Note
+	var err error
+	if batchQuery != "" {
+		noteList, err = noteManager.SearchNotes(batchQuery)
+	} else {
+		noteList, err = noteManager.ListNotes()
+	}
+	if err != nil {
+		fmt.Printf("Error listing notes: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	retitled, failed := 0, 0
+	for _, note := range noteList {
+		title, found := notes.ReplaceInTitle(note.Title, retitleMatch, retitleReplace, retitleCaseSensitive)
+		if !found || title == note.Title {
+			continue
+		}
+		if !notes.WritableFormat(note.Format) {
+			continue
+		}
+		if note.Locked {
+			fmt.Printf("Skipped %s: note is locked\n", note.ID)
+			continue
+		}
+
+		oldTitle, oldID, oldPath := note.Title, note.ID, note.Path()
+		newID := notes.RetitledID(note, title)
+		if !dryRun {
+			if err := noteManager.RetitleNote(note, title); err != nil {
+				fmt.Printf("Error retitling %s: %v\n", oldID, err)
+				failed++
+				continue
+			}
+		}
+
+		fmt.Printf("%s → %s\n", oldTitle, title)
+		if newID != oldID {
+			fmt.Printf("  %s → %s\n", oldID, newID)
+		}
+		if dryRun {
+			if newID != oldID {
+				printDryRun("rename", oldPath, filepath.Join(note.Dir, newID+filepath.Ext(note.Filename)))
+			} else {
+				printDryRun("write", oldPath)
+			}
+		}
+		retitled++
+	}
+
+	verb := "Retitled"
+	if dryRun {
+		verb = "Would retitle"
+	}
+	fmt.Printf("%s %d notes.\n", verb, retitled)
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"burh/config"
+	"burh/i18n"
 	"burh/notes"
 	"burh/tui"
 
@@ -13,7 +14,10 @@ import (
 )
 
 var (
-	cfgFile string
+	cfgFile      string
+	quickCapture string
+	quiet        bool
+	local        bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -23,14 +27,30 @@ var rootCmd = &cobra.Command{
 	Long: `Burh is a note-taking tool inspired by Denote, providing both CLI and TUI interfaces.
 It supports creating, editing, searching, and managing notes in both .org and .txt formats.
 Each note gets a unique ID based on timestamp and title.`,
-	Run: runTUI,
+	PersistentPreRunE: beforeCommand,
+	PersistentPostRun: recordUsage,
+	Run:               runTUI,
+}
+
+// version is the version of this build, e.g. "v1.2.0", or "dev"
+var version = "dev"
+
+// SetVersion sets the build's version, shown by --version and compared against
+// releases by self-update
+func SetVersion(v, buildTime string) {
+	version = v
+	rootCmd.Version = v
+	if buildTime != "" {
+		rootCmd.Version += " (built " + buildTime + ")"
+	}
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		// Commands report their own failures, so errors here are argument or flag problems
+		os.Exit(exitUsage)
 	}
 }
 
@@ -38,6 +58,13 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.burhrc.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&showContent, "content", "c", false, "Show note content in list/search results")
+	rootCmd.PersistentFlags().BoolVar(&quiet, "quiet", false, "Suppress decorative output (list/search print only note IDs)")
+	rootCmd.PersistentFlags().BoolVar(&local, "local", false, "Use the notes in the nearest .burh/ or notes/ directory above the working directory")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colors and text styling (also set by the NO_COLOR environment variable)")
+	rootCmd.PersistentFlags().BoolVar(&plain, "plain", false, "Plain output without colors or styling, a line per note (the default when output is piped)")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print which files delete, replace, retitle, convert, gc, or config import would change, without changing them")
+	addExcludeFlags(rootCmd)
+	rootCmd.Flags().StringVarP(&quickCapture, "quick", "q", "", "Quickly capture a note: first line is the title, #words become tags")
 
 	// Add subcommands
 	rootCmd.AddCommand(createCmd)
@@ -46,9 +73,59 @@ func init() {
 	rootCmd.AddCommand(listDirsCmd)
 	rootCmd.AddCommand(addDirCmd)
 	rootCmd.AddCommand(removeDirCmd)
-
-	// Initialize config after flags are parsed
-	cobra.OnInitialize(initConfig)
+	rootCmd.AddCommand(metaCmd)
+	rootCmd.AddCommand(insertCmd)
+	rootCmd.AddCommand(deleteCmd)
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(moveCmd)
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(editCmd)
+	rootCmd.AddCommand(copyCmd)
+	rootCmd.AddCommand(cloneCmd)
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
+	rootCmd.AddCommand(convertCmd)
+	rootCmd.AddCommand(ocrCmd)
+	rootCmd.AddCommand(staleCmd)
+	rootCmd.AddCommand(topicsCmd)
+	rootCmd.AddCommand(shareCmd)
+	rootCmd.AddCommand(printCmd)
+	rootCmd.AddCommand(qrCmd)
+	rootCmd.AddCommand(icalCmd)
+	rootCmd.AddCommand(queryCmd)
+	rootCmd.AddCommand(bookmarkCmd)
+	rootCmd.AddCommand(outlineCmd)
+	rootCmd.AddCommand(replaceCmd)
+	rootCmd.AddCommand(retitleCmd)
+	rootCmd.AddCommand(gcCmd)
+	rootCmd.AddCommand(refileCmd)
+	rootCmd.AddCommand(clockCmd)
+	rootCmd.AddCommand(habitCmd)
+	rootCmd.AddCommand(booksCmd)
+	rootCmd.AddCommand(recipeCmd)
+	rootCmd.AddCommand(snipCmd)
+	rootCmd.AddCommand(meetingCmd)
+	rootCmd.AddCommand(followupsCmd)
+	rootCmd.AddCommand(studyCmd)
+	rootCmd.AddCommand(randomCmd)
+	rootCmd.AddCommand(onThisDayCmd)
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(redoCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(genDocsCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(selfUpdateCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(launcherCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(projectCmd)
+	rootCmd.AddCommand(fromCmdCmd)
+	rootCmd.AddCommand(captureOutputCmd, generateDueCmd, themesCmd, pasteImageCmd)
 }
 
 // Global config variable
@@ -57,40 +134,122 @@ var globalConfig *config.Config
 // getConfig ensures the config is loaded and returns it
 func getConfig() *config.Config {
 	if globalConfig == nil {
+		if local {
+			useWorkspace()
+		}
+
 		// Load configuration
 		cfg, err := config.LoadConfig()
 		if err != nil {
 			fmt.Printf("Error loading config: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitIO)
 		}
 
 		// Store config globally
 		globalConfig = cfg
+		i18n.SetLocale(i18n.Detect(cfg.Locale))
 	}
 	return globalConfig
 }
 
+// useWorkspace switches to the workspace around the working directory for --local
+func useWorkspace() {
+	wd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	ws, err := config.FindWorkspace(wd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --local: %v\n", err)
+		os.Exit(exitNotFound)
+	}
+	if err := config.UseWorkspace(ws); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+}
+
+// newNoteManager creates a note manager for all configured directories, metadata fields, and timeouts
+func newNoteManager(cfg *config.Config) *notes.Manager {
+	noteManager := notes.NewManagerWithDirs(cfg.NotesDirs)
+	noteManager.SetMetadataKeys(cfg.MetadataKeys())
+	noteManager.SetRecursive(cfg.Recursive)
+	noteManager.SetReadOnlyDirs(cfg.ReadOnlyDirPaths())
+	noteManager.SetStaleExcludeTags(cfg.StaleExclude)
+	if err := noteManager.SetSearchLocale(cfg.SearchLocale); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	noteManager.SetSearchMatching(cfg.SearchStemming, cfg.SearchFuzzy)
+	noteManager.SetInboxTag(cfg.InboxTag)
+	noteManager.SetSyncMode(cfg.SyncMode)
+	noteManager.SetWarnFunc(func(err error) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	})
+	noteManager.SetJournal(config.JournalPath(), cfg.UndoLimit)
+	if cfg.AuditLog {
+		noteManager.SetAuditLog(config.AuditLogPath())
+	}
+	if cfg.BinaryNotes {
+		notes.RegisterBinaryFormats(cfg.ExtractorMap())
+	}
+	if timeouts, err := cfg.DirTimeoutMap(); err == nil {
+		noteManager.SetDirTimeouts(timeouts)
+	}
+	applyExclusions(noteManager)
+	return noteManager
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	// Just ensure config is loaded
 	getConfig()
 }
 
+// noConfigAnnotation marks the commands that run without loading the config,
+// which on a new machine or in CI would prompt for a notes directory
+const noConfigAnnotation = "no-config"
+
+// skipsConfig marks a command as running without the config
+func skipsConfig(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[noConfigAnnotation] = "true"
+}
+
 // runTUI starts the TUI interface
 func runTUI(cmd *cobra.Command, args []string) {
+	if quickCapture != "" {
+		runQuickCapture(quickCapture)
+		return
+	}
+
 	// Get config
 	cfg := getConfig()
 
 	// Create note manager with all directories
-	noteManager := notes.NewManagerWithDirs(cfg.NotesDirs)
+	noteManager := newNoteManager(cfg)
+	if applyDefaultView(noteManager, cfg) && cfg.DefaultView.Sort != "" {
+		cfg.SortColumn = cfg.DefaultView.Sort
+		cfg.SortDescending = cfg.DefaultView.Descending
+	}
 
 	// Create TUI model
 	model := tui.NewModel(noteManager, cfg)
+	model.SetManagerFactory(func(cfg *config.Config) *notes.Manager {
+		noteManager := newNoteManager(cfg)
+		applyDefaultView(noteManager, cfg)
+		return noteManager
+	})
+	if tryTheme != nil {
+		model.TryTheme(*tryTheme)
+	}
 
 	// Run TUI
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running TUI: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitIO)
 	}
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -14,14 +15,28 @@ import (
 var (
 	searchQuery       string
 	showContentSearch bool
+	searchStem        bool
+	searchFuzzy       int
+	searchOpen        bool
 )
 
 // searchCmd represents the search command
 var searchCmd = &cobra.Command{
 	Use:   "search [query]",
-	Short: "Search notes by title, content, or tags",
+	Short: "Search notes by title, content, tags, or ID",
 	Long: `Search for notes that match the given query.
-The search is case-insensitive and looks in titles, content, and tags.`,
+The search is case-insensitive and looks in titles, content, tags, and IDs.
+id:2024-05 finds notes whose ID contains 202405, e.g. those created in May 2024.
+A term with a leading "-" leaves out the notes it matches: "meeting -tag:archive"
+finds meetings outside the archive. Put "--" before a query starting with "-".
+
+--stem also matches other forms of English words ("running" finds "run"), and
+--fuzzy 1 or 2 tolerates that many typos per word (words under 4 letters must match
+exactly, and words under 8 letters allow one typo). Their defaults come from
+search_stemming and search_fuzzy in the config.
+
+--open opens the first hit (after --offset) in the editor, on the line where the
+query matches for editors that support it, such as vim, nano, emacs, and VS Code.`,
 	Args: cobra.ExactArgs(1),
 	Run:  runSearch,
 }
@@ -31,59 +46,178 @@ func init() {
 
 	// Local flags
 	searchCmd.Flags().BoolVarP(&showContentSearch, "content", "c", false, "Show note content")
+	searchCmd.Flags().BoolVar(&searchStem, "stem", false, "Match other forms of the words (default search_stemming from the config)")
+	searchCmd.Flags().IntVar(&searchFuzzy, "fuzzy", 0, "Typos allowed per word, 0-2 (default search_fuzzy from the config)")
+	searchCmd.Flags().BoolVar(&searchOpen, "open", false, "Open the first hit in the editor at the matching line")
+	addOutputFlags(searchCmd)
+	addPageFlags(searchCmd)
+	addExcludeFlags(searchCmd)
 }
 
 func runSearch(cmd *cobra.Command, args []string) {
 	searchQuery = args[0]
-
 	// Get config
 	cfg := getConfig()
+	checkOutputFields(cfg)
 
 	// Create note manager with all directories
-	noteManager := notes.NewManagerWithDirs(cfg.NotesDirs)
+	noteManager := newNoteManager(cfg)
+	splitFormatFlag()
 
-	// Search notes
-	results, err := noteManager.SearchNotes(searchQuery)
-	if err != nil {
-		fmt.Printf("Error searching notes: %v\n", err)
-		os.Exit(1)
+	if cmd.Flags().Changed("stem") || cmd.Flags().Changed("fuzzy") {
+		stemming, fuzzy := cfg.SearchStemming, cfg.SearchFuzzy
+		if cmd.Flags().Changed("stem") {
+			stemming = searchStem
+		}
+		if cmd.Flags().Changed("fuzzy") {
+			if searchFuzzy < 0 || searchFuzzy > 2 {
+				fmt.Fprintf(os.Stderr, "Error: --fuzzy must be 0, 1, or 2\n")
+				os.Exit(exitUsage)
+			}
+			fuzzy = searchFuzzy
+		}
+		noteManager.SetSearchMatching(stemming, fuzzy)
 	}
 
-	if len(results) == 0 {
-		fmt.Printf("No notes found matching '%s'\n", searchQuery)
+	if searchOpen {
+		openSearchHit(noteManager)
 		return
 	}
 
-	heading := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).Render(fmt.Sprintf("Found %d notes matching '%s'", len(results), searchQuery))
-	fmt.Printf("%s\n\n", heading)
+	// Machine-readable formats need the complete result set
+	if outputFormat != "" {
+		results, err := noteManager.SearchNotes(searchQuery)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error searching notes: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		results = paginate(filterNoteFormats(results))
+		if len(results) == 0 {
+			os.Exit(exitNotFound)
+		}
+		if outputNeedsContent() {
+			if err := noteManager.LoadContents(results); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading notes: %v\n", err)
+				os.Exit(exitIO)
+			}
+		}
+		printMachineReadable(results)
+		warnExtractorFailures()
+		return
+	}
 
-	for i, note := range results {
-		ts := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render(note.Created.Format("2006-01-02 15:04"))
-		fmtTag := lipgloss.NewStyle().Foreground(lipgloss.Color("#81A1C1")).Render("[" + note.Format + "]")
-		title := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true).Render(note.Title)
-		fmt.Printf("%2d. %s  %s  %s\n", i+1, ts, fmtTag, title)
+	finishPager := startPager()
 
-		if len(note.Tags) > 0 {
-			// Truncate tags to show only first 6
-			tagsToShow := note.Tags
-			if len(note.Tags) > 6 {
-				tagsToShow = note.Tags[:6]
-			}
-			tagsStr := strings.Join(tagsToShow, ", ")
-			if len(note.Tags) > 6 {
-				tagsStr += "..."
-			}
-			fmt.Printf("    %s %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("Tags:"), tagsStr)
+	// Print each hit as soon as it is found
+	count, skipped := 0, 0
+	err := noteManager.SearchNotesStream(context.Background(), searchQuery, func(note *notes.Note) bool {
+		if !inNoteFormats(note) {
+			return true
+		}
+		if skipped < pageOffset {
+			skipped++
+			return true
+		}
+		if pageLimit > 0 && count >= pageLimit {
+			return false
+		}
+		count++
+		if quiet {
+			fmt.Println(note.ID)
+			return true
+		}
+		if showContentSearch {
+			noteManager.LoadContent(note)
 		}
+		printSearchResult(pageOffset+count, note)
+		return true
+	})
+	finishPager()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error searching notes: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	warnExtractorFailures()
 
-		if showContentSearch && note.Content != "" {
-			content := note.Content
-			if len(content) > 100 {
-				content = content[:100] + "..."
-			}
-			fmt.Printf("    %s %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("Content:"), content)
+	if count == 0 {
+		if !quiet {
+			fmt.Printf("No notes found matching '%s'\n", searchQuery)
+		}
+		os.Exit(exitNotFound)
+	}
+
+	if !quiet && !plainLayout {
+		summary := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).Render(fmt.Sprintf("Found %d notes matching '%s'", count, searchQuery))
+		fmt.Printf("%s\n", summary)
+	}
+}
+
+// warnExtractorFailures prints a warning for each binary note whose text could
+// not be extracted, since it was only searched by its title
+func warnExtractorFailures() {
+	for _, err := range notes.ExtractorFailures() {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// openSearchHit opens the first search hit after --offset in the editor at the matching line
+func openSearchHit(noteManager *notes.Manager) {
+	results, err := noteManager.SearchNotes(searchQuery)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error searching notes: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	results = paginate(filterNoteFormats(results))
+	if len(results) == 0 {
+		if !quiet {
+			fmt.Printf("No notes found matching '%s'\n", searchQuery)
 		}
+		os.Exit(exitNotFound)
+	}
 
-		fmt.Printf("    %s %s\n\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("ID:"), note.ID)
+	note := results[0]
+	line, err := noteManager.MatchLine(note, searchQuery)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
 	}
+	editNote(noteManager, note, line)
+}
+
+// printSearchResult prints a single search hit
+func printSearchResult(i int, note *notes.Note) {
+	if plainLayout {
+		printPlainEntry(note, showContentSearch)
+		return
+	}
+	ts := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render(note.Created.Format("2006-01-02 15:04"))
+	fmtTag := lipgloss.NewStyle().Foreground(lipgloss.Color("#81A1C1")).Render("[" + note.Format + "]")
+	title := hyperlink(lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true).Render(note.Title), note.Path())
+	if note.Locked {
+		title = "🔒 " + title
+	}
+	fmt.Printf("%2d. %s  %s  %s\n", i, ts, fmtTag, title)
+
+	if len(note.Tags) > 0 {
+		// Truncate tags to show only first 6
+		tagsToShow := note.Tags
+		if len(note.Tags) > 6 {
+			tagsToShow = note.Tags[:6]
+		}
+		tagsStr := strings.Join(tagsToShow, ", ")
+		if len(note.Tags) > 6 {
+			tagsStr += "..."
+		}
+		fmt.Printf("    %s %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("Tags:"), tagsStr)
+	}
+
+	if showContentSearch && note.Content != "" {
+		content := note.Content
+		if len(content) > 100 {
+			content = content[:100] + "..."
+		}
+		fmt.Printf("    %s %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("Content:"), content)
+	}
+
+	fmt.Printf("    %s %s\n\n", lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6")).Render("ID:"), note.ID)
 }
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"burh/update"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	selfUpdateCheck bool
+	selfUpdateForce bool
+	selfUpdateYes   bool
+)
+
+// selfUpdateCmd represents the self-update command
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update burh to the latest release",
+	Long: `Check GitHub for the latest burh release and, if it is newer than this build,
+download the binary for this platform, verify it against the release's SHA-256
+checksums, and replace the running executable with it.
+
+Installs made with Homebrew or Scoop are left to them: run 'brew upgrade burh' or
+'scoop update burh' instead. Set update_check: false in the config file to turn
+update checks off entirely.`,
+	Example: `  burh self-update
+  burh self-update --check
+  burh self-update --yes`,
+	Args: cobra.NoArgs,
+	Run:  runSelfUpdate,
+}
+
+func init() {
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateCheck, "check", false, "Only report whether an update is available")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateForce, "force", false, "Install the latest release even if this build is as new")
+	selfUpdateCmd.Flags().BoolVarP(&selfUpdateYes, "yes", "y", false, "Don't ask before replacing the executable")
+}
+
+func runSelfUpdate(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	if !cfg.UpdateCheck {
+		fmt.Fprintln(os.Stderr, "Error: update checks are turned off (update_check: false)")
+		os.Exit(exitUsage)
+	}
+
+	exe, err := os.Executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cannot find the running executable: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if manager := update.ManagedBy(exe); manager != "" && !selfUpdateCheck {
+		fmt.Fprintf(os.Stderr, "Error: burh was installed with %s; update it with %s instead\n", manager, manager)
+		os.Exit(exitUsage)
+	}
+
+	release, err := update.Latest()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if !update.Newer(release.Tag, version) && !selfUpdateForce {
+		fmt.Printf("burh %s is up to date\n", version)
+		return
+	}
+	if selfUpdateCheck {
+		fmt.Printf("burh %s is available (this is %s)\n", release.Tag, version)
+		return
+	}
+
+	name := update.AssetName()
+	binary := release.Asset(name)
+	checksums := release.Asset(update.ChecksumsAsset)
+	if binary == nil {
+		fmt.Fprintf(os.Stderr, "Error: release %s has no binary for this platform (%s)\n", release.Tag, name)
+		os.Exit(exitNotFound)
+	}
+	if checksums == nil {
+		fmt.Fprintf(os.Stderr, "Error: release %s has no %s to verify the download with\n", release.Tag, update.ChecksumsAsset)
+		os.Exit(exitNotFound)
+	}
+
+	if !selfUpdateYes && !confirm(fmt.Sprintf("Replace %s (%s) with %s?", exe, version, release.Tag)) {
+		fmt.Println("Update cancelled.")
+		return
+	}
+
+	data, err := update.Download(binary)
+	if err == nil {
+		var sums []byte
+		if sums, err = update.Download(checksums); err == nil {
+			err = update.VerifyChecksum(data, name, sums)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if err := update.Replace(exe, data); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	fmt.Printf("Updated burh to %s\n", release.Tag)
+}
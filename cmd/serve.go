@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"burh/config"
+	"burh/rpc"
+
+	"github.com/spf13/cobra"
+)
+
+var serveSocket string
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve notes to editor plugins over a local socket",
+	Long: `Listen on a Unix socket for JSON-RPC 2.0 requests from editor plugins, so they
+can list, search, read, and create notes without running burh and parsing its
+output. Each request and response is one JSON object on a line. Methods:
+
+  list    {"limit": 50}                  notes, newest first (limit 0 for all)
+  search  {"query": "tag:work meeting"}  notes matching a search query
+  get     {"id": "20240101_120000_x"}    a note with its content
+  create  {"title": "...", "content": "...", "tags": ["a"], "format": "md"}
+
+Notes are sent as in 'burh list --format json', plus their full "path". Only the
+current user can connect to the socket. Stop the server with ctrl+c.`,
+	Example: `  burh serve
+  burh serve --socket /tmp/burh.sock
+  echo '{"jsonrpc":"2.0","id":1,"method":"search","params":{"query":"tag:work"}}' | nc -U ~/.burh.sock`,
+	Args: cobra.NoArgs,
+	Run:  runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveSocket, "socket", "", "Socket path (default is .burh.sock next to the config file)")
+}
+
+func runServe(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	path := serveSocket
+	if path == "" {
+		path = config.SocketPath()
+	}
+	server, err := rpc.Listen(path, noteManager)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if !quiet {
+		fmt.Printf("Serving notes on %s (ctrl+c to stop)\n", path)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := server.Serve(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"burh/notes"
+	"burh/share"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	shareTTL   time.Duration
+	shareAddr  string
+	shareOnce  bool
+	sharePaste bool
+)
+
+// shareCmd represents the share command
+var shareCmd = &cobra.Command{
+	Use:   "share [id]",
+	Short: "Share a read-only view of a note over HTTP",
+	Long: `Serve a rendered, read-only page of a note from this machine at a random,
+unguessable URL, for opening it on another device on the same network. The server
+stops after --ttl, when interrupted, or with --once after the first view.
+
+With --paste, the note is uploaded as plain text to the paste service set as
+paste_endpoint in the config instead, and the service's reply (usually the URL) is printed.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runShare,
+}
+
+func init() {
+	shareCmd.Flags().DurationVar(&shareTTL, "ttl", time.Hour, "How long to serve the note")
+	shareCmd.Flags().StringVar(&shareAddr, "addr", ":0", "Address to listen on (default any free port)")
+	shareCmd.Flags().BoolVar(&shareOnce, "once", false, "Stop after the note has been viewed once")
+	shareCmd.Flags().BoolVar(&sharePaste, "paste", false, "Upload to the configured paste_endpoint instead of serving")
+}
+
+func runShare(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	if err := noteManager.LoadContent(note); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading note: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if sharePaste {
+		if cfg.PasteEndpoint == "" {
+			fmt.Fprintln(os.Stderr, "Error: no paste_endpoint configured")
+			os.Exit(exitUsage)
+		}
+		text, _ := notes.RenderNote(note, "plain")
+		url, err := share.Paste(cfg.PasteEndpoint, text)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitIO)
+		}
+		fmt.Println(url)
+		return
+	}
+
+	server := newShareServer(note)
+	if quiet {
+		for _, url := range server.URLs() {
+			fmt.Println(url)
+		}
+	} else {
+		fmt.Printf("Sharing '%s' until %s (ctrl+c to stop):\n", note.Title, time.Now().Add(shareTTL).Format("15:04"))
+		for _, url := range server.URLs() {
+			fmt.Printf("  %s\n", url)
+		}
+	}
+	serveShared(server)
+}
+
+// newShareServer starts listening for a rendered page of a note, using the share flags
+func newShareServer(note *notes.Note) *share.Server {
+	body, _ := notes.RenderNote(note, "html")
+	server, err := share.NewServer(shareAddr, note.Title, body, shareOnce)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	return server
+}
+
+// serveShared serves a shared note until --ttl passes or the user interrupts
+func serveShared(server *share.Server) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := server.Serve(ctx, shareTTL); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if !quiet {
+		fmt.Println("Stopped sharing")
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// showCmd represents the show command
+var showCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "Show a note",
+	Long: `Print a note's metadata and content.
+With --quiet only the content is printed.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runShow,
+}
+
+func runShow(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	note, err := noteManager.GetNote(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	if quiet {
+		fmt.Println(note.Content)
+		return
+	}
+
+	fmt.Printf("Title: %s\n", hyperlink(note.Title, note.Path()))
+	fmt.Printf("ID: %s\n", note.ID)
+	fmt.Printf("Created: %s\n", note.Created.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Format: %s\n", note.Format)
+	fmt.Printf("Path: %s\n", hyperlink(note.Path(), note.Path()))
+	if note.Locked {
+		fmt.Printf("Locked: yes\n")
+	}
+	if len(note.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(note.Tags, ", "))
+	}
+	for _, key := range cfg.MetadataKeys() {
+		if value, ok := note.Meta[key]; ok {
+			fmt.Printf("%s: %s\n", key, value)
+		}
+	}
+	fmt.Println()
+	fmt.Println(note.Content)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/clipboard"
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snipLang  string
+	snipPrint bool
+	snipLines int
+)
+
+// snipCmd represents the snip command
+var snipCmd = &cobra.Command{
+	Use:   "snip [name]",
+	Short: "List code snippets or copy one to the clipboard",
+	Long: `Manage code snippets: notes tagged "snippet", with the code in a fenced block
+(or an org source block) or as the whole note, and its language in the language
+field or named by the block.
+
+Without a name, lists snippets with a highlighted preview. With a name (a note ID,
+a title, or a unique part of one), copies the snippet's code to the clipboard, or
+prints it highlighted with --print.`,
+	Example: `  burh snip
+  burh snip --lang go
+  burh snip "retry with backoff"
+  burh snip backoff --print`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runSnip,
+}
+
+func init() {
+	snipCmd.Flags().StringVarP(&snipLang, "lang", "l", "", "Only snippets in this language")
+	snipCmd.Flags().BoolVarP(&snipPrint, "print", "p", false, "Print the snippet instead of copying it")
+	snipCmd.Flags().IntVar(&snipLines, "lines", 5, "Lines of code previewed per snippet in the list (0 for none)")
+}
+
+func runSnip(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	snippets, err := noteManager.Snippets(snipLang)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading snippets: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if len(args) == 0 {
+		listSnippets(snippets)
+		return
+	}
+
+	snippet, err := notes.FindSnippet(snippets, args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitNotFound)
+	}
+
+	if snipPrint {
+		fmt.Println(highlightCode(snippet.Code, snippet.Language))
+		return
+	}
+	if err := clipboard.Write(snippet.Code); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if !quiet {
+		fmt.Printf("Copied %s (%d lines)\n", snippet.Note.Title, strings.Count(snippet.Code, "\n")+1)
+	}
+}
+
+// listSnippets prints each snippet's title, language, and the first lines of its code
+func listSnippets(snippets []notes.Snippet) {
+	if len(snippets) == 0 {
+		fmt.Println("No snippets found.")
+		return
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+
+	for i, s := range snippets {
+		if quiet {
+			fmt.Println(s.Note.ID)
+			continue
+		}
+		if i > 0 && snipLines > 0 {
+			fmt.Println()
+		}
+		line := titleStyle.Render(s.Note.Title)
+		if s.Language != "" {
+			line += " " + mutedStyle.Render("["+s.Language+"]")
+		}
+		fmt.Println(line + " " + mutedStyle.Render(s.Note.ID))
+		if snipLines <= 0 {
+			continue
+		}
+
+		code := strings.Split(s.Code, "\n")
+		more := len(code) - snipLines
+		if more > 0 {
+			code = code[:snipLines]
+		}
+		for _, l := range strings.Split(highlightCode(strings.Join(code, "\n"), s.Language), "\n") {
+			fmt.Println("  " + l)
+		}
+		if more > 0 {
+			fmt.Println(mutedStyle.Render(fmt.Sprintf("  … %d more lines", more)))
+		}
+	}
+}
+
+// highlightCode colours code in a language for the terminal
+func highlightCode(code, lang string) string {
+	styles := map[string]lipgloss.Style{
+		notes.TokenKeyword: lipgloss.NewStyle().Foreground(lipgloss.Color("#81A1C1")),
+		notes.TokenString:  lipgloss.NewStyle().Foreground(lipgloss.Color("#A3BE8C")),
+		notes.TokenComment: lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A")).Italic(true),
+		notes.TokenNumber:  lipgloss.NewStyle().Foreground(lipgloss.Color("#B48EAD")),
+	}
+
+	var sb strings.Builder
+	for _, token := range notes.Highlight(code, lang) {
+		style, ok := styles[token.Kind]
+		if !ok {
+			sb.WriteString(token.Text)
+			continue
+		}
+		// Style line by line so each line of the output carries its own colours
+		for i, part := range strings.Split(token.Text, "\n") {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			if part != "" {
+				sb.WriteString(style.Render(part))
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	staleOlderThan  string
+	staleExcludeTag []string
+)
+
+// staleCmd represents the stale command
+var staleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "List notes that have not been modified in a while",
+	Long: `List notes not modified within a period, least recently modified first, for
+periodic review and cleanup. Periods are written like 30d, 6w, 3m, or 1y and default
+to stale_after from the config. Notes tagged with one of stale_exclude_tags
+("reference" by default) are left out; --exclude-tag replaces that list.
+
+The same filter is available in searches as stale:PERIOD, e.g. --query "stale:1y".`,
+	Run: runStale,
+}
+
+func init() {
+	staleCmd.Flags().StringVar(&staleOlderThan, "older-than", "", "Period without changes, e.g. 6m or 1y (default stale_after from the config)")
+	staleCmd.Flags().StringSliceVar(&staleExcludeTag, "exclude-tag", nil, "Tags to leave out (default stale_exclude_tags from the config)")
+	addOutputFlags(staleCmd)
+}
+
+func runStale(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	checkOutputFields(cfg)
+	noteManager := newNoteManager(cfg)
+	splitFormatFlag()
+
+	period := staleOlderThan
+	if period == "" {
+		period = cfg.StaleAfter
+	}
+	cutoff, err := notes.AgeCutoff(period, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	if cmd.Flags().Changed("exclude-tag") {
+		noteManager.SetStaleExcludeTags(staleExcludeTag)
+	}
+
+	stale, err := noteManager.StaleNotes(cutoff)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing notes: %v\n", err)
+		os.Exit(exitIO)
+	}
+	stale = filterNoteFormats(stale)
+
+	if outputNeedsContent() {
+		if err := noteManager.LoadContents(stale); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading notes: %v\n", err)
+			os.Exit(exitIO)
+		}
+	}
+	if printMachineReadable(stale) {
+		return
+	}
+	if quiet {
+		printIDs(stale)
+		return
+	}
+
+	if len(stale) == 0 {
+		fmt.Printf("No notes untouched since %s.\n", cutoff.Format("2006-01-02"))
+		return
+	}
+
+	heading := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).
+		Render(fmt.Sprintf("Found %d notes untouched since %s", len(stale), cutoff.Format("2006-01-02")))
+	fmt.Printf("%s\n\n", heading)
+
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6"))
+	for i, note := range stale {
+		title := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true).Render(note.Title)
+		fmt.Printf("%2d. %s  %s  %s\n", i+1, muted.Render(note.Modified.Format("2006-01-02")), title, muted.Render("("+staleAge(note.Modified)+")"))
+		if len(note.Tags) > 0 {
+			fmt.Printf("    %s %s\n", muted.Render("Tags:"), strings.Join(note.Tags, ", "))
+		}
+		fmt.Printf("    %s %s\n\n", muted.Render("ID:"), note.ID)
+	}
+}
+
+// staleAge describes how long ago a time was, in days, months, or years
+func staleAge(t time.Time) string {
+	days := int(time.Since(t).Hours() / 24)
+	switch {
+	case days >= 365:
+		return fmt.Sprintf("%dy ago", days/365)
+	case days >= 30:
+		return fmt.Sprintf("%dmo ago", days/30)
+	default:
+		return fmt.Sprintf("%dd ago", days)
+	}
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"burh/config"
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsUsage bool
+	statsReset bool
+	statsTop   int
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show statistics about your notes or your use of burh",
+	Long: `Show how many notes there are by format and directory, and the most used tags.
+
+With --usage, show instead which commands you run most and how long they take,
+and which keys you press most in the TUI list. These are counted only when
+usage_metrics: true is set in the config file, in a file next to it; they are
+never sent anywhere. --reset deletes them.`,
+	Example: `  burh stats
+  burh stats --usage
+  burh stats --usage --reset`,
+	Args: cobra.NoArgs,
+	Run:  runStats,
+}
+
+func init() {
+	statsCmd.Flags().BoolVar(&statsUsage, "usage", false, "Show local usage metrics instead")
+	statsCmd.Flags().BoolVar(&statsReset, "reset", false, "Delete the usage metrics (with --usage)")
+	statsCmd.Flags().IntVarP(&statsTop, "top", "n", 10, "Number of tags, commands, or keys to show")
+}
+
+// statsRow is a name and a count in a stats table
+type statsRow struct {
+	name  string
+	count int
+	extra string
+}
+
+func runStats(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	if statsUsage {
+		runUsageStats(cfg)
+		return
+	}
+	if statsReset {
+		fmt.Fprintln(os.Stderr, "Error: --reset only applies to --usage")
+		os.Exit(exitUsage)
+	}
+
+	noteManager := newNoteManager(cfg)
+	noteList, err := noteManager.ListNotes()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing notes: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	formats := map[string]int{}
+	dirs := map[string]int{}
+	for _, note := range noteList {
+		formats[note.Format]++
+		dirs[note.Dir]++
+	}
+	printStatsTable(fmt.Sprintf("%d notes by format", len(noteList)), countRows(formats, 0))
+	printStatsTable("By directory", countRows(dirs, 0))
+	printStatsTable("Top tags", countRows(notes.TagCounts(noteList), statsTop))
+}
+
+// runUsageStats prints the local usage metrics, or deletes them with --reset
+func runUsageStats(cfg *config.Config) {
+	if statsReset {
+		if err := config.ClearUsage(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitIO)
+		}
+		fmt.Println("Usage metrics deleted.")
+		return
+	}
+
+	usage, err := config.LoadUsage()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if len(usage.Commands) == 0 && len(usage.Keys) == 0 {
+		if !cfg.UsageMetrics {
+			fmt.Println("No usage metrics. Set usage_metrics: true in the config file to start counting.")
+		} else {
+			fmt.Println("No usage metrics yet.")
+		}
+		return
+	}
+
+	var commands []statsRow
+	for name, c := range usage.Commands {
+		average := c.Total / time.Duration(c.Runs)
+		if average >= time.Millisecond {
+			average = average.Round(time.Millisecond)
+		} else {
+			average = average.Round(time.Microsecond)
+		}
+		commands = append(commands, statsRow{name, c.Runs, fmt.Sprintf("avg %s, last %s", average, c.Last.Format("2006-01-02"))})
+	}
+	sortRows(commands)
+	if statsTop > 0 && len(commands) > statsTop {
+		commands = commands[:statsTop]
+	}
+
+	printStatsTable("Commands since "+usage.Since.Format("2006-01-02"), commands)
+	if len(usage.Keys) > 0 {
+		printStatsTable("TUI keys", countRows(usage.Keys, statsTop))
+	}
+	if !cfg.UsageMetrics {
+		fmt.Println("Counting is off (usage_metrics: false); these are from before it was turned off.")
+	}
+}
+
+// countRows turns counts into rows, most first, keeping the top ones (all for 0)
+func countRows(counts map[string]int, top int) []statsRow {
+	rows := make([]statsRow, 0, len(counts))
+	for name, n := range counts {
+		rows = append(rows, statsRow{name: name, count: n})
+	}
+	sortRows(rows)
+	if top > 0 && len(rows) > top {
+		rows = rows[:top]
+	}
+	return rows
+}
+
+// sortRows sorts rows by count, most first, then by name
+func sortRows(rows []statsRow) {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].count != rows[j].count {
+			return rows[i].count > rows[j].count
+		}
+		return rows[i].name < rows[j].name
+	})
+}
+
+// printStatsTable prints a heading and its rows of names and counts
+func printStatsTable(heading string, rows []statsRow) {
+	fmt.Printf("%s\n\n", lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0")).Render(heading))
+	if len(rows) == 0 {
+		fmt.Printf("  None.\n\n")
+		return
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row.name))
+	}
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6"))
+	for _, row := range rows {
+		line := fmt.Sprintf("  %-*s  %5d", width, row.name, row.count)
+		if row.extra != "" {
+			line += "  " + muted.Render(row.extra)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
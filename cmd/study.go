@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"burh/config"
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	studyTag    string
+	studyLimit  int
+	studyNew    int
+	studyStatus bool
+)
+
+// studyCmd represents the study command
+var studyCmd = &cobra.Command{
+	Use:   "study",
+	Short: "Quiz yourself on the flashcards that are due",
+	Long: `Quiz yourself on flashcards in notes tagged "flashcards" (or --tag), scheduled with
+the SM-2 spaced repetition algorithm. Cards are "Q:"/"A:" pairs or cloze lines:
+
+  Q: What does SM-2 stand for?
+  A: SuperMemo 2
+  The capital of {{c1::France}} is {{c2::Paris}}.
+
+Each card is shown, then revealed when you press enter; grade your recall from 0
+(forgot) to 5 (perfect), or q to stop. Cards graded below 3 come back at the end of
+the session. Schedules are kept in .burh_study.json next to the config file.`,
+	Args: cobra.NoArgs,
+	Run:  runStudy,
+}
+
+func init() {
+	studyCmd.Flags().StringVar(&studyTag, "tag", notes.FlashcardsTag, "Quiz cards in notes with this tag")
+	studyCmd.Flags().IntVar(&studyLimit, "limit", 50, "Most cards to review in one session (0 for no limit)")
+	studyCmd.Flags().IntVar(&studyNew, "new", 20, "Most new cards to introduce in one session")
+	studyCmd.Flags().BoolVar(&studyStatus, "status", false, "Show how many cards are due and new without quizzing")
+}
+
+func runStudy(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	cards, err := noteManager.Flashcards(studyTag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading flashcards: %v\n", err)
+		os.Exit(exitIO)
+	}
+	statePath := config.StudyStatePath()
+	states, err := notes.LoadCardStates(statePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	// Reviews due first, most overdue first; then new cards, in note order
+	now := time.Now()
+	var due, fresh []notes.Card
+	for _, card := range cards {
+		state, ok := states[card.Key()]
+		switch {
+		case !ok:
+			fresh = append(fresh, card)
+		case state.IsDue(now):
+			due = append(due, card)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return states[due[i].Key()].Due.Before(states[due[j].Key()].Due) })
+
+	if studyStatus || len(due)+len(fresh) == 0 {
+		fmt.Printf("%d cards: %d due, %d new\n", len(cards), len(due), len(fresh))
+		if !studyStatus && len(cards) > 0 {
+			fmt.Println("Nothing to study right now.")
+		}
+		return
+	}
+
+	queue := append(due, fresh[:min(len(fresh), studyNew)]...)
+	if studyLimit > 0 && len(queue) > studyLimit {
+		queue = queue[:studyLimit]
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+	answerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#A3BE8C"))
+
+	reader := bufio.NewReader(os.Stdin)
+	reviewed := map[string]bool{} // Cards already graded this session; repeats don't reschedule
+	total := len(queue)
+	for i := 0; i < len(queue); i++ {
+		card := queue[i]
+		fmt.Println()
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("[%d/%d] %s", min(i+1, total), total, card.Note.Title)))
+		fmt.Println(headerStyle.Render(card.Question))
+		fmt.Print(mutedStyle.Render("(enter to show the answer) "))
+		if _, err := reader.ReadString('\n'); err != nil {
+			break
+		}
+		fmt.Println(answerStyle.Render(card.Answer))
+
+		quality, ok := readGrade(reader)
+		if !ok {
+			break
+		}
+		key := card.Key()
+		if !reviewed[key] {
+			reviewed[key] = true
+			states[key] = states[key].Review(quality, now)
+			if err := notes.SaveCardStates(statePath, states); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(exitIO)
+			}
+		}
+		if quality < 3 {
+			queue = append(queue, card) // Again at the end of the session
+		}
+	}
+
+	fmt.Printf("\nReviewed %d of %d cards.\n", len(reviewed), total)
+}
+
+// readGrade asks for a recall grade from 0 to 5 until it gets one; false means
+// the user quit
+func readGrade(reader *bufio.Reader) (int, bool) {
+	for {
+		fmt.Print("Grade 0-5 (q to quit): ")
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return 0, false
+		}
+		line = strings.TrimSpace(line)
+		if strings.EqualFold(line, "q") {
+			return 0, false
+		}
+		if n, err := strconv.Atoi(line); err == nil && n >= 0 && n <= 5 {
+			return n, true
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var syncClean bool
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Check the notes directories for files left by syncing",
+	Long: `List the files a sync tool left in the notes directories that aren't notes of their
+own. With sync_mode: syncthing, burh hides Syncthing's conflict copies
+(note.sync-conflict-<date>-<time>-<device>.md) from its lists and remembers the
+notes it deletes in .burh-deleted.json in each notes directory, so a deleted note
+brought back unchanged by a device that still had it stays hidden too.
+
+--clean resolves them: of a conflict copy and its note, the one changed last is
+kept as the note, and resurrected notes are deleted again. Each step can be
+reverted with 'burh undo'.`,
+	Example: `  burh sync
+  burh sync --clean
+  burh sync --clean --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  runSync,
+}
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncClean, "clean", false, "Resolve conflict copies and delete resurrected notes")
+	honorsDryRun(syncCmd)
+}
+
+func runSync(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	if cfg.SyncMode == "" && !quiet {
+		fmt.Fprintln(os.Stderr, "Note: sync_mode is not set, so these files also show up as notes")
+	}
+	issues, err := noteManager.SyncIssues()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if len(issues) == 0 {
+		if !quiet {
+			fmt.Println("No sync conflicts or resurrected notes.")
+		}
+		return
+	}
+
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6"))
+	warn := lipgloss.NewStyle().Foreground(lipgloss.Color("#EBCB8B"))
+	if !syncClean {
+		for _, issue := range issues {
+			fmt.Printf("%s %s\n", warn.Render(fmt.Sprintf("%-11s", issue.Kind)), issue.Path)
+			if issue.Kind == notes.SyncConflict && !quiet {
+				fmt.Println(muted.Render("            copy of " + issue.Original))
+			}
+		}
+		if !quiet {
+			fmt.Println()
+			fmt.Println(muted.Render("Run 'burh sync --clean' to resolve them."))
+		}
+		return
+	}
+
+	failed := false
+	for _, issue := range issues {
+		if dryRun {
+			if issue.Kind == notes.SyncConflict {
+				printDryRun("resolve", issue.Path, issue.Original)
+			} else {
+				printDryRun("delete", issue.Path)
+			}
+			continue
+		}
+		outcome, err := noteManager.ResolveSyncIssue(issue)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", issue.Path, err)
+			failed = true
+			continue
+		}
+		if !quiet {
+			fmt.Printf("%s: %s\n", issue.Path, outcome)
+		}
+	}
+	if failed {
+		os.Exit(exitIO)
+	}
+}
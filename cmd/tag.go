@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagAdd    string
+	tagRemove string
+)
+
+// tagCmd represents the tag command
+var tagCmd = &cobra.Command{
+	Use:   "tag [id...]",
+	Short: "Add or remove tags on notes",
+	Long:  `Add or remove tags on one or more notes by ID, or on every note matching --query.`,
+	Run:   runTag,
+}
+
+func init() {
+	addQueryFlag(tagCmd)
+	addYesFlag(tagCmd)
+	tagCmd.Flags().StringVarP(&tagAdd, "add", "a", "", "Comma-separated tags to add")
+	tagCmd.Flags().StringVarP(&tagRemove, "remove", "r", "", "Comma-separated tags to remove")
+}
+
+func runTag(cmd *cobra.Command, args []string) {
+	if tagAdd == "" && tagRemove == "" {
+		fmt.Println("Error: specify --add and/or --remove")
+		os.Exit(exitUsage)
+	}
+
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	selected, err := selectNotes(noteManager, args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	if !confirmBatch("tag", "Tag", selected) {
+		return
+	}
+
+	add := splitTags(tagAdd)
+	remove := splitTags(tagRemove)
+
+	failed := 0
+	for _, note := range selected {
+		if err := noteManager.UpdateTags(note, add, remove); err != nil {
+			fmt.Printf("Error tagging %s: %v\n", note.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Tagged %s: %s\n", note.ID, strings.Join(note.Tags, ", "))
+	}
+
+	if failed > 0 {
+		os.Exit(exitIO)
+	}
+}
+
+// splitTags parses a comma-separated tag list
+func splitTags(s string) []string {
+	var tagList []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tagList = append(tagList, tag)
+		}
+	}
+	return tagList
+}
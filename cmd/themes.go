@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/config"
+	"burh/tui"
+
+	"github.com/spf13/cobra"
+)
+
+// tryTheme is the theme 'burh themes try' opens the TUI in
+var tryTheme *config.Theme
+
+// themesCmd represents the themes command
+var themesCmd = &cobra.Command{
+	Use:   "themes",
+	Short: "Preview the built-in color themes",
+	Long: `Show each built-in theme's colors, and how the note list and status bar look
+in it. 'burh themes try NAME' opens the TUI in a theme without changing the config;
+to keep one, copy its colors under theme: in the config file.`,
+	Example: `  burh themes
+  burh themes try dracula`,
+	Args: cobra.NoArgs,
+	Run:  runThemes,
+}
+
+// themesTryCmd represents the themes try command
+var themesTryCmd = &cobra.Command{
+	Use:       "try [name]",
+	Short:     "Open the TUI in a built-in theme, for this run only",
+	Example:   `  burh themes try gruvbox`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: config.ThemeNames(),
+	Run:       runThemesTry,
+}
+
+func init() {
+	themesCmd.AddCommand(themesTryCmd)
+}
+
+func runThemes(cmd *cobra.Command, args []string) {
+	for i, name := range config.ThemeNames() {
+		if i > 0 {
+			fmt.Println()
+		}
+		label := name
+		if config.Themes[name] == config.DefaultConfig().Theme {
+			label += " (default)"
+		}
+		fmt.Println(label)
+		fmt.Println(tui.ThemePreview(config.Themes[name]))
+	}
+}
+
+func runThemesTry(cmd *cobra.Command, args []string) {
+	theme, ok := config.Themes[strings.ToLower(args[0])]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: no theme named %q (expected %s)\n", args[0], strings.Join(config.ThemeNames(), ", "))
+		os.Exit(exitNotFound)
+	}
+	tryTheme = &theme
+	runTUI(cmd, nil)
+}
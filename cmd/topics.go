@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	topicsTop int
+	topicsBy  string
+)
+
+// topicsCmd represents the topics command
+var topicsCmd = &cobra.Command{
+	Use:   "topics",
+	Short: "Show the most frequent terms in your notes",
+	Long: `Count the words in note titles and contents, leaving out common stopwords,
+numbers, and very short words, and list the most frequent ones. Use --by to get a
+separate list per tag, month, directory, or format, and --query to look at a subset.`,
+	Run: runTopics,
+}
+
+func init() {
+	topicsCmd.Flags().IntVarP(&topicsTop, "top", "n", 20, "Number of terms to show")
+	topicsCmd.Flags().StringVar(&topicsBy, "by", "", "Show terms per "+strings.Join(notes.GroupFields, ", "))
+	addQueryFlag(topicsCmd)
+}
+
+func runTopics(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	var noteList []*notes.Note
+	var err error
+	if batchQuery != "" {
+		noteList, err = noteManager.SearchNotes(batchQuery)
+	} else {
+		noteList, err = noteManager.ListNotes()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing notes: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	if err := noteManager.LoadContents(noteList); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading notes: %v\n", err)
+		os.Exit(exitIO)
+	}
+
+	if topicsBy == "" {
+		printTopics(fmt.Sprintf("Top terms across %d notes", len(noteList)), notes.TopTerms(noteList, topicsTop))
+		return
+	}
+
+	groups, err := notes.GroupNotes(noteList, topicsBy)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	for _, group := range groups {
+		printTopics(fmt.Sprintf("%s: %s (%d notes)", topicsBy, group.Name, len(group.Notes)), notes.TopTerms(group.Notes, topicsTop))
+	}
+}
+
+// printTopics prints a heading and a ranked list of terms with their counts
+func printTopics(heading string, terms []notes.TermCount) {
+	if quiet {
+		for _, t := range terms {
+			fmt.Println(t.Term)
+		}
+		return
+	}
+
+	fmt.Printf("%s\n\n", lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88C0D0")).Render(heading))
+	if len(terms) == 0 {
+		fmt.Printf("  No terms found.\n\n")
+		return
+	}
+
+	width := 0
+	for _, t := range terms {
+		if len(t.Term) > width {
+			width = len(t.Term)
+		}
+	}
+	count := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C8DA6"))
+	for i, t := range terms {
+		fmt.Printf("%3d. %-*s  %s\n", i+1, width, t.Term, count.Render(fmt.Sprintf("%d", t.Count)))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"burh/notes"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var undoList bool
+
+// undoCmd represents the undo command
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the most recent note operation",
+	Long: `Revert the most recent create, edit, delete, or move of a note, from the CLI or the
+TUI. Operations are recorded in .burh_journal.json next to the config file; the
+last undo_limit of them (50 by default) can be undone in turn. Edits made in the
+editor burh opens are included. A note changed outside burh since its operation
+is left alone. 'burh redo' applies an undone
+operation again, until a new operation is recorded.`,
+	Example: `  burh undo
+  burh undo --list`,
+	Args: cobra.NoArgs,
+	Run:  runUndo,
+}
+
+// redoCmd represents the redo command
+var redoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Apply the most recently undone note operation again",
+	Args:  cobra.NoArgs,
+	Run:   runUndo,
+}
+
+func init() {
+	undoCmd.Flags().BoolVarP(&undoList, "list", "l", false, "List the operations that can be undone, most recent first")
+}
+
+func runUndo(cmd *cobra.Command, args []string) {
+	cfg := getConfig()
+	noteManager := newNoteManager(cfg)
+
+	if undoList {
+		listJournal(noteManager)
+		return
+	}
+
+	verb, replay := "Undid", noteManager.Undo
+	if cmd.Name() == "redo" {
+		verb, replay = "Redid", noteManager.Redo
+	}
+	op, err := replay()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitNotFound)
+	}
+	if !quiet {
+		fmt.Printf("%s %s of %s (%s)\n", verb, op.Kind, op.NoteID, op.Title)
+	}
+}
+
+// listJournal prints the operations that can be undone, then those that can be redone
+func listJournal(noteManager *notes.Manager) {
+	journal, err := noteManager.Journal()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitIO)
+	}
+	if len(journal.Done)+len(journal.Undone) == 0 {
+		fmt.Println("Nothing to undo.")
+		return
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4C566A"))
+	for i := len(journal.Done) - 1; i >= 0; i-- {
+		op := journal.Done[i]
+		fmt.Printf("%s  %-6s  %s  %s\n", op.Time.Format("2006-01-02 15:04:05"), op.Kind, op.NoteID, op.Title)
+	}
+	for i := len(journal.Undone) - 1; i >= 0; i-- {
+		op := journal.Undone[i]
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("%s  %-6s  %s  %s  (undone)", op.Time.Format("2006-01-02 15:04:05"), op.Kind, op.NoteID, op.Title)))
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"time"
+
+	"burh/config"
+
+	"github.com/spf13/cobra"
+)
+
+// commandStart is when the running command started, for usage metrics
+var commandStart time.Time
+
+// beforeCommand runs before every command: it notes the start time, loads the
+// config unless the command does without it, turns off colors if asked, and
+// refuses --dry-run where it isn't supported
+func beforeCommand(cmd *cobra.Command, args []string) error {
+	commandStart = time.Now()
+	if cmd.Annotations[noConfigAnnotation] == "" {
+		// Load the config once flags are parsed
+		initConfig()
+	}
+	applyColorMode()
+	return checkDryRun(cmd, args)
+}
+
+// recordUsage counts a completed command in the local usage metrics when
+// usage_metrics is on. Commands that fail exit before this runs and aren't counted.
+func recordUsage(cmd *cobra.Command, args []string) {
+	if globalConfig == nil || !globalConfig.UsageMetrics {
+		return
+	}
+	_ = config.RecordCommand(cmd.CommandPath(), time.Since(commandStart))
+}
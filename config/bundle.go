@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HomePlaceholder stands for the home directory in the paths of a config bundle,
+// so the bundle works on a machine where it is elsewhere
+const HomePlaceholder = "{home}"
+
+// bundleVersion is the version of the config bundle format
+const bundleVersion = 1
+
+// Bundle is a shareable copy of the config file, with the home directory in its
+// paths replaced by HomePlaceholder
+type Bundle struct {
+	Version  int                    `yaml:"burh_bundle"`
+	Exported time.Time              `yaml:"exported"`
+	Config   map[string]interface{} `yaml:"config"`
+}
+
+// ExportBundle reads the config file into a bundle. Paths outside the home
+// directory are kept as they are and returned, since they may not exist elsewhere.
+func ExportBundle() (*Bundle, []string, error) {
+	data, err := os.ReadFile(getConfigPath())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	settings := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	homeDir, _ := os.UserHomeDir()
+	var fixed []string
+	bundled := mapStrings(settings, func(s string) string {
+		if homeDir != "" && (s == homeDir || strings.HasPrefix(s, homeDir+string(filepath.Separator))) {
+			return HomePlaceholder + filepath.ToSlash(strings.TrimPrefix(s, homeDir))
+		}
+		if filepath.IsAbs(s) {
+			fixed = append(fixed, s)
+		}
+		return s
+	})
+	return &Bundle{Version: bundleVersion, Exported: time.Now(), Config: bundled.(map[string]interface{})}, fixed, nil
+}
+
+// WriteBundle writes a bundle as YAML to path
+func WriteBundle(bundle *Bundle, path string) error {
+	data, err := MarshalBundle(bundle)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// MarshalBundle encodes a bundle as YAML
+func MarshalBundle(bundle *Bundle) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("# burh config bundle; import it with 'burh config import'\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(bundle); err != nil {
+		return nil, fmt.Errorf("failed to encode bundle: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseBundle decodes a bundle written by WriteBundle
+func ParseBundle(data []byte) (*Bundle, error) {
+	var bundle Bundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %w", err)
+	}
+	if bundle.Version == 0 || bundle.Config == nil {
+		return nil, fmt.Errorf("not a burh config bundle")
+	}
+	if bundle.Version > bundleVersion {
+		return nil, fmt.Errorf("the bundle is from a newer version of burh (bundle version %d)", bundle.Version)
+	}
+	return &bundle, nil
+}
+
+// ImportBundle replaces the config file with the bundle's config, putting this
+// machine's home directory in place of the placeholder. The config file it
+// replaces is kept with a .bak extension and put back if the new config doesn't
+// load. It returns the new config.
+func ImportBundle(bundle *Bundle) (*Config, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the home directory: %w", err)
+	}
+	settings := mapStrings(bundle.Config, func(s string) string {
+		if rest, ok := strings.CutPrefix(s, HomePlaceholder); ok {
+			return homeDir + filepath.FromSlash(rest)
+		}
+		return s
+	})
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(settings); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	configPath := getConfigPath()
+	backup := ImportBackupPath()
+	hadConfig := true
+	if err := os.Rename(configPath, backup); errors.Is(err, os.ErrNotExist) {
+		hadConfig = false
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to back up config file: %w", err)
+	}
+	restore := func() {
+		if hadConfig {
+			os.Rename(backup, configPath)
+		} else {
+			os.Remove(configPath)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		restore()
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(configPath, buf.Bytes(), 0644); err != nil {
+		restore()
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		restore()
+		return nil, fmt.Errorf("the bundle's config is invalid: %w", err)
+	}
+	return cfg, nil
+}
+
+// ImportBackupPath returns the path ImportBundle keeps the replaced config file at
+func ImportBackupPath() string {
+	return getConfigPath() + ".bak"
+}
+
+// mapStrings returns a copy of a decoded YAML value with f applied to every string in it
+func mapStrings(value interface{}, f func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return f(v)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = mapStrings(item, f)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = mapStrings(item, f)
+		}
+		return out
+	default:
+		return v
+	}
+}
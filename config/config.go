@@ -2,18 +2,119 @@ package config
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
+	"time"
 
+	"burh/i18n"
+	"burh/paths"
+
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
 // Config represents the application configuration
 type Config struct {
-	NotesDirs []string `mapstructure:"notes_dirs"` // Changed from NotesDir to NotesDirs
-	Theme     Theme    `mapstructure:"theme"`
+	NotesDirs       []string          `mapstructure:"notes_dirs"` // Changed from NotesDir to NotesDirs
+	Theme           Theme             `mapstructure:"theme"`
+	MetadataFields  []MetadataField   `mapstructure:"metadata_fields"`
+	DirTimeout      string            `mapstructure:"dir_timeout"`    // Default read timeout for every notes directory, e.g. "10s"
+	DirTimeouts     []DirTimeout      `mapstructure:"dir_timeouts"`   // Per-directory overrides for slow (e.g. network) directories
+	Recursive       bool              `mapstructure:"recursive"`      // Also scan subdirectories of the notes directories
+	ReadOnlyDirs    []string          `mapstructure:"read_only_dirs"` // Notes directories listed and searched but never written, e.g. a team share
+	SortColumn      string            `mapstructure:"sort_column"`    // TUI list sort column: "date", "format", "title", "tags", or "modified"
+	SortDescending  bool              `mapstructure:"sort_descending"`
+	PageSize        int               `mapstructure:"page_size"`          // TUI notes per page; 0 fits the terminal height
+	ListDensity     string            `mapstructure:"list_density"`       // TUI list rows: "compact", or "comfortable" with a blank line between
+	TitleOverflow   string            `mapstructure:"title_overflow"`     // TUI titles too long for their column: "truncate", "wrap" onto a second line, or "fit" the terminal width
+	Locale          string            `mapstructure:"locale"`             // Language of the UI, e.g. "de"; empty to follow LANG
+	Display         Display           `mapstructure:"display"`            // Icons for formats and tags in note lists
+	ModifiedColumn  bool              `mapstructure:"modified_column"`    // Show when each note was edited in the TUI list, e.g. "2h ago", and who edited the selected one
+	BinaryNotes     bool              `mapstructure:"binary_notes"`       // List PDFs and images in the notes directories
+	Extractors      []Extractor       `mapstructure:"extractors"`         // Commands that pull searchable text out of binary notes
+	OCRCommand      string            `mapstructure:"ocr_command"`        // Command run by 'burh ocr' on image notes; {path} is the image
+	StaleAfter      string            `mapstructure:"stale_after"`        // Default period for 'burh stale' and the TUI stale filter, e.g. "1y"
+	StaleExclude    []string          `mapstructure:"stale_exclude_tags"` // Tags whose notes are never reported as stale
+	PasteEndpoint   string            `mapstructure:"paste_endpoint"`     // URL 'burh share --paste' POSTs notes to
+	LinkTitles      bool              `mapstructure:"link_titles"`        // Fetch page titles for bare URLs in quick captures and 'burh insert', and write them as links
+	RecordTypes     []RecordType      `mapstructure:"record_types"`       // Structured notes holding key: value records, e.g. contacts
+	BookmarksNote   string            `mapstructure:"bookmarks_note"`     // "single" collects bookmarks in one note, "monthly" in one note per month
+	BookmarksFormat string            `mapstructure:"bookmarks_format"`   // Format of new bookmarks notes
+	SearchLocale    string            `mapstructure:"search_locale"`      // Language of search case folding, e.g. "tr"; empty for language-independent
+	SearchStemming  bool              `mapstructure:"search_stemming"`    // Match other forms of English search words, e.g. "running" finds "run"
+	SearchFuzzy     int               `mapstructure:"search_fuzzy"`       // Typos allowed per search word: 0 (off), 1, or 2
+	Retention       []RetentionRule   `mapstructure:"retention"`          // Rules 'burh gc' uses to archive old notes by tag
+	InboxTag        string            `mapstructure:"inbox_tag"`          // Tag quick captures get until refiled; empty for no inbox
+	KanbanBy        string            `mapstructure:"kanban_by"`          // What the kanban board's columns are: "status" values or "tag"s
+	KanbanColumns   []string          `mapstructure:"kanban_columns"`     // Kanban board columns, left to right
+	Habits          []string          `mapstructure:"habits"`             // Habits tracked by 'burh habit', besides those already logged
+	HabitsFormat    string            `mapstructure:"habits_format"`      // Format of the habits note when it is created
+	BooksFormat     string            `mapstructure:"books_format"`       // Format of the library note when it is created
+	Pantry          []string          `mapstructure:"pantry"`             // Staples always on hand when matching recipes, e.g. salt
+	FocusWidth      int               `mapstructure:"focus_width"`        // Column the TUI's focus mode wraps text at
+	FocusAutosave   string            `mapstructure:"focus_autosave"`     // How often focus mode saves, e.g. "5s"; empty to save only on exit
+	UndoLimit       int               `mapstructure:"undo_limit"`         // Note operations kept for 'burh undo'; 0 turns the journal off
+	Safety          Safety            `mapstructure:"safety"`             // Extra confirmation for risky deletions
+	Confirmations   Confirmations     `mapstructure:"confirmations"`      // Which actions ask before going ahead
+	Accessibility   Accessibility     `mapstructure:"accessibility"`      // High contrast, markers besides colors, and reduced motion
+	Keys            map[string]string `mapstructure:"keys"`               // Keys of TUI list actions, by action, e.g. new: "a"; others keep their default keys
+	AuditLog        bool              `mapstructure:"audit_log"`          // Log every change to a note for 'burh log'
+	UsageMetrics    bool              `mapstructure:"usage_metrics"`      // Count command runs and TUI keys in a local file for 'burh stats --usage'; never sent anywhere
+	UpdateCheck     bool              `mapstructure:"update_check"`       // Allow 'burh self-update' to contact GitHub; false turns update checks off entirely
+	SyncMode        string            `mapstructure:"sync_mode"`          // How the notes directories are synced between devices: "syncthing", or empty for not at all
+	Hyperlinks      string            `mapstructure:"hyperlinks"`         // Make note titles in list, search, and show output open the note: "auto", "always", or "never"
+	DefaultView     DefaultView       `mapstructure:"default_view"`       // Notes the TUI and 'burh list' leave out, and their order, until asked for everything
+	Series          []Series          `mapstructure:"series"`             // Recurring notes 'burh generate-due' creates, e.g. a weekly review
+}
+
+// Extractor sets the command that prints the text of binary notes with an extension
+type Extractor struct {
+	Extension string `mapstructure:"extension" yaml:"extension"` // e.g. ".pdf"
+	Command   string `mapstructure:"command" yaml:"command"`     // e.g. "pdftotext {path} -"
+}
+
+// DirTimeout sets the read timeout for a single notes directory
+type DirTimeout struct {
+	Path    string `mapstructure:"path" yaml:"path"`
+	Timeout string `mapstructure:"timeout" yaml:"timeout"`
+}
+
+// MetadataField declares a user-defined metadata key and its value type
+type MetadataField struct {
+	Name     string `mapstructure:"name" yaml:"name"`
+	Type     string `mapstructure:"type" yaml:"type"`         // "string", "number", "bool", "date", or "url"
+	Required bool   `mapstructure:"required" yaml:"required"` // Only checked for record fields
+}
+
+// RecordType declares a kind of structured note, such as contacts, whose records
+// are typed like metadata fields
+type RecordType struct {
+	Name   string          `mapstructure:"name" yaml:"name"`
+	Tag    string          `mapstructure:"tag" yaml:"tag"` // Tag of the notes holding the records; defaults to the name
+	Fields []MetadataField `mapstructure:"fields" yaml:"fields"`
+}
+
+// RetentionRule archives notes with a tag once they have gone unchanged for a period
+type RetentionRule struct {
+	Tag   string `mapstructure:"tag" yaml:"tag"`
+	After string `mapstructure:"after" yaml:"after"` // e.g. "30d", "6w", "3m", or "1y"
+}
+
+// Series is a recurring note, such as a weekly review or a biweekly 1:1, created
+// from a template by 'burh generate-due'
+type Series struct {
+	Name     string   `mapstructure:"name" yaml:"name"`         // e.g. "Weekly review"; instances are tagged with it, e.g. weekly-review
+	Every    string   `mapstructure:"every" yaml:"every"`       // A weekday such as "monday", "weekly", "biweekly", "monthly", or a period such as "2w"
+	Start    string   `mapstructure:"start" yaml:"start"`       // Date of the first instance, YYYY-MM-DD; periods count from it
+	Title    string   `mapstructure:"title" yaml:"title"`       // Title of each instance, {date} being its date; defaults to the name and date
+	Tags     []string `mapstructure:"tags" yaml:"tags"`         // Tags besides the series tag
+	Format   string   `mapstructure:"format" yaml:"format"`     // Format of the instances; defaults to md
+	Template string   `mapstructure:"template" yaml:"template"` // Content of each instance; {date} and {title} are filled in
 }
 
 // Theme represents the color theme configuration
@@ -27,6 +128,76 @@ type Theme struct {
 	Muted     string `mapstructure:"muted"`
 }
 
+// Safety sets when deleting notes takes more than a y/n answer
+type Safety struct {
+	BulkDelete int    `mapstructure:"bulk_delete"` // Deleting more notes than this at once asks for DELETE to be typed; 0 never does
+	PinnedTag  string `mapstructure:"pinned_tag"`  // Deleting a note with this tag asks for its title to be typed; empty for none
+}
+
+// DefaultView narrows what the TUI shows at startup and what 'burh list' prints,
+// e.g. to keep archived notes out of sight. The TUI's A key and 'burh list --all'
+// show every note.
+type DefaultView struct {
+	ExcludeTags []string `mapstructure:"exclude_tags"` // Notes with any of these tags are left out
+	ExcludeDirs []string `mapstructure:"exclude_dirs"` // Directories left out: paths, or subdirectory names of the notes directories
+	PrimaryOnly bool     `mapstructure:"primary_only"` // Only show notes in the first of notes_dirs
+	Sort        string   `mapstructure:"sort"`         // "date", "modified", "format", "title", or "tags"; empty keeps sort_column
+	Descending  bool     `mapstructure:"descending"`
+}
+
+// Confirmations sets which actions ask for a y/n answer before going ahead. The
+// typed confirmations of Safety are set separately.
+type Confirmations struct {
+	Delete          bool     `mapstructure:"delete"`           // The TUI asks before deleting a note
+	QuitWithDraft   bool     `mapstructure:"quit_with_draft"`  // The TUI asks before quitting with unsaved create or edit form input
+	Bulk            []string `mapstructure:"bulk"`             // Batch commands that ask before changing several notes: delete, tag, move, archive
+	ImportOverwrite bool     `mapstructure:"import_overwrite"` // 'burh config import' asks before replacing the config
+}
+
+// BulkCommands are the batch commands confirmations.bulk can name
+var BulkCommands = []string{"delete", "tag", "move", "archive"}
+
+// ConfirmsBulk checks if a batch command asks before changing several notes
+func (c Confirmations) ConfirmsBulk(command string) bool {
+	for _, name := range c.Bulk {
+		if strings.EqualFold(name, command) {
+			return true
+		}
+	}
+	return false
+}
+
+// Accessibility makes the TUI easier to use with low vision or a screen reader
+type Accessibility struct {
+	HighContrast  bool `mapstructure:"high_contrast"`  // Use the high-contrast theme instead of theme
+	Markers       bool `mapstructure:"markers"`        // Mark the selection with ">" and warnings with "[!]", not only by color
+	ReducedMotion bool `mapstructure:"reduced_motion"` // Show search results once the search is done rather than as they are found
+}
+
+// ActiveTheme returns the theme the TUI is drawn in: the high-contrast theme when
+// accessibility.high_contrast is on, the configured theme otherwise
+func (c *Config) ActiveTheme() Theme {
+	if c.Accessibility.HighContrast {
+		return Themes["high-contrast"]
+	}
+	return c.Theme
+}
+
+// Display sets the icons shown before note formats and tags in note lists, the
+// characters the TUI is drawn with, and how it shows images
+type Display struct {
+	Icons    string            `mapstructure:"icons"`     // "emoji", "nerdfont" for Nerd Font glyphs, or "off"
+	TagIcons map[string]string `mapstructure:"tag_icons"` // Icon shown before each tag, e.g. work: "💼"
+	Ascii    bool              `mapstructure:"ascii"`     // Draw the TUI's borders, separators, and arrows with ASCII characters
+	Images   string            `mapstructure:"images"`    // How the outline preview shows images: "auto", "kitty", "iterm", "sixel", or "off"
+}
+
+// formatIcons are the icons for each note format, by icon set
+var formatIcons = map[string]map[string]string{
+	"emoji":    {"md": "📝", "org": "◆", "txt": "≡", "pdf": "📕", "": "📄"},
+	"nerdfont": {"md": "\ue609", "org": "\ue633", "txt": "\uf15c", "pdf": "\uf1c1", "": "\uf15b"},
+}
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	homeDir, _ := os.UserHomeDir()
@@ -43,23 +214,65 @@ func DefaultConfig() *Config {
 			Info:      "#81A1C1", // Nord Light Blue
 			Muted:     "#5E81AC", // Nord Dark Blue
 		},
+		SortColumn:      "date",
+		ListDensity:     "compact",
+		TitleOverflow:   "truncate",
+		OCRCommand:      "tesseract {path} -",
+		StaleAfter:      "1y",
+		StaleExclude:    []string{"reference"},
+		BookmarksNote:   "single",
+		BookmarksFormat: "md",
+		InboxTag:        "inbox",
+		KanbanBy:        "status",
+		KanbanColumns:   []string{"todo", "doing", "done"},
+		HabitsFormat:    "org",
+		BooksFormat:     "org",
+		Pantry:          []string{"salt", "pepper", "water"},
+		FocusWidth:      72,
+		FocusAutosave:   "5s",
+		UndoLimit:       50,
+		Safety: Safety{
+			BulkDelete: 10,
+			PinnedTag:  "pinned",
+		},
+		Confirmations: Confirmations{
+			Delete:          true,
+			Bulk:            []string{"delete"},
+			ImportOverwrite: true,
+		},
+		AuditLog:    true,
+		UpdateCheck: true,
+		Hyperlinks:  "auto",
+		Display: Display{
+			Icons:  "off",
+			Images: "auto",
+		},
 	}
 }
 
 // expandTilde expands ~ to the user's home directory
 func expandTilde(path string) string {
-	if strings.HasPrefix(path, "~/") {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return path // Return original path if we can't get home dir
-		}
-		return filepath.Join(homeDir, path[2:])
-	}
-	return path
+	return paths.ExpandHome(path)
 }
 
+// ErrNoConfigFile is returned by ReloadConfig when the config file is missing
+var ErrNoConfigFile = errors.New("config file not found")
+
 // LoadConfig loads configuration from file or creates default
 func LoadConfig() (*Config, error) {
+	return loadConfig(true)
+}
+
+// ReloadConfig loads the configuration from file again, as while the TUI runs.
+// Unlike LoadConfig it never prompts for a notes directory; a missing config file
+// is ErrNoConfigFile.
+func ReloadConfig() (*Config, error) {
+	return loadConfig(false)
+}
+
+// loadConfig loads configuration from file, with firstRun prompting for a notes
+// directory to create it when it is missing
+func loadConfig(firstRun bool) (*Config, error) {
 	configPath := getConfigPath()
 
 	viper.SetConfigFile(configPath) // Use SetConfigFile instead of SetConfigName/AddConfigPath
@@ -74,18 +287,91 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault("theme.error", defaultConfig.Theme.Error)
 	viper.SetDefault("theme.info", defaultConfig.Theme.Info)
 	viper.SetDefault("theme.muted", defaultConfig.Theme.Muted)
+	viper.SetDefault("metadata_fields", []MetadataField{})
+	viper.SetDefault("dir_timeout", "")
+	viper.SetDefault("dir_timeouts", []DirTimeout{})
+	viper.SetDefault("recursive", false)
+	viper.SetDefault("read_only_dirs", []string{})
+	viper.SetDefault("sort_column", defaultConfig.SortColumn)
+	viper.SetDefault("sort_descending", false)
+	viper.SetDefault("page_size", 0)
+	viper.SetDefault("list_density", defaultConfig.ListDensity)
+	viper.SetDefault("title_overflow", defaultConfig.TitleOverflow)
+	viper.SetDefault("locale", defaultConfig.Locale)
+	viper.SetDefault("display.icons", defaultConfig.Display.Icons)
+	viper.SetDefault("display.tag_icons", defaultConfig.Display.TagIcons)
+	viper.SetDefault("display.ascii", false)
+	viper.SetDefault("display.images", defaultConfig.Display.Images)
+	viper.SetDefault("modified_column", defaultConfig.ModifiedColumn)
+	viper.SetDefault("binary_notes", false)
+	viper.SetDefault("extractors", []Extractor{})
+	viper.SetDefault("ocr_command", defaultConfig.OCRCommand)
+	viper.SetDefault("stale_after", defaultConfig.StaleAfter)
+	viper.SetDefault("stale_exclude_tags", defaultConfig.StaleExclude)
+	viper.SetDefault("paste_endpoint", "")
+	viper.SetDefault("link_titles", false)
+	viper.SetDefault("record_types", []RecordType{})
+	viper.SetDefault("bookmarks_note", defaultConfig.BookmarksNote)
+	viper.SetDefault("bookmarks_format", defaultConfig.BookmarksFormat)
+	viper.SetDefault("search_locale", "")
+	viper.SetDefault("search_stemming", false)
+	viper.SetDefault("search_fuzzy", 0)
+	viper.SetDefault("retention", []RetentionRule{})
+	viper.SetDefault("inbox_tag", defaultConfig.InboxTag)
+	viper.SetDefault("kanban_by", defaultConfig.KanbanBy)
+	viper.SetDefault("kanban_columns", defaultConfig.KanbanColumns)
+	viper.SetDefault("habits", []string{})
+	viper.SetDefault("habits_format", defaultConfig.HabitsFormat)
+	viper.SetDefault("books_format", defaultConfig.BooksFormat)
+	viper.SetDefault("pantry", defaultConfig.Pantry)
+	viper.SetDefault("focus_width", defaultConfig.FocusWidth)
+	viper.SetDefault("focus_autosave", defaultConfig.FocusAutosave)
+	viper.SetDefault("undo_limit", defaultConfig.UndoLimit)
+	viper.SetDefault("safety.bulk_delete", defaultConfig.Safety.BulkDelete)
+	viper.SetDefault("safety.pinned_tag", defaultConfig.Safety.PinnedTag)
+	viper.SetDefault("confirmations.delete", defaultConfig.Confirmations.Delete)
+	viper.SetDefault("confirmations.quit_with_draft", defaultConfig.Confirmations.QuitWithDraft)
+	viper.SetDefault("confirmations.bulk", defaultConfig.Confirmations.Bulk)
+	viper.SetDefault("confirmations.import_overwrite", defaultConfig.Confirmations.ImportOverwrite)
+	viper.SetDefault("keys", map[string]string{})
+	viper.SetDefault("accessibility.high_contrast", false)
+	viper.SetDefault("accessibility.markers", false)
+	viper.SetDefault("accessibility.reduced_motion", false)
+	viper.SetDefault("audit_log", defaultConfig.AuditLog)
+	viper.SetDefault("usage_metrics", defaultConfig.UsageMetrics)
+	viper.SetDefault("update_check", defaultConfig.UpdateCheck)
+	viper.SetDefault("sync_mode", defaultConfig.SyncMode)
+	viper.SetDefault("hyperlinks", defaultConfig.Hyperlinks)
+	viper.SetDefault("default_view.exclude_tags", []string{})
+	viper.SetDefault("default_view.exclude_dirs", []string{})
+	viper.SetDefault("default_view.primary_only", false)
+	viper.SetDefault("default_view.sort", "")
+	viper.SetDefault("default_view.descending", false)
+	viper.SetDefault("series", []Series{})
 
 	// Try to read config file
 	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+		_, notFound := err.(viper.ConfigFileNotFoundError)
+		switch {
+		case workspace != nil && (notFound || errors.Is(err, os.ErrNotExist)):
+			// A workspace has its own notes directory and can do without a config file
+		case !firstRun && (notFound || errors.Is(err, os.ErrNotExist)):
+			return nil, ErrNoConfigFile
+		case notFound:
 			// Config file not found, prompt user for notes directory
 			return promptForNotesDirectory(configPath, defaultConfig)
+		default:
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+	if workspace != nil {
+		if err := mergeWorkspaceConfig(configPath); err != nil {
+			return nil, err
 		}
-		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	var config Config
-	if err := viper.Unmarshal(&config); err != nil {
+	if err := viper.Unmarshal(&config, viper.DecodeHook(decodeHook)); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
@@ -93,10 +379,81 @@ func LoadConfig() (*Config, error) {
 	for i, dir := range config.NotesDirs {
 		config.NotesDirs[i] = expandTilde(dir)
 	}
+	if workspace != nil {
+		config.NotesDirs = []string{workspace.NotesDir}
+	}
+
+	if _, err := config.DirTimeoutMap(); err != nil {
+		return nil, err
+	}
+	if err := config.checkMetadataFields(); err != nil {
+		return nil, err
+	}
+	if _, err := config.FocusAutosaveInterval(); err != nil {
+		return nil, err
+	}
+	if config.ListDensity != "compact" && config.ListDensity != "comfortable" {
+		return nil, fmt.Errorf("invalid list_density %q (expected compact or comfortable)", config.ListDensity)
+	}
+	switch config.TitleOverflow {
+	case "truncate", "wrap", "fit":
+	default:
+		return nil, fmt.Errorf("invalid title_overflow %q (expected truncate, wrap, or fit)", config.TitleOverflow)
+	}
+	if config.Locale != "" && !i18n.Supported(config.Locale) {
+		return nil, fmt.Errorf("invalid locale %q (expected %s)", config.Locale, strings.Join(i18n.Locales(), ", "))
+	}
+	switch config.Display.Icons {
+	case "emoji", "nerdfont", "off":
+	default:
+		return nil, fmt.Errorf("invalid display.icons %q (expected emoji, nerdfont, or off)", config.Display.Icons)
+	}
+	switch config.Display.Images {
+	case "auto", "kitty", "iterm", "sixel", "off":
+	default:
+		return nil, fmt.Errorf("invalid display.images %q (expected auto, kitty, iterm, sixel, or off)", config.Display.Images)
+	}
+	switch config.SyncMode {
+	case "", "syncthing":
+	default:
+		return nil, fmt.Errorf("invalid sync_mode %q (expected syncthing or empty)", config.SyncMode)
+	}
+	switch config.Hyperlinks {
+	case "auto", "always", "never":
+	default:
+		return nil, fmt.Errorf("invalid hyperlinks %q (expected auto, always, or never)", config.Hyperlinks)
+	}
+	known := Confirmations{Bulk: BulkCommands}
+	for _, name := range config.Confirmations.Bulk {
+		if !known.ConfirmsBulk(name) {
+			return nil, fmt.Errorf("invalid confirmations.bulk command %q (expected %s)", name, strings.Join(BulkCommands, ", "))
+		}
+	}
+	if err := validateKeys(config.Keys); err != nil {
+		return nil, err
+	}
+	switch config.DefaultView.Sort {
+	case "", "date", "modified", "format", "title", "tags":
+	default:
+		return nil, fmt.Errorf("invalid default_view.sort %q (expected date, modified, format, title, or tags)", config.DefaultView.Sort)
+	}
 
 	return &config, nil
 }
 
+// decodeHook keeps viper's own decode hooks and also reads YAML dates into string
+// fields as YYYY-MM-DD, so a series' start may be written unquoted
+var decodeHook = mapstructure.ComposeDecodeHookFunc(
+	mapstructure.StringToTimeDurationHookFunc(),
+	mapstructure.StringToSliceHookFunc(","),
+	func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if date, ok := data.(time.Time); ok && to.Kind() == reflect.String {
+			return date.Format("2006-01-02"), nil
+		}
+		return data, nil
+	},
+)
+
 // promptForNotesDirectory prompts the user to select notes directories
 func promptForNotesDirectory(configPath string, defaultConfig *Config) (*Config, error) {
 	fmt.Println("Welcome to Burh! This appears to be your first time running the program.")
@@ -113,11 +470,7 @@ func promptForNotesDirectory(configPath string, defaultConfig *Config) (*Config,
 		return nil, err
 	}
 
-	// Ensure the directory exists
-	if err := os.MkdirAll(firstDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create notes directory: %w", err)
-	}
-
+	// The directory is created when the first note is saved
 	selectedDirs = append(selectedDirs, firstDir)
 	fmt.Printf("Primary notes directory set to: %s\n", firstDir)
 
@@ -252,6 +605,9 @@ func openFileExplorer(defaultPath string) string {
 
 // SaveConfig saves the current configuration to file
 func SaveConfig(config *Config) error {
+	if workspace != nil {
+		return fmt.Errorf("the config can't be changed while using the workspace at %s (--local)", workspace.Root)
+	}
 	configPath := getConfigPath()
 
 	// Save the expanded path (without tilde) to avoid confusion
@@ -263,14 +619,98 @@ func SaveConfig(config *Config) error {
 	viper.Set("theme.error", config.Theme.Error)
 	viper.Set("theme.info", config.Theme.Info)
 	viper.Set("theme.muted", config.Theme.Muted)
+	viper.Set("metadata_fields", config.MetadataFields)
+	viper.Set("dir_timeout", config.DirTimeout)
+	viper.Set("dir_timeouts", config.DirTimeouts)
+	viper.Set("recursive", config.Recursive)
+	viper.Set("read_only_dirs", config.ReadOnlyDirs)
+	viper.Set("sort_column", config.SortColumn)
+	viper.Set("sort_descending", config.SortDescending)
+	viper.Set("page_size", config.PageSize)
+	viper.Set("list_density", config.ListDensity)
+	viper.Set("title_overflow", config.TitleOverflow)
+	viper.Set("locale", config.Locale)
+	viper.Set("display.icons", config.Display.Icons)
+	viper.Set("display.tag_icons", config.Display.TagIcons)
+	viper.Set("display.ascii", config.Display.Ascii)
+	viper.Set("display.images", config.Display.Images)
+	viper.Set("modified_column", config.ModifiedColumn)
+	viper.Set("binary_notes", config.BinaryNotes)
+	viper.Set("extractors", config.Extractors)
+	viper.Set("ocr_command", config.OCRCommand)
+	viper.Set("stale_after", config.StaleAfter)
+	viper.Set("stale_exclude_tags", config.StaleExclude)
+	viper.Set("paste_endpoint", config.PasteEndpoint)
+	viper.Set("link_titles", config.LinkTitles)
+	viper.Set("record_types", config.RecordTypes)
+	viper.Set("bookmarks_note", config.BookmarksNote)
+	viper.Set("bookmarks_format", config.BookmarksFormat)
+	viper.Set("search_locale", config.SearchLocale)
+	viper.Set("search_stemming", config.SearchStemming)
+	viper.Set("search_fuzzy", config.SearchFuzzy)
+	viper.Set("retention", config.Retention)
+	viper.Set("inbox_tag", config.InboxTag)
+	viper.Set("kanban_by", config.KanbanBy)
+	viper.Set("kanban_columns", config.KanbanColumns)
+	viper.Set("habits", config.Habits)
+	viper.Set("habits_format", config.HabitsFormat)
+	viper.Set("books_format", config.BooksFormat)
+	viper.Set("pantry", config.Pantry)
+	viper.Set("focus_width", config.FocusWidth)
+	viper.Set("focus_autosave", config.FocusAutosave)
+	viper.Set("undo_limit", config.UndoLimit)
+	viper.Set("safety.bulk_delete", config.Safety.BulkDelete)
+	viper.Set("safety.pinned_tag", config.Safety.PinnedTag)
+	viper.Set("confirmations.delete", config.Confirmations.Delete)
+	viper.Set("confirmations.quit_with_draft", config.Confirmations.QuitWithDraft)
+	viper.Set("confirmations.bulk", config.Confirmations.Bulk)
+	viper.Set("confirmations.import_overwrite", config.Confirmations.ImportOverwrite)
+	viper.Set("keys", config.Keys)
+	viper.Set("accessibility.high_contrast", config.Accessibility.HighContrast)
+	viper.Set("accessibility.markers", config.Accessibility.Markers)
+	viper.Set("accessibility.reduced_motion", config.Accessibility.ReducedMotion)
+	viper.Set("audit_log", config.AuditLog)
+	viper.Set("usage_metrics", config.UsageMetrics)
+	viper.Set("update_check", config.UpdateCheck)
+	viper.Set("sync_mode", config.SyncMode)
+	viper.Set("hyperlinks", config.Hyperlinks)
+	viper.Set("default_view.exclude_tags", config.DefaultView.ExcludeTags)
+	viper.Set("default_view.exclude_dirs", config.DefaultView.ExcludeDirs)
+	viper.Set("default_view.primary_only", config.DefaultView.PrimaryOnly)
+	viper.Set("default_view.sort", config.DefaultView.Sort)
+	viper.Set("default_view.descending", config.DefaultView.Descending)
+	viper.Set("series", config.Series)
 
 	return viper.WriteConfigAs(configPath)
 }
 
-// getConfigPath returns the path to the configuration file
+// Path returns the path to the configuration file
+func Path() string {
+	return getConfigPath()
+}
+
+// getConfigPath returns the path to the configuration file: ~/.burhrc.yaml, or on
+// Windows %APPDATA%\burh\.burhrc.yaml unless a ~/.burhrc.yaml from an earlier
+// version is there. State files such as the session are kept next to it.
 func getConfigPath() string {
 	homeDir, _ := os.UserHomeDir()
-	return filepath.Join(homeDir, ".burhrc.yaml")
+	return configPathFor(runtime.GOOS, homeDir, os.Getenv("APPDATA"))
+}
+
+// configPathFor returns the config file path on an OS, given the home directory
+// and the value of APPDATA
+func configPathFor(goos, homeDir, appData string) string {
+	path := filepath.Join(homeDir, ".burhrc.yaml")
+	if goos != "windows" {
+		return path
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	if appData == "" {
+		return path
+	}
+	return filepath.Join(appData, "burh", ".burhrc.yaml")
 }
 
 // createDefaultConfig creates a default configuration file
@@ -289,29 +729,119 @@ func createDefaultConfig(configPath string, config *Config) (*Config, error) {
 	return config, nil
 }
 
-// ValidateAndReloadConfig validates the current configuration and reloads it
+// DirTimeoutMap returns the configured directory read timeouts keyed by cleaned path.
+// The "" key holds the default timeout.
+func (c *Config) DirTimeoutMap() (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+
+	if c.DirTimeout != "" {
+		d, err := time.ParseDuration(c.DirTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dir_timeout %q: %w", c.DirTimeout, err)
+		}
+		timeouts[""] = d
+	}
+
+	for _, dt := range c.DirTimeouts {
+		d, err := time.ParseDuration(dt.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q for %s: %w", dt.Timeout, dt.Path, err)
+		}
+		timeouts[filepath.Clean(expandTilde(dt.Path))] = d
+	}
+
+	return timeouts, nil
+}
+
+// ReadOnlyDirPaths returns the read-only notes directories with ~ expanded
+func (c *Config) ReadOnlyDirPaths() []string {
+	var dirs []string
+	for _, dir := range c.ReadOnlyDirs {
+		dirs = append(dirs, expandTilde(dir))
+	}
+	return dirs
+}
+
+// ViewExclusions returns the tags and directories the default view leaves out,
+// with every notes directory but the first among the directories for primary_only
+func (c *Config) ViewExclusions() (tags, dirs []string) {
+	for _, dir := range c.DefaultView.ExcludeDirs {
+		dirs = append(dirs, expandTilde(dir))
+	}
+	if c.DefaultView.PrimaryOnly && len(c.NotesDirs) > 1 {
+		dirs = append(dirs, c.NotesDirs[1:]...)
+	}
+	return c.DefaultView.ExcludeTags, dirs
+}
+
+// IsReadOnlyDir checks if a configured notes directory is read-only
+func (c *Config) IsReadOnlyDir(dir string) bool {
+	for _, ro := range c.ReadOnlyDirPaths() {
+		if paths.Same(ro, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// FocusAutosaveInterval returns how often focus mode saves, or 0 to save only on exit
+func (c *Config) FocusAutosaveInterval() (time.Duration, error) {
+	if c.FocusAutosave == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.FocusAutosave)
+	if err != nil {
+		return 0, fmt.Errorf("invalid focus_autosave %q: %w", c.FocusAutosave, err)
+	}
+	return d, nil
+}
+
+// ExtractorMap returns the configured extractor commands keyed by lowercase extension
+func (c *Config) ExtractorMap() map[string]string {
+	extractors := map[string]string{}
+	for _, e := range c.Extractors {
+		ext := strings.ToLower(e.Extension)
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		extractors[ext] = e.Command
+	}
+	return extractors
+}
+
+// ValidateAndReloadConfig validates the current configuration and reloads it. It
+// only reads: notes directories that don't exist yet are created when the first
+// note is saved, and the config file is written only when a directory's path had
+// to be made absolute.
 func ValidateAndReloadConfig() (*Config, error) {
 	config, err := LoadConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	// Validate that all directories exist or can be created
+	changed := false
 	for i, dir := range config.NotesDirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return nil, fmt.Errorf("failed to create or access directory %s: %w", dir, err)
+		info, err := os.Stat(dir)
+		switch {
+		case err == nil && !info.IsDir():
+			return nil, fmt.Errorf("notes directory %s is not a directory", dir)
+		case err != nil && !errors.Is(err, os.ErrNotExist):
+			return nil, fmt.Errorf("failed to access directory %s: %w", dir, err)
 		}
-		// Update with absolute path
 		absPath, err := filepath.Abs(dir)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get absolute path for %s: %w", dir, err)
 		}
-		config.NotesDirs[i] = absPath
+		if absPath != dir {
+			config.NotesDirs[i] = absPath
+			changed = true
+		}
 	}
 
-	// Save the validated config back to file
-	if err := SaveConfig(config); err != nil {
-		return nil, fmt.Errorf("failed to save validated config: %w", err)
+	if changed && workspace == nil {
+		if err := SaveConfig(config); err != nil {
+			return nil, fmt.Errorf("failed to save validated config: %w", err)
+		}
 	}
 
 	return config, nil
@@ -329,7 +859,7 @@ func AddNotesDirectory(newDir string) error {
 
 	// Check if directory already exists in the list
 	for _, dir := range config.NotesDirs {
-		if dir == newDir {
+		if paths.Same(dir, newDir) {
 			return fmt.Errorf("directory %s is already in the configuration", newDir)
 		}
 	}
@@ -360,7 +890,7 @@ func RemoveNotesDirectory(dirToRemove string) error {
 	found := false
 	var newDirs []string
 	for _, dir := range config.NotesDirs {
-		if dir == dirToRemove {
+		if paths.Same(dir, dirToRemove) {
 			found = true
 			continue
 		}
@@ -381,3 +911,48 @@ func RemoveNotesDirectory(dirToRemove string) error {
 	// Save updated configuration
 	return SaveConfig(config)
 }
+
+// IsBulkDelete checks if deleting count notes at once asks for DELETE to be typed
+func (s Safety) IsBulkDelete(count int) bool {
+	return s.BulkDelete > 0 && count > s.BulkDelete
+}
+
+// IsPinned checks if a note with the tags is pinned, so deleting it asks for its
+// title to be typed
+func (s Safety) IsPinned(tags []string) bool {
+	if s.PinnedTag == "" {
+		return false
+	}
+	for _, tag := range tags {
+		if strings.EqualFold(tag, s.PinnedTag) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatLabel returns a note format with its icon before it, e.g. "📝 md", or
+// the format alone when icons are off
+func (d Display) FormatLabel(format string) string {
+	icons, ok := formatIcons[d.Icons]
+	if !ok {
+		return format
+	}
+	icon, ok := icons[format]
+	if !ok {
+		icon = icons[""]
+	}
+	return icon + " " + format
+}
+
+// TagLabel returns a tag with its configured icon before it, or the tag alone
+// when it has none or icons are off
+func (d Display) TagLabel(tag string) string {
+	if _, ok := formatIcons[d.Icons]; !ok {
+		return tag
+	}
+	if icon := d.TagIcons[strings.ToLower(tag)]; icon != "" {
+		return icon + " " + tag
+	}
+	return tag
+}
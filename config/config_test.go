@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigPathFor(t *testing.T) {
+	home := t.TempDir()
+	legacyHome := t.TempDir()
+	if err := os.WriteFile(filepath.Join(legacyHome, ".burhrc.yaml"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	appData := filepath.Join(t.TempDir(), "AppData", "Roaming")
+
+	tests := []struct {
+		name    string
+		goos    string
+		home    string
+		appData string
+		want    string
+	}{
+		{"linux uses the home directory", "linux", home, appData, filepath.Join(home, ".burhrc.yaml")},
+		{"darwin uses the home directory", "darwin", home, appData, filepath.Join(home, ".burhrc.yaml")},
+		{"windows uses APPDATA", "windows", home, appData, filepath.Join(appData, "burh", ".burhrc.yaml")},
+		{"windows keeps a config from an earlier version", "windows", legacyHome, appData, filepath.Join(legacyHome, ".burhrc.yaml")},
+		{"windows without APPDATA uses the home directory", "windows", home, "", filepath.Join(home, ".burhrc.yaml")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := configPathFor(tt.goos, tt.home, tt.appData); got != tt.want {
+				t.Errorf("configPathFor(%q, %q, %q) = %q, want %q", tt.goos, tt.home, tt.appData, got, tt.want)
+			}
+		})
+	}
+}
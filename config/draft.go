@@ -0,0 +1,64 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Draft is the unsaved input of the TUI's create or edit form, kept so it survives
+// a crash or an accidental esc
+type Draft struct {
+	Form    string    `json:"form"`              // "create" or "edit"
+	NoteID  string    `json:"note_id,omitempty"` // Note being edited
+	Title   string    `json:"title,omitempty"`
+	Tags    string    `json:"tags,omitempty"`
+	Format  string    `json:"format,omitempty"`
+	Content string    `json:"content,omitempty"`
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// LoadDraft reads the saved form draft, or returns nil if there is none
+func LoadDraft() (*Draft, error) {
+	data, err := os.ReadFile(DraftPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read draft: %w", err)
+	}
+
+	var draft Draft
+	if err := json.Unmarshal(data, &draft); err != nil {
+		return nil, fmt.Errorf("failed to parse draft: %w", err)
+	}
+	return &draft, nil
+}
+
+// SaveDraft writes the form draft next to the config file
+func SaveDraft(draft *Draft) error {
+	data, err := json.MarshalIndent(draft, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(DraftPath(), data, 0600); err != nil {
+		return fmt.Errorf("failed to save draft: %w", err)
+	}
+	return nil
+}
+
+// ClearDraft removes the saved form draft, if any
+func ClearDraft() error {
+	if err := os.Remove(DraftPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove draft: %w", err)
+	}
+	return nil
+}
+
+// DraftPath returns the path to the form draft file, next to the config file
+func DraftPath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), ".burh_draft.json")
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyAction is an action of the TUI note list that keys: can move to another key
+type KeyAction struct {
+	Name string // Name under keys:
+	Key  string // Default key
+	Help string // What the action does, as the TUI help lists it
+}
+
+// KeyActions are the note list's actions in the order the TUI help lists them
+var KeyActions = []KeyAction{
+	{"new", "n", "new note"},
+	{"search", "s", "search"},
+	{"open", "enter", "edit"},
+	{"delete", "d", "delete"},
+	{"undo", "u", "undo"},
+	{"redo", "U", "redo"},
+	{"refresh", "r", "refresh"},
+	{"stale", "S", "stale notes"},
+	{"format", "F", "filter by format"},
+	{"all", "A", "all notes"},
+	{"jump", ":", "open row N"},
+	{"clone", "c", "clone"},
+	{"lock", "L", "lock"},
+	{"copy", "y", "copy content"},
+	{"copy_path", "Y", "copy path"},
+	{"copy_id", "ctrl+y", "copy ID"},
+	{"paste_image", "P", "paste image"},
+	{"rename", "R", "rename"},
+	{"tags", "#", "edit tags"},
+	{"group", "v", "group"},
+	{"sort", ",", "sort (then 1-4)"},
+	{"tree", "t", "tree"},
+	{"outline", "o", "outline"},
+	{"refile", "ctrl+r", "refile"},
+	{"board", "b", "board"},
+	{"random", "%", "random note"},
+	{"focus", "z", "focus"},
+	{"split", "w", "split view"},
+	{"next_chip", "tab", "next filter"},
+	{"remove_chip", "x", "remove filter"},
+	{"clear_filters", "X", "clear filters"},
+	{"top", "K", "top"},
+	{"bottom", "J", "bottom"},
+	{"help", "?", "help"},
+	{"quit", "q", "quit"},
+}
+
+// DefaultKey returns the default key of a note list action, "" if there is no
+// such action
+func DefaultKey(action string) string {
+	for _, a := range KeyActions {
+		if a.Name == action {
+			return a.Key
+		}
+	}
+	return ""
+}
+
+// validateKeys checks that keys: names known actions and gives no two of them
+// the same key
+func validateKeys(keys map[string]string) error {
+	var names []string
+	for _, a := range KeyActions {
+		names = append(names, a.Name)
+	}
+	actionOf := map[string]string{}
+	for action, key := range keys {
+		if DefaultKey(action) == "" {
+			return fmt.Errorf("invalid keys action %q (expected %s)", action, strings.Join(names, ", "))
+		}
+		if key == "" {
+			return fmt.Errorf("keys.%s has no key", action)
+		}
+		if other, taken := actionOf[key]; taken {
+			return fmt.Errorf("keys.%s and keys.%s are both %q", other, action, key)
+		}
+		actionOf[key] = action
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExpiresField is the built-in metadata field holding the date a note expires.
+// 'burh gc' archives notes once the date has passed.
+var ExpiresField = MetadataField{Name: "expires", Type: "date"}
+
+// StatusField is the built-in metadata field holding a note's status, such as
+// todo or done, which the TUI's kanban board arranges notes by
+var StatusField = MetadataField{Name: "status", Type: "string"}
+
+// LanguageField is the built-in metadata field holding the programming language
+// of a snippet note, used to filter and highlight snippets
+var LanguageField = MetadataField{Name: "language", Type: "string"}
+
+// builtinFields are the metadata fields every config has
+var builtinFields = []MetadataField{ExpiresField, StatusField, LanguageField}
+
+// reservedFieldNames are header keys and query filters burh already uses, so a
+// metadata field with one of these names would clash with them
+var reservedFieldNames = []string{
+	"title", "created", "date", "modified", "tags", "filetags", "locked",
+	"tag", "format", "id", "before", "after", "stale",
+}
+
+// checkMetadataFields checks that every declared metadata field has a usable name
+// and a known type
+func (c *Config) checkMetadataFields() error {
+	seen := map[string]bool{}
+	for _, field := range c.MetadataFields {
+		name := strings.ToLower(field.Name)
+		if name == "" {
+			return fmt.Errorf("invalid metadata_fields: a field has no name")
+		}
+		if strings.ContainsAny(name, ": \t") {
+			return fmt.Errorf("invalid metadata_fields: field name %q cannot contain spaces or colons", field.Name)
+		}
+		for _, reserved := range reservedFieldNames {
+			if name == reserved {
+				return fmt.Errorf("invalid metadata_fields: %q is already used by burh", field.Name)
+			}
+		}
+		if seen[name] {
+			return fmt.Errorf("invalid metadata_fields: %q is declared more than once", field.Name)
+		}
+		seen[name] = true
+		switch strings.ToLower(field.Type) {
+		case "", "string", "number", "bool", "date", "url":
+		default:
+			return fmt.Errorf("invalid metadata_fields: field %s has unknown type %q (expected string, number, bool, date, or url)", field.Name, field.Type)
+		}
+	}
+	return nil
+}
+
+// Fields returns the declared metadata fields followed by the built-in ones that
+// are not declared
+func (c *Config) Fields() []MetadataField {
+	fields := append([]MetadataField(nil), c.MetadataFields...)
+	for _, builtin := range builtinFields {
+		declared := false
+		for _, field := range c.MetadataFields {
+			if strings.EqualFold(field.Name, builtin.Name) {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			fields = append(fields, builtin)
+		}
+	}
+	return fields
+}
+
+// MetadataKeys returns the lowercased names of all metadata fields
+func (c *Config) MetadataKeys() []string {
+	var keys []string
+	for _, field := range c.Fields() {
+		keys = append(keys, strings.ToLower(field.Name))
+	}
+	return keys
+}
+
+// LookupMetadataField finds a metadata field by name (case-insensitive)
+func (c *Config) LookupMetadataField(name string) (MetadataField, bool) {
+	for _, field := range c.Fields() {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return MetadataField{}, false
+}
+
+// Validate checks that a value matches the field's declared type
+func (f MetadataField) Validate(value string) error {
+	switch strings.ToLower(f.Type) {
+	case "", "string":
+		return nil
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("field %s expects a number, got %q", f.Name, value)
+		}
+	case "bool":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("field %s expects true or false, got %q", f.Name, value)
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Errorf("field %s expects a date (YYYY-MM-DD), got %q", f.Name, value)
+		}
+	case "url":
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("field %s expects a URL, got %q", f.Name, value)
+		}
+	default:
+		return fmt.Errorf("field %s has unknown type %q in config", f.Name, f.Type)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Project is the tag a git repository's notes get from 'burh project'
+type Project struct {
+	Tag      string `json:"tag"`
+	Branches bool   `json:"branches"` // Also tag notes with the branch checked out
+}
+
+// LoadProjects reads the projects, keyed by repository root. A missing file
+// yields none.
+func LoadProjects() (map[string]Project, error) {
+	data, err := os.ReadFile(ProjectsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]Project{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projects: %w", err)
+	}
+	projects := map[string]Project{}
+	if err := json.Unmarshal(data, &projects); err != nil {
+		return nil, fmt.Errorf("failed to parse projects: %w", err)
+	}
+	return projects, nil
+}
+
+// SaveProject associates the repository at root with a project, replacing any
+// project it had
+func SaveProject(root string, project Project) error {
+	projects, err := LoadProjects()
+	if err != nil {
+		return err
+	}
+	projects[root] = project
+	data, err := json.MarshalIndent(projects, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(ProjectsPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to save projects: %w", err)
+	}
+	return nil
+}
+
+// ProjectsPath returns the path to the file associating git repositories with
+// projects, next to the config file
+func ProjectsPath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), ".burh_projects.json")
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LookupRecordType finds a declared record type by name (case-insensitive)
+func (c *Config) LookupRecordType(name string) (RecordType, bool) {
+	for _, t := range c.RecordTypes {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return RecordType{}, false
+}
+
+// NoteTag returns the tag that marks notes holding records of the type
+func (t RecordType) NoteTag() string {
+	if t.Tag != "" {
+		return t.Tag
+	}
+	return t.Name
+}
+
+// Field finds a declared field of the type by name (case-insensitive)
+func (t RecordType) Field(name string) (MetadataField, bool) {
+	for _, field := range t.Fields {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return MetadataField{}, false
+}
+
+// Validate checks a record's fields, keyed by lowercase name, against the type:
+// required fields must be present and every declared field must match its type
+func (t RecordType) Validate(fields map[string]string) []error {
+	var errs []error
+	for _, field := range t.Fields {
+		value, ok := fields[strings.ToLower(field.Name)]
+		if !ok || value == "" {
+			if field.Required {
+				errs = append(errs, fmt.Errorf("missing required field %s", field.Name))
+			}
+			continue
+		}
+		if err := field.Validate(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
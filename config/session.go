@@ -0,0 +1,76 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Session is the TUI state saved on exit and restored on the next start
+type Session struct {
+	SelectedID string `json:"selected_id,omitempty"` // ID of the selected note
+	StartIndex int    `json:"start_index,omitempty"` // First visible row of the list
+	SearchType string `json:"search_type,omitempty"` // "keyword", "tag", or "date"; empty when unfiltered
+	Query      string `json:"query,omitempty"`       // Search that produced the list
+	GroupBy    string `json:"group_by,omitempty"`
+}
+
+// LoadSession reads the saved TUI session. A missing file yields an empty session.
+func LoadSession() (*Session, error) {
+	data, err := os.ReadFile(SessionPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return &Session{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+	return &session, nil
+}
+
+// SaveSession writes the TUI session next to the config file
+func SaveSession(session *Session) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(SessionPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// SessionPath returns the path to the session state file, next to the config file
+func SessionPath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), ".burh_session.json")
+}
+
+// StudyStatePath returns the path to the file holding flashcard schedules, next
+// to the config file
+func StudyStatePath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), ".burh_study.json")
+}
+
+// JournalPath returns the path to the journal of note operations 'burh undo'
+// reverts, next to the config file
+func JournalPath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), ".burh_journal.json")
+}
+
+// AuditLogPath returns the path to the log of every change made to a note, next
+// to the config file
+func AuditLogPath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), ".burh_audit.log")
+}
+
+// SocketPath returns the default path of the socket 'burh serve' listens on, next
+// to the config file
+func SocketPath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), ".burh.sock")
+}
@@ -0,0 +1,64 @@
+package config
+
+import "sort"
+
+// Themes are the built-in color presets, shown by 'burh themes'. The default
+// theme is nord; accessibility.high_contrast uses high-contrast.
+var Themes = map[string]Theme{
+	"nord": DefaultConfig().Theme,
+	"dracula": {
+		Primary:   "#BD93F9", // Purple
+		Secondary: "#44475A", // Current line
+		Success:   "#50FA7B", // Green
+		Warning:   "#F1FA8C", // Yellow
+		Error:     "#FF5555", // Red
+		Info:      "#8BE9FD", // Cyan
+		Muted:     "#6272A4", // Comment
+	},
+	"gruvbox": {
+		Primary:   "#FABD2F", // Yellow
+		Secondary: "#504945", // Dark gray
+		Success:   "#B8BB26", // Green
+		Warning:   "#FE8019", // Orange
+		Error:     "#FB4934", // Red
+		Info:      "#83A598", // Blue
+		Muted:     "#928374", // Gray
+	},
+	"high-contrast": {
+		Primary:   "#00FFFF", // Cyan
+		Secondary: "#FFFFFF", // White
+		Success:   "#00FF00", // Green
+		Warning:   "#FFFF00", // Yellow
+		Error:     "#FF5F5F", // Light red
+		Info:      "#FFFFFF", // White
+		Muted:     "#D0D0D0", // Light gray, still readable
+	},
+	"solarized": {
+		Primary:   "#268BD2", // Blue
+		Secondary: "#073642", // Base02
+		Success:   "#859900", // Green
+		Warning:   "#B58900", // Yellow
+		Error:     "#DC322F", // Red
+		Info:      "#2AA198", // Cyan
+		Muted:     "#657B83", // Base00
+	},
+	"catppuccin": {
+		Primary:   "#CBA6F7", // Mauve
+		Secondary: "#45475A", // Surface 1
+		Success:   "#A6E3A1", // Green
+		Warning:   "#F9E2AF", // Yellow
+		Error:     "#F38BA8", // Red
+		Info:      "#89B4FA", // Blue
+		Muted:     "#7F849C", // Overlay 1
+	},
+}
+
+// ThemeNames returns the names of the built-in themes in alphabetical order
+func ThemeNames() []string {
+	var names []string
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Usage counts how burh is used on this machine, kept only when usage_metrics is
+// on and never sent anywhere
+type Usage struct {
+	Since    time.Time                `json:"since"`
+	Commands map[string]*CommandUsage `json:"commands"`
+	Keys     map[string]int           `json:"keys,omitempty"` // Keys pressed in the TUI list, by key
+}
+
+// CommandUsage counts the completed runs of a command and the time they took
+type CommandUsage struct {
+	Runs  int           `json:"runs"`
+	Total time.Duration `json:"total"`
+	Last  time.Time     `json:"last"`
+}
+
+// LoadUsage reads the usage metrics. A missing file yields empty metrics.
+func LoadUsage() (*Usage, error) {
+	usage := &Usage{Commands: map[string]*CommandUsage{}, Keys: map[string]int{}}
+	data, err := os.ReadFile(UsagePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return usage, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage metrics: %w", err)
+	}
+	if err := json.Unmarshal(data, usage); err != nil {
+		return nil, fmt.Errorf("failed to parse usage metrics: %w", err)
+	}
+	if usage.Commands == nil {
+		usage.Commands = map[string]*CommandUsage{}
+	}
+	if usage.Keys == nil {
+		usage.Keys = map[string]int{}
+	}
+	return usage, nil
+}
+
+// saveUsage writes the usage metrics, starting the count now if it is new
+func saveUsage(usage *Usage) error {
+	if usage.Since.IsZero() {
+		usage.Since = time.Now()
+	}
+	data, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(UsagePath(), data, 0600); err != nil {
+		return fmt.Errorf("failed to save usage metrics: %w", err)
+	}
+	return nil
+}
+
+// RecordCommand adds a completed run of a command, which took the duration, to
+// the usage metrics
+func RecordCommand(name string, took time.Duration) error {
+	usage, err := LoadUsage()
+	if err != nil {
+		return err
+	}
+	counts := usage.Commands[name]
+	if counts == nil {
+		counts = &CommandUsage{}
+		usage.Commands[name] = counts
+	}
+	counts.Runs++
+	counts.Total += took
+	counts.Last = time.Now()
+	return saveUsage(usage)
+}
+
+// RecordKeys adds TUI key presses, counted by key, to the usage metrics
+func RecordKeys(keys map[string]int) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	usage, err := LoadUsage()
+	if err != nil {
+		return err
+	}
+	for key, n := range keys {
+		usage.Keys[key] += n
+	}
+	return saveUsage(usage)
+}
+
+// ClearUsage deletes the usage metrics
+func ClearUsage() error {
+	if err := os.Remove(UsagePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to clear usage metrics: %w", err)
+	}
+	return nil
+}
+
+// UsagePath returns the path to the usage metrics file, next to the config file
+func UsagePath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), ".burh_usage.json")
+}
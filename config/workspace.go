@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+// WorkspaceMarker is the directory marking the root of a workspace with its own
+// notes, e.g. in a project repository. Its notes are kept in a notes directory
+// inside it, and a config.yaml in it overrides settings of the global config.
+const WorkspaceMarker = ".burh"
+
+// workspaceNotesDir is the directory a workspace's notes are kept in: inside the
+// marker, or at the workspace root for a workspace without one
+const workspaceNotesDir = "notes"
+
+// Workspace is a notes space found from the working directory, used instead of
+// the global config's notes directories
+type Workspace struct {
+	Root     string // Directory holding the marker or the notes directory
+	NotesDir string
+	Marked   bool // Whether it was found by its marker rather than a notes directory
+}
+
+// workspace is the workspace in use, if any (see UseWorkspace)
+var workspace *Workspace
+
+// FindWorkspace walks up from dir to the first directory holding a .burh marker
+// directory or a notes directory, and returns the workspace there
+func FindWorkspace(dir string) (*Workspace, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if isDir(filepath.Join(dir, WorkspaceMarker)) {
+			return &Workspace{Root: dir, NotesDir: filepath.Join(dir, WorkspaceMarker, workspaceNotesDir), Marked: true}, nil
+		}
+		if isDir(filepath.Join(dir, workspaceNotesDir)) {
+			return &Workspace{Root: dir, NotesDir: filepath.Join(dir, workspaceNotesDir)}, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("no %s or %s directory here or in any parent directory", WorkspaceMarker, workspaceNotesDir)
+		}
+		dir = parent
+	}
+}
+
+// UseWorkspace makes LoadConfig use a workspace's notes directory instead of the
+// configured ones, with the settings in its config.yaml, if any, on top of the
+// global config. The global config file isn't needed, and isn't changed by SaveConfig.
+func UseWorkspace(ws *Workspace) error {
+	if err := os.MkdirAll(ws.NotesDir, 0755); err != nil {
+		return fmt.Errorf("failed to create workspace notes directory: %w", err)
+	}
+	workspace = ws
+	return nil
+}
+
+// CurrentWorkspace returns the workspace in use, or nil
+func CurrentWorkspace() *Workspace {
+	return workspace
+}
+
+// ConfigPath returns the path of the workspace's own config file
+func (ws *Workspace) ConfigPath() string {
+	return filepath.Join(ws.Root, WorkspaceMarker, "config.yaml")
+}
+
+// mergeWorkspaceConfig reads the workspace's config file, if it has one, over
+// the global config
+func mergeWorkspaceConfig(configPath string) error {
+	if !workspace.Marked {
+		return nil
+	}
+	if _, err := os.Stat(workspace.ConfigPath()); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	viper.SetConfigFile(workspace.ConfigPath())
+	defer viper.SetConfigFile(configPath)
+	if err := viper.MergeInConfig(); err != nil {
+		return fmt.Errorf("failed to read workspace config: %w", err)
+	}
+	return nil
+}
+
+// isDir checks if path is a directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,101 @@
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Command returns a command that opens path in the user's preferred editor.
+// $VISUAL and $EDITOR are tried first, then the OS default opener.
+func Command(path string) (*exec.Cmd, error) {
+	return CommandAt(path, 0)
+}
+
+// Configured returns the editor set in $VISUAL or $EDITOR, without its arguments,
+// or "" if neither is set
+func Configured() string {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		return ""
+	}
+	program, _ := splitEditor(editor)
+	return program
+}
+
+// CommandAt is Command with the cursor placed on a 1-based line, for editors whose
+// command line supports it; others, and line 0, open the file at the top
+func CommandAt(path string, line int) (*exec.Cmd, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	if editor != "" {
+		program, args := splitEditor(editor)
+		return exec.Command(program, append(args, lineArgs(program, path, line)...)...), nil
+	}
+
+	// Windows has no default editor for .md or .org files, and the default opener
+	// returns before editing is done, so Notepad stands in
+	if runtime.GOOS == "windows" {
+		return exec.Command("notepad", path), nil
+	}
+
+	// Fallback to OS default opener
+	return OpenCommand(path)
+}
+
+// splitEditor splits an editor setting such as "code --wait" into the program and
+// its arguments. A setting naming an existing program, such as
+// "C:\Program Files\Notepad++\notepad++.exe", is taken whole even with spaces.
+func splitEditor(editor string) (string, []string) {
+	if _, err := exec.LookPath(editor); err == nil {
+		return editor, nil
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		return editor, nil
+	}
+	return fields[0], fields[1:]
+}
+
+// lineArgs returns the arguments that open path at line in the editor
+func lineArgs(editor, path string, line int) []string {
+	if line <= 0 {
+		return []string{path}
+	}
+	n := strconv.Itoa(line)
+
+	name := strings.TrimSuffix(strings.ToLower(filepath.Base(editor)), ".exe")
+	switch name {
+	case "vi", "vim", "nvim", "gvim", "mvim", "nano", "pico", "emacs", "emacsclient", "micro", "kak", "joe", "ne", "mg":
+		return []string{"+" + n, path}
+	case "hx", "helix", "subl", "sublime_text", "zed":
+		return []string{path + ":" + n}
+	case "code", "code-insiders", "codium":
+		return []string{"--goto", path + ":" + n}
+	}
+	return []string{path}
+}
+
+// OpenCommand returns a command that opens path with the OS default application
+func OpenCommand(path string) (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", path), nil
+	case "linux":
+		return exec.Command("xdg-open", path), nil
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", path), nil
+	default:
+		return nil, fmt.Errorf("no editor configured: set $EDITOR")
+	}
+}
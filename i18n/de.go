@@ -0,0 +1,80 @@
+package i18n
+
+// de is the German catalog
+var de = map[string]string{
+	// TUI list
+	"BURH - NOTE MANAGER": "BURH - NOTIZVERWALTUNG",
+	"%s: new | %s: search | %s: edit | %s: delete | %s: all keys | %s: quit": "%s: neu | %s: suchen | %s: bearbeiten | %s: löschen | %s: alle Tasten | %s: beenden",
+	"No notes found. Press 'n' to create a new note.":                        "Keine Notizen gefunden. Drücke 'n', um eine neue Notiz anzulegen.",
+	"Date":                      "Datum",
+	"Edited":                    "Geändert",
+	"Format":                    "Format",
+	"Title":                     "Titel",
+	"Tags":                      "Tags",
+	"Showing %d-%d of %d notes": "Notizen %d-%d von %d",
+	"Previous page (k/up)":      "Vorherige Seite (k/hoch)",
+	"Next page (j/down)":        "Nächste Seite (j/runter)",
+	"enter: save | esc: cancel": "enter: speichern | esc: abbrechen",
+
+	// Status bar
+	"%d notes":       "%d Notizen",
+	"%d of %d notes": "%d von %d Notizen",
+	"filter:":        "Filter:",
+	"sort:":          "Sortierung:",
+	"group:":         "Gruppe:",
+	"dir:":           "Verzeichnis:",
+	"(read-only)":    "(schreibgeschützt)",
+	"loading...":     "lädt...",
+	"refreshed":      "aktualisiert",
+
+	"Warning: %v":       "Warnung: %v",
+	"Search failed: %v": "Suche fehlgeschlagen: %v",
+	"'%s' is no longer in the list; nothing deleted":       "'%s' ist nicht mehr in der Liste; nichts gelöscht",
+	"sort by: 1 date | 2 format | 3 title | 4 tags":        "sortieren nach: 1 Datum | 2 Format | 3 Titel | 4 Tags",
+	"other keys: cancel":                                   "andere Tasten: abbrechen",
+	"Text could not be extracted from %d files: %v":        "Aus %d Dateien konnte kein Text gelesen werden: %v",
+	"some notes hidden (A: all)":                           "einige Notizen ausgeblendet (A: alle)",
+	"all notes (A: default view)":                          "alle Notizen (A: Standardansicht)",
+	"All notes shown":                                      "Alle Notizen angezeigt",
+	"Default view restored":                                "Standardansicht wiederhergestellt",
+	"enter: open row | esc: cancel":                        "enter: Zeile öffnen | esc: abbrechen",
+	"No row %d on this page":                               "Keine Zeile %d auf dieser Seite",
+	"No notes are hidden":                                  "Keine Notizen ausgeblendet",
+	"Config reloaded":                                      "Konfiguration neu geladen",
+	"Config reloaded; notes directories changed":           "Konfiguration neu geladen; Notizverzeichnisse geändert",
+	"Config not reloaded: %v":                              "Konfiguration nicht neu geladen: %v",
+	"Config file is missing; keeping the current settings": "Konfigurationsdatei fehlt; die aktuellen Einstellungen bleiben",
+	"Config changes won't be reloaded: %v":                 "Konfigurationsänderungen werden nicht neu geladen: %v",
+
+	// Search form
+	"SEARCH NOTES": "NOTIZEN DURCHSUCHEN",
+	"Search Type:": "Suchart:",
+	"Keyword:":     "Stichwort:",
+	"Tag:":         "Tag:",
+	"Date:":        "Datum:",
+	"Tab: Next field | Shift+Tab: Previous field | Space: Toggle search type | Enter: Search | Esc: Cancel": "Tab: Nächstes Feld | Shift+Tab: Vorheriges Feld | Leertaste: Suchart wechseln | Enter: Suchen | Esc: Abbrechen",
+	"Keyword search: Searches in title, content, and tags":                                                  "Stichwortsuche: Sucht in Titel, Inhalt und Tags",
+	"Tag search: Searches only in note tags":                                                                "Tag-Suche: Sucht nur in den Tags der Notizen",
+	"Date search: Searches by creation date (formats: YYYY-MM-DD, MM/DD/YYYY, etc.)":                        "Datumssuche: Sucht nach Erstellungsdatum (Formate: YYYY-MM-DD, MM/DD/YYYY usw.)",
+
+	// Create and edit forms
+	"CREATE NEW NOTE": "NEUE NOTIZ ANLEGEN",
+	"EDIT NOTE":       "NOTIZ BEARBEITEN",
+	"Title:":          "Titel:",
+	"Tags:":           "Tags:",
+	"Format:":         "Format:",
+	"Content:":        "Inhalt:",
+	"Tab: Next field | Shift+Tab: Previous field | Enter: Next/Save | Ctrl+S: Save | Esc: Cancel": "Tab: Nächstes Feld | Shift+Tab: Vorheriges Feld | Enter: Weiter/Speichern | Ctrl+S: Speichern | Esc: Abbrechen",
+
+	// Delete confirmation
+	"CONFIRM DELETE": "LÖSCHEN BESTÄTIGEN",
+	"'%s' is pinned. Type its title to delete it.":                               "'%s' ist angeheftet. Gib den Titel ein, um sie zu löschen.",
+	"Are you sure you want to delete note '%s'? This action cannot be undone.":   "Notiz '%s' wirklich löschen? Das kann nicht rückgängig gemacht werden.",
+	"Are you sure you want to delete note '%s'? Press u in the list to undo it.": "Notiz '%s' wirklich löschen? Drücke u in der Liste, um es rückgängig zu machen.",
+	"enter: Delete | esc: Cancel":                                                "enter: Löschen | esc: Abbrechen",
+	"Y: Confirm | N: Cancel":                                                     "Y: Bestätigen | N: Abbrechen",
+
+	// CLI
+	"No notes found.": "Keine Notizen gefunden.",
+	"Found %d notes":  "%d Notizen gefunden",
+}
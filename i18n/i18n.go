@@ -0,0 +1,81 @@
+// Package i18n translates burh's user-facing strings. Messages are looked up by
+// their English text in the catalog of the current locale; anything a catalog
+// lacks, and everything in the default "en" locale, is shown in English.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// catalogs holds the translations of each locale, keyed by English message
+var catalogs = map[string]map[string]string{
+	"de": de,
+}
+
+// locale is the language messages are shown in
+var locale = "en"
+
+// Locales returns the supported locales, "en" first
+func Locales() []string {
+	locales := []string{"en"}
+	for name := range catalogs {
+		locales = append(locales, name)
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// Detect picks the locale to use: the configured one if set, otherwise the one
+// in LC_ALL, LC_MESSAGES, or LANG, the first of them that is set. "de_DE.UTF-8"
+// means "de"; unsupported locales fall back to "en".
+func Detect(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		name := strings.ToLower(value)
+		if i := strings.IndexAny(name, "_.@"); i >= 0 {
+			name = name[:i]
+		}
+		if catalogs[name] != nil {
+			return name
+		}
+		return "en"
+	}
+	return "en"
+}
+
+// Supported checks if there is a catalog for a locale, or it is "en"
+func Supported(name string) bool {
+	return name == "en" || catalogs[name] != nil
+}
+
+// SetLocale sets the language messages are shown in. Unsupported locales show
+// English.
+func SetLocale(name string) {
+	locale = name
+}
+
+// Locale returns the language messages are shown in
+func Locale() string {
+	return locale
+}
+
+// T translates a message. With args, the message is a format string and the
+// translation is formatted with them.
+func T(message string, args ...any) string {
+	if translated, ok := catalogs[locale][message]; ok {
+		message = translated
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -2,6 +2,13 @@ package main
 
 import "burh/cmd"
 
+// Set at build time by the Makefile
+var (
+	Version   = "dev"
+	BuildTime = ""
+)
+
 func main() {
+	cmd.SetVersion(Version, BuildTime)
 	cmd.Execute()
 }
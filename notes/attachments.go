@@ -0,0 +1,60 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AttachmentsDir is the folder, next to the notes, that images added to notes are
+// saved in
+const AttachmentsDir = "attachments"
+
+// AddImage saves PNG data in the attachments folder next to a note's file and
+// links it at the end of the note. It returns the note and the image's path
+// relative to the note.
+func (m *Manager) AddImage(id string, data []byte) (*Note, string, error) {
+	note, err := m.GetNote(id)
+	if err != nil {
+		return nil, "", err
+	}
+	if !WritableFormat(note.Format) {
+		return nil, "", fmt.Errorf("'%s' is a %s file and cannot be edited", note.Title, note.Format)
+	}
+	if err := checkUnlocked(note); err != nil {
+		return nil, "", err
+	}
+	if err := m.checkWritable(note.Path()); err != nil {
+		return nil, "", err
+	}
+
+	dir := filepath.Join(filepath.Dir(note.Path()), AttachmentsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, "", fmt.Errorf("failed to create attachments folder: %w", err)
+	}
+	name := fmt.Sprintf("%s_%s.png", note.ID, time.Now().Format("20060102_150405"))
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+		return nil, "", fmt.Errorf("failed to save image: %w", err)
+	}
+
+	rel := AttachmentsDir + "/" + name
+	note, err = m.AppendContent(note, imageLink(rel, note.Format))
+	if err != nil {
+		return nil, "", err
+	}
+	return note, rel, nil
+}
+
+// imageLink links an image in the syntax of a note format: ![](path) in markdown,
+// [[file:path]] in org, which displays it inline, and the path alone in text notes
+func imageLink(path, format string) string {
+	switch markupOf(format) {
+	case MarkdownMarkup:
+		return fmt.Sprintf("![](%s)", path)
+	case OrgMarkup:
+		return fmt.Sprintf("[[file:%s]]", path)
+	default:
+		return path
+	}
+}
@@ -0,0 +1,140 @@
+package notes
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AuditEntry is a line of the audit log: a change made to a note's file
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	User     string    `json:"user"`
+	Action   string    `json:"action"` // An operation kind, or "undo" or "redo" and the kind undone or redone
+	NoteID   string    `json:"note_id"`
+	Title    string    `json:"title,omitempty"`
+	OldID    string    `json:"old_id,omitempty"`    // Set when the change renamed the file
+	OldTitle string    `json:"old_title,omitempty"` // Set when the change retitled the note
+	Path     string    `json:"path"`
+}
+
+// SetAuditLog turns on logging every change to a note to an append-only file; an
+// empty path turns it off
+func (m *Manager) SetAuditLog(path string) {
+	m.auditPath = path
+}
+
+// audit logs a change to a note's file from one state to another; either may be
+// nil for no file
+func (m *Manager) audit(action string, before, after *FileState) error {
+	if m.auditPath == "" {
+		return nil
+	}
+
+	entry := AuditEntry{Time: time.Now(), User: currentUser(), Action: action}
+	if after == nil {
+		entry.NoteID, entry.Title, entry.Path = stateID(before), m.stateTitle(before), before.Path
+	} else {
+		entry.NoteID, entry.Title, entry.Path = stateID(after), m.stateTitle(after), after.Path
+		if before != nil {
+			if id := stateID(before); id != entry.NoteID {
+				entry.OldID = id
+			}
+			if title := m.stateTitle(before); title != entry.Title {
+				entry.OldTitle = title
+			}
+		}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(m.auditPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// stateID returns the ID of the note a file state belongs to
+func stateID(state *FileState) string {
+	name := filepath.Base(state.Path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// stateTitle returns the title of the note in a file state, "" for binary notes
+func (m *Manager) stateTitle(state *FileState) string {
+	_, handler := fileFormat(state.Path)
+	if _, ok := handler.(FileParser); ok {
+		return ""
+	}
+	return handler.Parse(string(state.Data), m.isHeaderField).Title
+}
+
+// currentUser returns the name of the user making a change
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// ReadAuditLog reads the audit log, oldest entry first. A missing file yields no
+// entries; lines that can't be parsed are skipped.
+func ReadAuditLog(path string) ([]AuditEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// Matches checks if an audit entry concerns a note, by its ID now or before the change
+func (e AuditEntry) Matches(id string) bool {
+	return strings.HasPrefix(e.NoteID, id) || (e.OldID != "" && strings.HasPrefix(e.OldID, id))
+}
+
+// LastEditors returns who made the last logged change to each note, by note ID.
+// It is empty when the audit log is off.
+func (m *Manager) LastEditors() (map[string]string, error) {
+	editors := map[string]string{}
+	if m.auditPath == "" {
+		return editors, nil
+	}
+	entries, err := ReadAuditLog(m.auditPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		editors[entry.NoteID] = entry.User // Later entries are more recent
+	}
+	return editors, nil
+}
@@ -0,0 +1,138 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveDirName is the subdirectory of a notes directory that archived notes are moved to.
+// Notes in subdirectories are not listed, so archived notes drop out of the normal views.
+const ArchiveDirName = "archive"
+
+// MoveNote moves a note file into another directory
+func (m *Manager) MoveNote(note *Note, dir string) error {
+	if err := m.checkWritable(note.Path()); err != nil {
+		return err
+	}
+	if err := m.checkWritable(filepath.Join(dir, note.Filename)); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	target := filepath.Join(dir, note.Filename)
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("a file named %s already exists in %s", note.Filename, dir)
+	}
+
+	return m.journaled(OpMove, note, func() error {
+		if err := os.Rename(note.Path(), target); err != nil {
+			return fmt.Errorf("failed to move note: %w", err)
+		}
+		if err := moveSidecar(note.Path(), target); err != nil {
+			return fmt.Errorf("failed to move OCR text: %w", err)
+		}
+
+		note.Dir = dir
+		return nil
+	})
+}
+
+// ArchiveNote moves a note into the archive subdirectory of its notes directory
+func (m *Manager) ArchiveNote(note *Note) error {
+	return m.MoveNote(note, filepath.Join(note.Dir, ArchiveDirName))
+}
+
+// RenameNote changes a note's title and saves it. The file name and ID are kept.
+func (m *Manager) RenameNote(note *Note, title string) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+
+	note.Title = title
+	_, err := m.saveUpdated(note)
+	return err
+}
+
+// UpdateTags adds and removes tags on a note and saves it
+func (m *Manager) UpdateTags(note *Note, add, remove []string) error {
+	var tags []string
+	for _, tag := range note.Tags {
+		if !hasTag(remove, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	for _, tag := range add {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !hasTag(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	note.Tags = tags
+	_, err := m.saveUpdated(note)
+	return err
+}
+
+// CloneNote creates a new note with the same content, tags, format, and metadata as note,
+// in the same directory. An empty title defaults to "Copy of <title>".
+func (m *Manager) CloneNote(note *Note, title string) (*Note, error) {
+	if strings.TrimSpace(title) == "" {
+		title = "Copy of " + note.Title
+	}
+	return m.copyNote(note, title, note.Dir)
+}
+
+// CopyNote creates a copy of note, under a new ID, in the given directory
+func (m *Manager) CopyNote(note *Note, dir string) (*Note, error) {
+	return m.copyNote(note, note.Title, dir)
+}
+
+// copyNote creates a new note with the given title and the same content, tags,
+// format, and metadata as note, in dir
+func (m *Manager) copyNote(note *Note, title, dir string) (*Note, error) {
+	if err := m.LoadContent(note); err != nil {
+		return nil, err
+	}
+
+	tags := append([]string(nil), note.Tags...)
+	clone, err := m.createNote(title, note.Content, tags, note.Format, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(note.Meta) > 0 {
+		clone.Meta = make(map[string]string, len(note.Meta))
+		for key, value := range note.Meta {
+			clone.Meta[key] = value
+		}
+		if err := m.saveNoteToFile(clone); err != nil {
+			return nil, fmt.Errorf("failed to save cloned note: %w", err)
+		}
+	}
+
+	if filepath.Clean(clone.Dir) != filepath.Clean(dir) {
+		if err := m.MoveNote(clone, dir); err != nil {
+			return nil, err
+		}
+	}
+	return clone, nil
+}
+
+// ExportNote copies a note file into the given directory
+func (m *Manager) ExportNote(note *Note, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	data, err := os.ReadFile(note.Path())
+	if err != nil {
+		return fmt.Errorf("failed to read note: %w", err)
+	}
+
+	return os.WriteFile(filepath.Join(dir, note.Filename), data, 0644)
+}
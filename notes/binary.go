@@ -0,0 +1,159 @@
+package notes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// extractorTimeout bounds how long an extractor may run on one file
+const extractorTimeout = 30 * time.Second
+
+// extraction is the cached result of running an extractor on a file
+type extraction struct {
+	modTime time.Time
+	size    int64
+	text    string
+	err     error
+}
+
+// extractions caches extractor output by path, so a file is only extracted
+// again once it changes. Failures are cached too, and reported by ExtractorFailures.
+var extractions = struct {
+	sync.Mutex
+	byPath map[string]extraction
+}{byPath: map[string]extraction{}}
+
+// BinaryExtensions are the file types that can be listed as binary notes
+var BinaryExtensions = append([]string{".pdf"}, ImageExtensions...)
+
+// BinaryFormat lists a non-text file, such as a PDF or an image, as a note.
+// Its title comes from the file name and it cannot be edited from burh. When
+// Extractor is set, the command's output becomes the note's searchable content.
+type BinaryFormat struct {
+	Extension string
+	Extractor string // Command printing the file's text; {path} is replaced by the file path
+}
+
+// Extensions returns the single extension the format was registered for
+func (f BinaryFormat) Extensions() []string {
+	return []string{f.Extension}
+}
+
+// Parse returns nothing: binary files have no header or body to read
+func (f BinaryFormat) Parse(content string, isField func(key string) bool) Parsed {
+	return Parsed{}
+}
+
+// Format is never used, since binary notes are read-only
+func (f BinaryFormat) Format(note *Note) string {
+	return ""
+}
+
+// ReadHeader returns an empty header without reading the file
+func (f BinaryFormat) ReadHeader(r io.Reader, isField func(key string) bool) (string, error) {
+	return "", nil
+}
+
+// ParseFile reads the file's OCR sidecar if it has an up-to-date one, and otherwise
+// runs the extractor, if any, unless its output for the file is cached. A failing
+// extractor leaves the content empty so the note can still be found by its title;
+// the failure is kept for ExtractorFailures.
+func (f BinaryFormat) ParseFile(path string, isField func(key string) bool) (Parsed, error) {
+	if text, ok := readSidecar(path); ok {
+		return Parsed{Content: text}, nil
+	}
+	if f.Extractor == "" {
+		return Parsed{}, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return Parsed{}, err
+	}
+
+	extractions.Lock()
+	cached, ok := extractions.byPath[path]
+	extractions.Unlock()
+	if !ok || !cached.modTime.Equal(info.ModTime()) || cached.size != info.Size() {
+		text, err := runExtractor(f.Extractor, path)
+		cached = extraction{modTime: info.ModTime(), size: info.Size(), text: text, err: err}
+		extractions.Lock()
+		extractions.byPath[path] = cached
+		extractions.Unlock()
+	}
+	return Parsed{Content: cached.text}, nil
+}
+
+// ExtractorFailures returns the errors of extractors that failed on the files
+// read so far, ordered by path
+func ExtractorFailures() []error {
+	extractions.Lock()
+	defer extractions.Unlock()
+
+	paths := make([]string, 0, len(extractions.byPath))
+	for path, cached := range extractions.byPath {
+		if cached.err != nil {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	failures := make([]error, len(paths))
+	for i, path := range paths {
+		failures[i] = extractions.byPath[path].err
+	}
+	return failures
+}
+
+// ReadOnly marks binary notes as not writable
+func (f BinaryFormat) ReadOnly() bool {
+	return true
+}
+
+// RegisterBinaryFormats registers a BinaryFormat for every binary extension.
+// extractors maps extensions to extractor commands.
+func RegisterBinaryFormats(extractors map[string]string) {
+	for _, ext := range BinaryExtensions {
+		RegisterFormat(strings.TrimPrefix(ext, "."), BinaryFormat{Extension: ext, Extractor: extractors[ext]})
+	}
+}
+
+// runExtractor runs an extractor command on a file and returns its output. The
+// command is killed if it runs longer than extractorTimeout.
+func runExtractor(command, path string) (string, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return "", nil
+	}
+
+	substituted := false
+	for i, arg := range args {
+		if strings.Contains(arg, "{path}") {
+			args[i] = strings.ReplaceAll(arg, "{path}", path)
+			substituted = true
+		}
+	}
+	if !substituted {
+		args = append(args, path)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), extractorTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.WaitDelay = time.Second // Don't wait on children still holding the output open
+	out, err := cmd.Output()
+	if ctx.Err() != nil {
+		return "", fmt.Errorf("extractor %s timed out on %s after %s", args[0], filepath.Base(path), extractorTimeout)
+	}
+	if err != nil {
+		return "", fmt.Errorf("extractor %s failed on %s: %w", args[0], filepath.Base(path), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,164 @@
+package notes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BookmarksTag marks the notes that collect bookmarks
+const BookmarksTag = "bookmarks"
+
+// Bookmark is a saved link: one list item in a bookmarks note
+type Bookmark struct {
+	Note  *Note
+	URL   string
+	Title string
+	Tags  []string
+	Added time.Time
+}
+
+var (
+	mdBookmark     = regexp.MustCompile(`^\s*[-*+]\s+\[(.*?)\]\((\S+?)\)(.*)$`)
+	orgBookmark    = regexp.MustCompile(`^\s*[-+]\s+\[\[(\S+?)\](?:\[(.*?)\])?\](.*)$`)
+	textBookmark   = regexp.MustCompile(`^\s*[-*]\s+(.*?)\s*<(\S+)>(.*)$`)
+	bookmarkTag    = regexp.MustCompile(`(?:^|\s)#([\w-]+)`)
+	bookmarkAdded  = regexp.MustCompile(`\((\d{4}-\d{2}-\d{2})\)`)
+	bookmarkEscape = strings.NewReplacer("[", "(", "]", ")", "\n", " ")
+)
+
+// FormatBookmark renders a bookmark as a list item in a note format:
+// "- [Title](url) #tag (2024-06-01)" in Markdown, "- [[url][Title]] ..." in org,
+// and "- Title <url> ..." in plain text
+func FormatBookmark(b Bookmark, format string) string {
+	title := strings.TrimSpace(bookmarkEscape.Replace(b.Title))
+	if title == "" {
+		title = b.URL
+	}
+
+	var sb strings.Builder
+	switch markupOf(format) {
+	case MarkdownMarkup:
+		fmt.Fprintf(&sb, "- [%s](%s)", title, b.URL)
+	case OrgMarkup:
+		fmt.Fprintf(&sb, "- [[%s][%s]]", b.URL, title)
+	default:
+		fmt.Fprintf(&sb, "- %s <%s>", title, b.URL)
+	}
+	for _, tag := range b.Tags {
+		sb.WriteString(" #" + tag)
+	}
+	if !b.Added.IsZero() {
+		sb.WriteString(" (" + b.Added.Format("2006-01-02") + ")")
+	}
+	return sb.String()
+}
+
+// ParseBookmarks reads the bookmarks written by FormatBookmark from a note's content
+func ParseBookmarks(note *Note) []Bookmark {
+	var bookmarks []Bookmark
+	for _, line := range strings.Split(note.Content, "\n") {
+		var title, url, rest string
+		if m := orgBookmark.FindStringSubmatch(line); m != nil && isOrg(note.Format) {
+			url, title, rest = m[1], m[2], m[3]
+		} else if m := mdBookmark.FindStringSubmatch(line); m != nil {
+			title, url, rest = m[1], m[2], m[3]
+		} else if m := textBookmark.FindStringSubmatch(line); m != nil {
+			title, url, rest = m[1], m[2], m[3]
+		} else {
+			continue
+		}
+		if !strings.Contains(url, "://") {
+			continue
+		}
+
+		b := Bookmark{Note: note, URL: url, Title: title}
+		if b.Title == "" {
+			b.Title = url
+		}
+		for _, m := range bookmarkTag.FindAllStringSubmatch(rest, -1) {
+			b.Tags = append(b.Tags, m[1])
+		}
+		if m := bookmarkAdded.FindStringSubmatch(rest); m != nil {
+			b.Added, _ = time.ParseInLocation("2006-01-02", m[1], time.Local)
+		}
+		bookmarks = append(bookmarks, b)
+	}
+	return bookmarks
+}
+
+// BookmarksTitle returns the title of the note new bookmarks go to:
+// "Bookmarks", or "Bookmarks YYYY-MM" with one note per month
+func BookmarksTitle(monthly bool, now time.Time) string {
+	if monthly {
+		return "Bookmarks " + now.Format("2006-01")
+	}
+	return "Bookmarks"
+}
+
+// Bookmarks returns the bookmarks in every note tagged "bookmarks", newest first
+func (m *Manager) Bookmarks() ([]Bookmark, error) {
+	noteList, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	var bookmarks []Bookmark
+	for _, note := range noteList {
+		if !hasTag(note.Tags, BookmarksTag) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, ParseBookmarks(note)...)
+	}
+
+	sort.SliceStable(bookmarks, func(i, j int) bool {
+		return bookmarks[i].Added.After(bookmarks[j].Added)
+	})
+	return bookmarks, nil
+}
+
+// AddBookmark appends a bookmark to the bookmarks note with the given title,
+// creating the note in the given format if it does not exist yet
+func (m *Manager) AddBookmark(b Bookmark, noteTitle, format string) (*Note, error) {
+	noteList, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, note := range noteList {
+		if !hasTag(note.Tags, BookmarksTag) || !strings.EqualFold(note.Title, noteTitle) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		if !WritableFormat(note.Format) {
+			return nil, fmt.Errorf("%s notes cannot be edited", note.Format)
+		}
+		content := strings.TrimRight(note.Content, "\n")
+		if content != "" {
+			content += "\n"
+		}
+		note.Content = content + FormatBookmark(b, note.Format)
+		return m.saveUpdated(note)
+	}
+
+	return m.CreateNote(noteTitle, FormatBookmark(b, format), []string{BookmarksTag}, format)
+}
+
+// MatchesBookmark reports whether every word of the query appears in the bookmark's
+// title, URL, or tags, ignoring case
+func MatchesBookmark(b Bookmark, query string) bool {
+	text := strings.ToLower(b.Title + " " + b.URL + " " + strings.Join(b.Tags, " "))
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,282 @@
+package notes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BooksTag marks the library note that holds books
+const BooksTag = "books"
+
+// LibraryTitle is the title of the library note created when the first book is added
+const LibraryTitle = "Library"
+
+// Book statuses
+const (
+	BookToRead   = "to-read"
+	BookReading  = "reading"
+	BookFinished = "finished"
+)
+
+// BookStatuses lists the statuses a book can have, in reading order
+var BookStatuses = []string{BookToRead, BookReading, BookFinished}
+
+// Book is one record of a library note: a heading with the book's title and
+// author, status, rating, pages, and dates as fields
+type Book struct {
+	Note     *Note
+	Title    string
+	Author   string
+	Status   string
+	Rating   int // 1-5, 0 when not rated
+	Pages    int // Length of the book, 0 when unknown
+	Page     int // Page reached while reading
+	Started  time.Time
+	Finished time.Time
+}
+
+// Progress returns how far through the book its reader is, from 0 to 1. Finished
+// books are complete; books without a page count have no progress.
+func (b Book) Progress() float64 {
+	if b.Status == BookFinished {
+		return 1
+	}
+	if b.Pages <= 0 {
+		return 0
+	}
+	return min(1, float64(b.Page)/float64(b.Pages))
+}
+
+// BookFromRecord reads a book from a library record, ignoring fields it cannot parse
+func BookFromRecord(r Record) Book {
+	b := Book{
+		Note:   r.Note,
+		Title:  r.Name,
+		Author: r.Fields["author"],
+		Status: strings.ToLower(r.Fields["status"]),
+	}
+	if b.Status == "" {
+		b.Status = BookToRead
+	}
+	b.Rating, _ = strconv.Atoi(r.Fields["rating"])
+	b.Pages, _ = strconv.Atoi(r.Fields["pages"])
+	b.Page, _ = strconv.Atoi(r.Fields["page"])
+	b.Started, _ = time.ParseInLocation("2006-01-02", r.Fields["started"], time.Local)
+	b.Finished, _ = time.ParseInLocation("2006-01-02", r.Fields["finished"], time.Local)
+	return b
+}
+
+// bookFields returns the fields of a book as they are written to the library note,
+// in a fixed order, leaving out empty ones
+func bookFields(b Book) [][2]string {
+	var fields [][2]string
+	add := func(key, value string) {
+		if value != "" && value != "0" {
+			fields = append(fields, [2]string{key, value})
+		}
+	}
+	add("author", b.Author)
+	add("status", b.Status)
+	add("rating", strconv.Itoa(b.Rating))
+	add("pages", strconv.Itoa(b.Pages))
+	add("page", strconv.Itoa(b.Page))
+	if !b.Started.IsZero() {
+		add("started", b.Started.Format("2006-01-02"))
+	}
+	if !b.Finished.IsZero() {
+		add("finished", b.Finished.Format("2006-01-02"))
+	}
+	return fields
+}
+
+// FormatBook writes a book as a record in a note format: an org heading with a
+// property drawer, or a markdown heading followed by "key: value" lines
+func FormatBook(b Book, format string) string {
+	var sb strings.Builder
+	if isOrg(format) {
+		sb.WriteString("* " + b.Title + "\n:PROPERTIES:\n")
+		for _, f := range bookFields(b) {
+			sb.WriteString(fmt.Sprintf(":%s: %s\n", strings.ToUpper(f[0]), f[1]))
+		}
+		sb.WriteString(":END:")
+		return sb.String()
+	}
+	sb.WriteString("## " + b.Title)
+	for _, f := range bookFields(b) {
+		sb.WriteString(fmt.Sprintf("\n%s: %s", f[0], f[1]))
+	}
+	return sb.String()
+}
+
+// Books returns the books in every note tagged "books", sorted by title
+func (m *Manager) Books() ([]Book, error) {
+	noteList, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	var library []*Note
+	for _, note := range noteList {
+		if !hasTag(note.Tags, BooksTag) || !WritableFormat(note.Format) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		library = append(library, note)
+	}
+
+	var books []Book
+	for _, record := range RecordsWithTag(library, BooksTag) {
+		books = append(books, BookFromRecord(record))
+	}
+	return books, nil
+}
+
+// FindBook finds a book by title, ignoring case: an exact match, or else the only
+// book whose title contains the text
+func FindBook(books []Book, title string) (Book, error) {
+	title = strings.ToLower(strings.TrimSpace(title))
+	var matches []Book
+	for _, b := range books {
+		if strings.ToLower(b.Title) == title {
+			return b, nil
+		}
+		if strings.Contains(strings.ToLower(b.Title), title) {
+			matches = append(matches, b)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Book{}, fmt.Errorf("no book matching %q", title)
+	case 1:
+		return matches[0], nil
+	default:
+		var titles []string
+		for _, b := range matches {
+			titles = append(titles, b.Title)
+		}
+		sort.Strings(titles)
+		return Book{}, fmt.Errorf("%q matches several books: %s", title, strings.Join(titles, "; "))
+	}
+}
+
+// AddBook appends a book to the library note, creating the note in the given
+// format if there is none yet
+func (m *Manager) AddBook(b Book, format string) (*Note, error) {
+	b.Title = strings.TrimSpace(b.Title)
+	if b.Title == "" {
+		return nil, fmt.Errorf("book title cannot be empty")
+	}
+
+	books, err := m.Books()
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range books {
+		if strings.EqualFold(existing.Title, b.Title) {
+			return nil, fmt.Errorf("%q is already in the library", existing.Title)
+		}
+	}
+
+	noteList, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range noteList {
+		if !hasTag(note.Tags, BooksTag) || !WritableFormat(note.Format) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		content := strings.TrimRight(note.Content, "\n")
+		if content != "" {
+			content += "\n\n"
+		}
+		note.Content = content + FormatBook(b, note.Format)
+		return m.saveUpdated(note)
+	}
+
+	return m.CreateNote(LibraryTitle, FormatBook(b, format), []string{BooksTag}, format)
+}
+
+// UpdateBook rewrites a book's record in its library note with the book's fields
+func (m *Manager) UpdateBook(b Book) error {
+	note := b.Note
+	if err := m.LoadContent(note); err != nil {
+		return err
+	}
+
+	lines := strings.Split(note.Content, "\n")
+	start, end := -1, len(lines)
+	for i, line := range lines {
+		title, ok := bookHeading(line, note.Format)
+		if !ok {
+			continue
+		}
+		if start != -1 {
+			end = i
+			break
+		}
+		if strings.EqualFold(title, b.Title) {
+			start = i
+		}
+	}
+	if start == -1 {
+		return fmt.Errorf("%q not found in %s", b.Title, note.ID)
+	}
+
+	// Keep anything in the section that is not a book field, such as other org
+	// properties or notes on the book
+	var properties, rest []string
+	for _, line := range lines[start+1 : end] {
+		trimmed := strings.TrimSpace(line)
+		if m := orgPropertyRow.FindStringSubmatch(trimmed); m != nil {
+			if key := strings.ToLower(m[1]); key != "properties" && key != "end" && !isBookKey(key) {
+				properties = append(properties, line)
+			}
+			continue
+		}
+		if m := recordFieldRow.FindStringSubmatch(trimmed); m == nil || !isBookKey(m[1]) {
+			rest = append(rest, line)
+		}
+	}
+
+	entry := strings.Split(FormatBook(b, note.Format), "\n")
+	entry[0] = lines[start] // Keep the heading as written, e.g. with org tags
+	if isOrg(note.Format) {
+		// Other properties stay in the drawer, before its :END:
+		last := len(entry) - 1
+		entry = append(append(entry[:last:last], properties...), entry[last])
+	}
+	note.Content = joinLines(lines[:start], entry, rest, lines[end:])
+	_, err := m.saveUpdated(note)
+	return err
+}
+
+// bookHeading returns the title of a heading line in a library note
+func bookHeading(line, format string) (string, bool) {
+	if isOrg(format) {
+		if m := orgHeadingLine.FindStringSubmatch(line); m != nil {
+			return m[3], true
+		}
+		return "", false
+	}
+	if m := mdHeadingLine.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+		return m[1], true
+	}
+	return "", false
+}
+
+// isBookKey checks if a field name is one of the fields a book has
+func isBookKey(key string) bool {
+	switch strings.ToLower(key) {
+	case "author", "status", "rating", "pages", "page", "started", "finished":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package notes
+
+import (
+	"strings"
+)
+
+// ParseQuickCapture splits free-form capture text into a title, content, and tags.
+// The first line becomes the title, the remaining lines the content, and every
+// #word anywhere in the text becomes a tag. Hashtags are removed from the title.
+func ParseQuickCapture(text string) (title, content string, tags []string) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\\n", "\n"))
+	firstLine, rest, _ := strings.Cut(text, "\n")
+
+	seen := map[string]struct{}{}
+	var titleWords []string
+
+	for _, word := range strings.Fields(firstLine) {
+		if tag, ok := hashtag(word); ok {
+			if _, dup := seen[tag]; !dup {
+				seen[tag] = struct{}{}
+				tags = append(tags, tag)
+			}
+			continue
+		}
+		titleWords = append(titleWords, word)
+	}
+
+	for _, word := range strings.Fields(rest) {
+		if tag, ok := hashtag(word); ok {
+			if _, dup := seen[tag]; !dup {
+				seen[tag] = struct{}{}
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	title = strings.Join(titleWords, " ")
+	if title == "" {
+		title = "Quick note"
+	}
+
+	return title, strings.TrimSpace(rest), tags
+}
+
+// hashtag returns the tag name if word is of the form #tag
+func hashtag(word string) (string, bool) {
+	if len(word) < 2 || word[0] != '#' {
+		return "", false
+	}
+	tag := strings.TrimRight(word[1:], ".,;:!?")
+	if tag == "" || strings.HasPrefix(tag, "#") {
+		return "", false
+	}
+	return strings.ToLower(tag), true
+}
@@ -0,0 +1,276 @@
+package notes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// clockLayout is the org mode timestamp format used in CLOCK lines
+const clockLayout = "2006-01-02 Mon 15:04"
+
+// logbookStart and logbookEnd delimit the drawer that holds a note's CLOCK lines
+const (
+	logbookStart = ":LOGBOOK:"
+	logbookEnd   = ":END:"
+)
+
+// clockLine matches an org CLOCK line, closed or still running
+var clockLine = regexp.MustCompile(`^\s*CLOCK:\s*\[([^\]]+)\](?:--\[([^\]]+)\])?`)
+
+// ClockEntry is one stretch of time logged on a note
+type ClockEntry struct {
+	Note  *Note
+	Start time.Time
+	End   time.Time // Zero while the clock is running
+}
+
+// Running checks if the entry's clock has not been stopped yet
+func (e ClockEntry) Running() bool {
+	return e.End.IsZero()
+}
+
+// Duration returns the time logged by the entry, counting a running clock up to now
+func (e ClockEntry) Duration(now time.Time) time.Duration {
+	if e.Running() {
+		return now.Sub(e.Start)
+	}
+	return e.End.Sub(e.Start)
+}
+
+// FormatClockDuration formats a duration the way org does in CLOCK lines, e.g. "1:05"
+func FormatClockDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// parseClockTime reads an org timestamp, ignoring the weekday
+func parseClockTime(value string) (time.Time, bool) {
+	fields := strings.Fields(value)
+	if len(fields) != 3 {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04", fields[0]+" "+fields[2], time.Local)
+	return t, err == nil
+}
+
+// ParseClock returns the CLOCK entries in a note's content, in the order they appear
+func ParseClock(note *Note) []ClockEntry {
+	var entries []ClockEntry
+	for _, line := range strings.Split(note.Content, "\n") {
+		match := clockLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		start, ok := parseClockTime(match[1])
+		if !ok {
+			continue
+		}
+		entry := ClockEntry{Note: note, Start: start}
+		if match[2] != "" {
+			if entry.End, ok = parseClockTime(match[2]); !ok {
+				continue
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// formatClockLine writes an entry as an org CLOCK line
+func formatClockLine(start, end time.Time) string {
+	line := "CLOCK: [" + start.Format(clockLayout) + "]"
+	if !end.IsZero() {
+		line += "--[" + end.Format(clockLayout) + "] => " + fmt.Sprintf("%5s", FormatClockDuration(end.Sub(start)))
+	}
+	return line
+}
+
+// ClockEntries returns every CLOCK entry in the notes, oldest first
+func (m *Manager) ClockEntries() ([]ClockEntry, error) {
+	all, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ClockEntry
+	for _, note := range all {
+		if !WritableFormat(note.Format) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		entries = append(entries, ParseClock(note)...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Start.Before(entries[j].Start) })
+	return entries, nil
+}
+
+// RunningClock returns the entry of the clock that is running, if any
+func (m *Manager) RunningClock() (*ClockEntry, error) {
+	entries, err := m.ClockEntries()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Running() {
+			return &entries[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// ClockIn starts a clock on a note by adding a running CLOCK line to its LOGBOOK
+// drawer. Only one clock runs at a time, so a clock running on another note is
+// stopped first and returned.
+func (m *Manager) ClockIn(note *Note, now time.Time) (*ClockEntry, error) {
+	if err := checkUnlocked(note); err != nil {
+		return nil, err
+	}
+	running, err := m.RunningClock()
+	if err != nil {
+		return nil, err
+	}
+	if running != nil && running.Note.Path() == note.Path() {
+		return nil, fmt.Errorf("already clocked in to %s since %s", note.ID, running.Start.Format("15:04"))
+	}
+	if running != nil {
+		if err := m.closeClock(running, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.LoadContent(note); err != nil {
+		return nil, err
+	}
+	note.Content = addToLogbook(note.Content, formatClockLine(now.Truncate(time.Minute), time.Time{}))
+	if _, err := m.saveUpdated(note); err != nil {
+		return nil, err
+	}
+	return running, nil
+}
+
+// ClockOut stops the running clock and returns its entry
+func (m *Manager) ClockOut(now time.Time) (*ClockEntry, error) {
+	running, err := m.RunningClock()
+	if err != nil {
+		return nil, err
+	}
+	if running == nil {
+		return nil, fmt.Errorf("no clock is running")
+	}
+	if err := m.closeClock(running, now); err != nil {
+		return nil, err
+	}
+	return running, nil
+}
+
+// closeClock completes an entry's running CLOCK line with an end time and duration
+func (m *Manager) closeClock(entry *ClockEntry, now time.Time) error {
+	note := entry.Note
+	if err := checkUnlocked(note); err != nil {
+		return err
+	}
+
+	entry.End = now.Truncate(time.Minute)
+	if entry.End.Before(entry.Start) {
+		entry.End = entry.Start
+	}
+
+	lines := strings.Split(note.Content, "\n")
+	for i, line := range lines {
+		match := clockLine.FindStringSubmatch(line)
+		if match == nil || match[2] != "" {
+			continue
+		}
+		if start, ok := parseClockTime(match[1]); ok && start.Equal(entry.Start) {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			lines[i] = indent + formatClockLine(entry.Start, entry.End)
+			note.Content = strings.Join(lines, "\n")
+			_, err := m.saveUpdated(note)
+			return err
+		}
+	}
+	return fmt.Errorf("running clock not found in %s", note.ID)
+}
+
+// addToLogbook puts a line at the top of the content's LOGBOOK drawer, newest first
+// as org does, creating the drawer at the end of the content if there is none
+func addToLogbook(content, line string) string {
+	lines := strings.Split(content, "\n")
+	for i, l := range lines {
+		if strings.EqualFold(strings.TrimSpace(l), logbookStart) {
+			return joinLines(lines[:i+1], []string{line}, lines[i+1:])
+		}
+	}
+
+	content = strings.TrimRight(content, "\n")
+	if content != "" {
+		content += "\n\n"
+	}
+	return content + logbookStart + "\n" + line + "\n" + logbookEnd + "\n"
+}
+
+// ClockTotal is the time logged on one note or one tag
+type ClockTotal struct {
+	Name string // Note title or tag
+	ID   string // Note ID, empty for tags
+	Time time.Duration
+}
+
+// ClockReport sums the time logged between from and to, per note and per tag, most
+// time first. Entries are clipped to the period; running clocks count up to now.
+// A zero from or to leaves that end of the period open.
+func ClockReport(entries []ClockEntry, from, to, now time.Time) (total time.Duration, byNote, byTag []ClockTotal) {
+	noteTotals := map[string]*ClockTotal{}
+	tagTotals := map[string]*ClockTotal{}
+	for _, entry := range entries {
+		start, end := entry.Start, entry.End
+		if entry.Running() {
+			end = now
+		}
+		if !from.IsZero() && start.Before(from) {
+			start = from
+		}
+		if !to.IsZero() && end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+		d := end.Sub(start)
+		total += d
+
+		note := entry.Note
+		if noteTotals[note.ID] == nil {
+			noteTotals[note.ID] = &ClockTotal{Name: note.Title, ID: note.ID}
+		}
+		noteTotals[note.ID].Time += d
+		for _, tag := range note.Tags {
+			key := strings.ToLower(tag)
+			if tagTotals[key] == nil {
+				tagTotals[key] = &ClockTotal{Name: tag}
+			}
+			tagTotals[key].Time += d
+		}
+	}
+	return total, sortedTotals(noteTotals), sortedTotals(tagTotals)
+}
+
+// sortedTotals lists totals with the most time first
+func sortedTotals(totals map[string]*ClockTotal) []ClockTotal {
+	var list []ClockTotal
+	for _, t := range totals {
+		list = append(list, *t)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Time != list[j].Time {
+			return list[i].Time > list[j].Time
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
@@ -0,0 +1,68 @@
+package notes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CommandTag marks notes recording a shell command and its output
+const CommandTag = "command"
+
+// CommandRun is a shell command that was run and what came of it
+type CommandRun struct {
+	Command  string
+	Dir      string
+	Exit     int
+	Started  time.Time     // Zero if unknown
+	Duration time.Duration // Zero if unknown
+	Output   string        // What it printed, stdout and stderr together
+}
+
+// ansiEscape matches terminal escape sequences, such as colors, in command output
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\][^\x07]*\x07`)
+
+// CommandTitle makes a note title from a command line, shortened to fit a title
+func CommandTitle(command string) string {
+	title := strings.Join(strings.Fields(command), " ")
+	if runes := []rune(title); len(runes) > 60 {
+		title = string(runes[:59]) + "…"
+	}
+	return title
+}
+
+// CommandNote returns the content of a note recording a command run in a format:
+// where and when it ran, its exit status, the command, and its output, if any,
+// without terminal escape sequences
+func CommandNote(format string, run CommandRun) string {
+	marker := headingMarker(format)
+	var sb strings.Builder
+	if run.Dir != "" {
+		sb.WriteString("directory: " + run.Dir + "\n")
+	}
+	if !run.Started.IsZero() {
+		sb.WriteString("ran: " + run.Started.Format("2006-01-02 15:04:05") + "\n")
+	}
+	if run.Duration > 0 {
+		sb.WriteString("duration: " + run.Duration.Round(time.Millisecond).String() + "\n")
+	}
+	sb.WriteString(fmt.Sprintf("exit status: %d\n\n", run.Exit))
+
+	sb.WriteString(marker + " Command\n")
+	sb.WriteString(codeBlock(format, "sh", run.Command) + "\n")
+	output := strings.TrimRight(ansiEscape.ReplaceAllString(run.Output, ""), "\n")
+	if output != "" {
+		sb.WriteString("\n" + marker + " Output\n")
+		sb.WriteString(codeBlock(format, "", strings.ReplaceAll(output, "\r\n", "\n")) + "\n")
+	}
+	return sb.String()
+}
+
+// codeBlock wraps text in a fenced code block, or a source block in org
+func codeBlock(format, lang, text string) string {
+	if isOrg(format) {
+		return strings.TrimSpace("#+BEGIN_SRC "+lang) + "\n" + text + "\n#+END_SRC"
+	}
+	return "```" + lang + "\n" + text + "\n```"
+}
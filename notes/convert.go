@@ -0,0 +1,139 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConvertNote rewrites a note in another format and renames its file, keeping the ID.
+// Headers, heading levels, checkboxes, bullets, and code blocks are mapped to the new format.
+func (m *Manager) ConvertNote(note *Note, to string) error {
+	if !WritableFormat(to) {
+		return fmt.Errorf("unknown format %q (expected %s)", to, strings.Join(FormatNames(), ", "))
+	}
+	if note.Format == to {
+		return nil
+	}
+	if err := checkUnlocked(note); err != nil {
+		return err
+	}
+	if isReadOnly(handlerFor(note.Format)) {
+		return fmt.Errorf("%s notes cannot be converted", note.Format)
+	}
+	if err := m.LoadContent(note); err != nil {
+		return err
+	}
+
+	oldPath := note.Path()
+	newPath := ConvertedPath(note, to)
+	filename := filepath.Base(newPath)
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("a file named %s already exists in %s", filename, note.Dir)
+	}
+
+	return m.journaled(OpUpdate, note, func() error {
+		note.Content = convertContent(note.Content, markupOf(note.Format), markupOf(to))
+		note.Format = to
+		note.Filename = filename
+		if _, err := m.saveUpdated(note); err != nil {
+			return fmt.Errorf("failed to write converted note: %w", err)
+		}
+
+		if err := os.Remove(oldPath); err != nil {
+			return fmt.Errorf("converted note written but failed to remove %s: %w", oldPath, err)
+		}
+		return nil
+	})
+}
+
+// ConvertedPath returns the path a note's file gets when it is converted to a format
+func ConvertedPath(note *Note, to string) string {
+	return filepath.Join(note.Dir, note.ID+fileExtension(to))
+}
+
+// convertContent maps a note body from one markup to another. Plain text and
+// Markdown are written alike, so only conversions to or from org change the body.
+func convertContent(content, from, to string) string {
+	if from == to || (from != OrgMarkup && to != OrgMarkup) {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	if from == OrgMarkup && len(lines) > 0 && strings.TrimSpace(lines[0]) == "* CONTENT" {
+		// The wrapper heading is added back when the org note is written
+		lines = lines[1:]
+	}
+
+	inCode := false
+	for i, line := range lines {
+		if to == OrgMarkup {
+			lines[i], inCode = mdLineToOrg(line, inCode)
+		} else {
+			lines[i], inCode = orgLineToMd(line, inCode)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// mdLineToOrg converts one line of txt/md markup to org
+func mdLineToOrg(line string, inCode bool) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "```") {
+		if inCode {
+			return "#+END_SRC", false
+		}
+		return strings.TrimSpace("#+BEGIN_SRC " + strings.TrimPrefix(trimmed, "```")), true
+	}
+	if inCode {
+		return line, true
+	}
+
+	// Headings sit one level below org's CONTENT heading
+	if level := headingLevel(line, "#"); level > 0 {
+		return strings.Repeat("*", level+1) + " " + headingText(line, level), false
+	}
+
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	rest := line[len(indent):]
+	// "* item" bullets would read as org headings
+	if strings.HasPrefix(rest, "* ") {
+		rest = "- " + rest[2:]
+	}
+	if strings.HasPrefix(rest, "- [x] ") {
+		rest = "- [X] " + rest[len("- [x] "):]
+	}
+	return indent + rest, false
+}
+
+// orgLineToMd converts one line of org markup to txt/md
+func orgLineToMd(line string, inCode bool) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	upper := strings.ToUpper(trimmed)
+	if strings.HasPrefix(upper, "#+BEGIN_SRC") {
+		return "```" + strings.TrimSpace(trimmed[len("#+BEGIN_SRC"):]), true
+	}
+	if strings.HasPrefix(upper, "#+END_SRC") {
+		return "```", false
+	}
+	if inCode {
+		return line, true
+	}
+
+	// Headings move up a level now that the CONTENT heading is gone
+	if level := headingLevel(line, "*"); level > 0 {
+		mdLevel := level - 1
+		if mdLevel < 1 {
+			mdLevel = 1
+		}
+		return strings.Repeat("#", mdLevel) + " " + headingText(line, level), false
+	}
+
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	rest := line[len(indent):]
+	if strings.HasPrefix(rest, "- [X] ") {
+		rest = "- [x] " + rest[len("- [X] "):]
+	}
+	return indent + rest, false
+}
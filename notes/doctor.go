@@ -0,0 +1,59 @@
+package notes
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DuplicateIDs returns the IDs that more than one note file has, with the paths
+// of the files, sorted. Only the first of them is reachable by ID.
+func DuplicateIDs(noteList []*Note) map[string][]string {
+	paths := map[string][]string{}
+	for _, note := range noteList {
+		paths[note.ID] = append(paths[note.ID], note.Path())
+	}
+	for id, files := range paths {
+		if len(files) < 2 {
+			delete(paths, id)
+			continue
+		}
+		sort.Strings(files)
+	}
+	return paths
+}
+
+// OrphanedSidecars returns the OCR sidecar files in the notes directories whose
+// note file no longer exists
+func (m *Manager) OrphanedSidecars() ([]string, error) {
+	var orphans []string
+	for _, dir := range m.notesDirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if path == dir && os.IsNotExist(err) {
+					return fs.SkipDir
+				}
+				return err
+			}
+			if d.IsDir() {
+				if path != dir && !m.recursive {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ocrSuffix) {
+				return nil
+			}
+			if _, err := os.Stat(strings.TrimSuffix(path, ocrSuffix)); os.IsNotExist(err) {
+				orphans = append(orphans, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return orphans, nil
+}
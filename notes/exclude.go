@@ -0,0 +1,59 @@
+package notes
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// SetExclusions leaves notes with any of the tags, or in any of the directories,
+// out of ListNotes and searches. An absolute directory excludes everything under
+// it; a relative one names a subdirectory of each notes directory, e.g. clippings.
+// Notes stay reachable by ID.
+func (m *Manager) SetExclusions(tags, dirs []string) {
+	m.excludeTags = tags
+	m.excludeDirs = nil
+	for _, dir := range dirs {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			m.excludeDirs = append(m.excludeDirs, filepath.Clean(dir))
+		}
+	}
+}
+
+// Exclusions returns the tags and directories set by SetExclusions
+func (m *Manager) Exclusions() (tags, dirs []string) {
+	return m.excludeTags, m.excludeDirs
+}
+
+// excluded checks if a note read from notesDir is left out by the exclusions
+func (m *Manager) excluded(notesDir string, note *Note) bool {
+	for _, tag := range m.excludeTags {
+		if hasTag(note.Tags, tag) {
+			return true
+		}
+	}
+	for _, dir := range m.excludeDirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(notesDir, dir)
+		}
+		if within(dir, note.Dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// excludedDir checks if a whole notes directory is left out by the exclusions
+func (m *Manager) excludedDir(notesDir string) bool {
+	for _, dir := range m.excludeDirs {
+		if filepath.IsAbs(dir) && within(dir, notesDir) {
+			return true
+		}
+	}
+	return false
+}
+
+// within checks if path is dir or somewhere under it
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,81 @@
+package notes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExpiresKey is the metadata field holding the date (YYYY-MM-DD) a note expires
+const ExpiresKey = "expires"
+
+// RetentionRule expires notes with a tag that have not been modified since a cutoff
+type RetentionRule struct {
+	Tag    string
+	After  string    // Period the rule was written with, e.g. "30d", for reports
+	Cutoff time.Time // Notes last modified before this are expired
+}
+
+// Expired is a note due for archiving and the reason it is due
+type Expired struct {
+	Note   *Note
+	Reason string
+}
+
+// ExpiresOn returns the expiry date set on a note. ok is false when the note has
+// no expiry date; err is set when the date cannot be read.
+func ExpiresOn(note *Note) (date time.Time, ok bool, err error) {
+	value := strings.TrimSpace(note.Meta[ExpiresKey])
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	date, err = time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%s: invalid %s date %q (expected YYYY-MM-DD)", note.ID, ExpiresKey, value)
+	}
+	return date, true, nil
+}
+
+// ExpiredNotes returns the notes due for archiving at now: those whose expiry date
+// has passed, and those matching a retention rule. A note's own expiry date takes
+// precedence over the rules. Notes with unreadable expiry dates are reported in
+// problems and otherwise left alone. Locked notes are never expired.
+func (m *Manager) ExpiredNotes(rules []RetentionRule, now time.Time) (expired []Expired, problems []error, err error) {
+	all, err := m.ListNotes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for _, note := range all {
+		if note.Locked {
+			continue
+		}
+
+		date, ok, err := ExpiresOn(note)
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		if ok {
+			if !date.After(today) {
+				expired = append(expired, Expired{Note: note, Reason: "expired " + date.Format("2006-01-02")})
+			}
+			continue
+		}
+
+		for _, rule := range rules {
+			if hasTag(note.Tags, rule.Tag) && note.Modified.Before(rule.Cutoff) {
+				reason := fmt.Sprintf("tagged %s, unchanged for %s", rule.Tag, rule.After)
+				expired = append(expired, Expired{Note: note, Reason: reason})
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].Note.Modified.Before(expired[j].Note.Modified)
+	})
+	return expired, problems, nil
+}
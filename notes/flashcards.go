@@ -0,0 +1,222 @@
+package notes
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FlashcardsTag marks the notes 'burh study' quizzes by default
+const FlashcardsTag = "flashcards"
+
+// Card is a flashcard found in a note: a "Q:"/"A:" pair, or one deletion of a
+// cloze line such as "The capital of {{c1::France}} is {{c2::Paris}}"
+type Card struct {
+	Note     *Note
+	Question string
+	Answer   string
+	Line     int // 1-based line in the note's content where the card starts
+}
+
+// CardState is a card's SM-2 schedule
+type CardState struct {
+	Ease     float64   `json:"ease"`     // Easiness factor, at least 1.3
+	Interval int       `json:"interval"` // Days until the next review
+	Reps     int       `json:"reps"`     // Successful reviews in a row
+	Due      time.Time `json:"due"`
+}
+
+var (
+	questionLine = regexp.MustCompile(`^(?:[-*+]\s+)?Q:\s*(.*)$`)
+	answerLine   = regexp.MustCompile(`^(?:[-*+]\s+)?A:\s*(.*)$`)
+	clozePattern = regexp.MustCompile(`\{\{(?:c(\d+)::)?(.*?)(?:::(.*?))?\}\}`)
+)
+
+// Key identifies a card across sessions by its text, so it keeps its schedule
+// when its note is renamed or moved. Editing a card's text starts it afresh.
+func (c Card) Key() string {
+	sum := sha1.Sum([]byte(strings.TrimSpace(c.Question) + "\x00" + strings.TrimSpace(c.Answer)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ParseCards finds the flashcards in a note. A "Q:" line is answered by the "A:"
+// line after it, which may continue on following lines up to a blank line, a
+// heading, or another card. A line
+// with {{cloze}} deletions makes a card per deletion number (c1, c2, ...); each
+// card hides its deletions, showing "[...]" or the hint after "::", and reveals
+// the others.
+func ParseCards(note *Note) []Card {
+	var cards []Card
+	lines := strings.Split(note.Content, "\n")
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		if m := questionLine.FindStringSubmatch(trimmed); m != nil {
+			card := Card{Note: note, Question: m[1], Line: i + 1}
+			j := i + 1
+			for j < len(lines) && strings.TrimSpace(lines[j]) != "" && !answerLine.MatchString(strings.TrimSpace(lines[j])) {
+				card.Question += "\n" + strings.TrimSpace(lines[j]) // Questions may span lines too
+				j++
+			}
+			if j < len(lines) {
+				if a := answerLine.FindStringSubmatch(strings.TrimSpace(lines[j])); a != nil {
+					answer := []string{a[1]}
+					for j+1 < len(lines) {
+						next := strings.TrimSpace(lines[j+1])
+						if next == "" || questionLine.MatchString(next) || clozePattern.MatchString(next) ||
+							headingLevel(next, headingMarker(note.Format)) > 0 {
+							break
+						}
+						answer = append(answer, next)
+						j++
+					}
+					card.Answer = strings.TrimSpace(strings.Join(answer, "\n"))
+					cards = append(cards, card)
+					i = j
+				}
+			}
+			continue
+		}
+
+		if clozePattern.MatchString(trimmed) {
+			cards = append(cards, clozeCards(note, trimmed, i+1)...)
+		}
+	}
+	return cards
+}
+
+// clozeCards makes a card for each deletion number in a cloze line
+func clozeCards(note *Note, line string, lineNo int) []Card {
+	var numbers []int
+	seen := map[int]bool{}
+	for _, m := range clozePattern.FindAllStringSubmatch(line, -1) {
+		n := clozeNumber(m[1])
+		if !seen[n] {
+			seen[n] = true
+			numbers = append(numbers, n)
+		}
+	}
+	sort.Ints(numbers)
+
+	var cards []Card
+	for _, n := range numbers {
+		question := clozePattern.ReplaceAllStringFunc(line, func(s string) string {
+			m := clozePattern.FindStringSubmatch(s)
+			if clozeNumber(m[1]) != n {
+				return m[2]
+			}
+			if m[3] != "" {
+				return "[" + m[3] + "]"
+			}
+			return "[...]"
+		})
+		answer := clozePattern.ReplaceAllString(line, "$2")
+		cards = append(cards, Card{Note: note, Question: question, Answer: answer, Line: lineNo})
+	}
+	return cards
+}
+
+// clozeNumber reads the number of a cloze deletion, 1 when it has none
+func clozeNumber(s string) int {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	return 1
+}
+
+// Flashcards returns the cards in every note with the tag, in note order
+func (m *Manager) Flashcards(tag string) ([]Card, error) {
+	noteList, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(noteList, func(i, j int) bool { return noteList[i].Created.Before(noteList[j].Created) })
+
+	var cards []Card
+	for _, note := range noteList {
+		if !hasTag(note.Tags, tag) || !WritableFormat(note.Format) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		cards = append(cards, ParseCards(note)...)
+	}
+	return cards, nil
+}
+
+// IsDue checks if a card with the state needs reviewing at now. New cards, with
+// a zero state, are always due.
+func (s CardState) IsDue(now time.Time) bool {
+	return s.Due.IsZero() || !s.Due.After(now)
+}
+
+// Review schedules a card's next review with SM-2 after a review graded quality:
+// 5 perfect, 4 correct after hesitation, 3 correct with difficulty, 2 wrong but
+// easy to recall once seen, 1 wrong, 0 blackout. Grades below 3 start the card's
+// intervals over.
+func (s CardState) Review(quality int, now time.Time) CardState {
+	quality = max(0, min(5, quality))
+	if s.Ease == 0 {
+		s.Ease = 2.5
+	}
+
+	if quality >= 3 {
+		switch s.Reps {
+		case 0:
+			s.Interval = 1
+		case 1:
+			s.Interval = 6
+		default:
+			s.Interval = int(math.Round(float64(s.Interval) * s.Ease))
+		}
+		s.Reps++
+	} else {
+		s.Reps = 0
+		s.Interval = 1
+	}
+
+	q := float64(5 - quality)
+	s.Ease = math.Max(1.3, s.Ease+0.1-q*(0.08+q*0.02))
+	s.Due = startOfDay(now).AddDate(0, 0, s.Interval)
+	return s
+}
+
+// LoadCardStates reads card schedules, keyed by Card.Key, from a JSON file. A
+// missing file yields no states.
+func LoadCardStates(path string) (map[string]CardState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]CardState{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read study state: %w", err)
+	}
+
+	states := map[string]CardState{}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("failed to parse study state: %w", err)
+	}
+	return states, nil
+}
+
+// SaveCardStates writes card schedules to a JSON file
+func SaveCardStates(path string, states map[string]CardState) error {
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save study state: %w", err)
+	}
+	return nil
+}
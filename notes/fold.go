@@ -0,0 +1,67 @@
+package notes
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// SetSearchLocale sets the language whose case rules search uses, as a BCP 47 tag
+// such as "tr" or "de". With "" (the default) case folding is language-independent.
+func (m *Manager) SetSearchLocale(locale string) error {
+	if strings.TrimSpace(locale) == "" {
+		m.searchLocale = language.Und
+		return nil
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return fmt.Errorf("invalid search locale %q: %w", locale, err)
+	}
+	m.searchLocale = tag
+	return nil
+}
+
+// textFolder maps text to a form in which search ignores case, accents, character
+// width, and the difference between hiragana and katakana: "Café" and "CAFE" fold to
+// "cafe", "Straße" to "strasse", and "カタカナ" to "かたかな". Casing follows the locale,
+// so in Turkish "I" folds to "ı" and "İ" to "i".
+// A textFolder keeps transformer state and must not be shared between goroutines.
+type textFolder struct {
+	lower cases.Caser
+	fold  cases.Caser
+	strip transform.Transformer
+}
+
+// newTextFolder returns a folder for the locale
+func newTextFolder(locale language.Tag) *textFolder {
+	return &textFolder{
+		lower: cases.Lower(locale),
+		fold:  cases.Fold(),
+		strip: transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), width.Fold, norm.NFC),
+	}
+}
+
+// Fold returns the search form of s. Casing comes first, so that locale rules
+// such as the Turkish dotted I see the marks they depend on.
+func (f *textFolder) Fold(s string) string {
+	s = f.fold.String(f.lower.String(s))
+	if folded, _, err := transform.String(f.strip, s); err == nil {
+		s = folded
+	}
+	return strings.Map(foldKana, s)
+}
+
+// foldKana maps katakana to the matching hiragana
+func foldKana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - 0x60
+	}
+	return r
+}
@@ -0,0 +1,206 @@
+package notes
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FormatHandler reads and writes one note file format. Handlers are registered
+// with RegisterFormat; the Manager picks one by file extension when loading
+// and by Note.Format when saving.
+type FormatHandler interface {
+	// Extensions returns the file extensions of the format, with the leading dot.
+	// The first one is used for new notes.
+	Extensions() []string
+	// Parse reads a note file. isField reports whether a header key is a
+	// metadata field (or the locked flag) to keep in Parsed.Meta.
+	Parse(content string, isField func(key string) bool) Parsed
+	// Format renders a note, header and body, as file content
+	Format(note *Note) string
+}
+
+// HeaderReader is implemented by formats whose metadata can be read without the body,
+// so lists stay fast. Notes in other formats are always read in full.
+type HeaderReader interface {
+	// ReadHeader returns the part of the file that Parse needs to fill in everything but the body
+	ReadHeader(r io.Reader, isField func(key string) bool) (string, error)
+}
+
+// FileParser is implemented by formats that read a file themselves instead of
+// having its content passed to Parse, e.g. to run an external tool on it
+type FileParser interface {
+	ParseFile(path string, isField func(key string) bool) (Parsed, error)
+}
+
+// ReadOnlyFormat is implemented by formats that burh lists but never writes
+type ReadOnlyFormat interface {
+	ReadOnly() bool
+}
+
+// MarkupFormat is implemented by formats that say which markup their note bodies
+// are written in, so headings, code blocks, links, and records in them are read
+// and written in it. Bodies of other formats are treated as plain text.
+type MarkupFormat interface {
+	// Markup returns PlainMarkup, MarkdownMarkup, or OrgMarkup
+	Markup() string
+}
+
+// The body markups a MarkupFormat can name
+const (
+	PlainMarkup    = "txt"
+	MarkdownMarkup = "md"
+	OrgMarkup      = "org"
+)
+
+// Parsed holds what a FormatHandler reads from a note file
+type Parsed struct {
+	Title   string
+	Content string
+	Tags    []string
+	Meta    map[string]string
+}
+
+// formats holds the registered handlers by format name
+var formats = map[string]FormatHandler{}
+
+func init() {
+	RegisterFormat("txt", textFormat{ext: ".txt", markup: PlainMarkup})
+	RegisterFormat("md", textFormat{ext: ".md", markup: MarkdownMarkup})
+	RegisterFormat("org", orgFormat{})
+}
+
+// RegisterFormat adds a note format, replacing any handler already registered under the name
+func RegisterFormat(name string, handler FormatHandler) {
+	formats[name] = handler
+}
+
+// LookupFormat returns the handler for a format name
+func LookupFormat(name string) (FormatHandler, bool) {
+	handler, ok := formats[name]
+	return handler, ok
+}
+
+// FormatNames returns the names of the formats notes can be written in, sorted
+func FormatNames() []string {
+	var names []string
+	for _, name := range allFormatNames() {
+		if WritableFormat(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// WritableFormat reports whether a format is registered and notes can be written in it
+func WritableFormat(name string) bool {
+	handler, ok := LookupFormat(name)
+	return ok && !isReadOnly(handler)
+}
+
+// allFormatNames returns the names of all registered formats, sorted
+func allFormatNames() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isReadOnly reports whether a handler refuses writes
+func isReadOnly(handler FormatHandler) bool {
+	ro, ok := handler.(ReadOnlyFormat)
+	return ok && ro.ReadOnly()
+}
+
+// formatForFile finds the format of a file from its extension
+func formatForFile(path string) (string, FormatHandler, bool) {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, name := range allFormatNames() {
+		for _, e := range formats[name].Extensions() {
+			if e == ext {
+				return name, formats[name], true
+			}
+		}
+	}
+	return "", nil, false
+}
+
+// markupOf returns the body markup of a format
+func markupOf(format string) string {
+	if mf, ok := handlerFor(format).(MarkupFormat); ok {
+		return mf.Markup()
+	}
+	return PlainMarkup
+}
+
+// isOrg reports whether notes in a format have Org mode bodies
+func isOrg(format string) bool {
+	return markupOf(format) == OrgMarkup
+}
+
+// handlerFor returns the handler for a note's format, falling back to plain text
+func handlerFor(format string) FormatHandler {
+	if handler, ok := LookupFormat(format); ok {
+		return handler
+	}
+	return formats["txt"]
+}
+
+// fileExtension returns the extension new files of a format get
+func fileExtension(format string) string {
+	return handlerFor(format).Extensions()[0]
+}
+
+// parseField parses a "key: value" header line for a field isField accepts
+func parseField(line string, isField func(key string) bool) (key, value string, ok bool) {
+	key, value, found := strings.Cut(line, ":")
+	if !found || !isField(strings.TrimSpace(key)) {
+		return "", "", false
+	}
+	return strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value), true
+}
+
+// scanHeader copies the leading header lines of a file, as judged by isHeader.
+// When bodyLine is set, later lines it accepts are copied too.
+func scanHeader(r io.Reader, isHeader, bodyLine func(trimmed string) bool) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxHeaderLine)
+
+	var sb strings.Builder
+	inHeader := true
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if inHeader {
+			if trimmed == "" || isHeader(trimmed) {
+				sb.WriteString(line + "\n")
+				continue
+			}
+			inHeader = false
+			if bodyLine == nil {
+				break
+			}
+		}
+
+		if bodyLine(trimmed) {
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	return sb.String(), scanner.Err()
+}
+
+// sortedMetaKeys returns metadata keys in a stable order for writing
+func sortedMetaKeys(meta map[string]string) []string {
+	keys := make([]string, 0, len(meta))
+	for key := range meta {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,114 @@
+package notes
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SetSearchMatching makes free-text search looser: with stemming, words match other
+// forms of the same English word ("running" finds "run"), and with fuzzy set to 1 or 2,
+// words match despite up to that many typos. Phrases that appear verbatim always match.
+func (m *Manager) SetSearchMatching(stemming bool, fuzzy int) {
+	m.searchStem = stemming
+	m.searchFuzzy = max(0, min(fuzzy, 2))
+}
+
+// maxEdits returns how many typos a query word may contain: none in words shorter
+// than 4 letters, where a typo makes a different word, and one in words shorter than 8
+func maxEdits(word string, fuzzy int) int {
+	switch n := len([]rune(word)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return min(fuzzy, 1)
+	}
+	return fuzzy
+}
+
+// searchWords splits folded text into words
+func searchWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matchesLoosely reports whether every word of the query text matches a word of
+// the folded note text, by stem or within the allowed number of typos
+func (q *Query) matchesLoosely(text string) bool {
+	vocab := map[string]string{} // Word to its stem
+	for _, word := range searchWords(text) {
+		if _, ok := vocab[word]; !ok {
+			vocab[word] = word
+			if q.Stem {
+				vocab[word] = stem(word)
+			}
+		}
+	}
+
+	for _, want := range searchWords(q.Text) {
+		if _, ok := vocab[want]; ok {
+			continue
+		}
+		wantStem := want
+		if q.Stem {
+			wantStem = stem(want)
+		}
+		edits := maxEdits(want, q.Fuzzy)
+
+		found := false
+		for word, wordStem := range vocab {
+			if wordStem == wantStem || (edits > 0 && (withinEdits(want, word, edits) || withinEdits(wantStem, wordStem, edits))) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// withinEdits reports whether a can be turned into b with at most limit single-character
+// insertions, deletions, substitutions, or swaps of adjacent characters
+func withinEdits(a, b string, limit int) bool {
+	ra, rb := []rune(a), []rune(b)
+	if abs(len(ra)-len(rb)) > limit {
+		return false
+	}
+
+	// Rolling rows of the optimal string alignment distance table
+	prevPrev := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prevPrev[j-2]+1)
+			}
+			best = min(best, cur[j])
+		}
+		if best > limit {
+			return false
+		}
+		prevPrev, prev, cur = prev, cur, prevPrev
+	}
+	return prev[len(rb)] <= limit
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,92 @@
+package notes
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GroupFields lists the supported group-by fields
+var GroupFields = []string{"tag", "month", "dir", "format"}
+
+// untaggedGroup is the group name for notes without tags
+const untaggedGroup = "(untagged)"
+
+// Group is a named set of notes sharing a group-by key
+type Group struct {
+	Name  string
+	Notes []*Note
+}
+
+// GroupKeys returns the groups a note belongs to for the given field.
+// Notes with several tags belong to one group per tag.
+func GroupKeys(note *Note, by string) []string {
+	switch by {
+	case "tag":
+		if len(note.Tags) == 0 {
+			return []string{untaggedGroup}
+		}
+		keys := make([]string, len(note.Tags))
+		for i, tag := range note.Tags {
+			keys[i] = strings.ToLower(tag)
+		}
+		return keys
+	case "month":
+		return []string{note.Created.Format("2006-01")}
+	case "dir":
+		return []string{filepath.Clean(note.Dir)}
+	case "format":
+		return []string{note.Format}
+	}
+	return []string{""}
+}
+
+// GroupNotes splits notes into groups sorted by name, keeping note order within each group
+func GroupNotes(notes []*Note, by string) ([]Group, error) {
+	if !isGroupField(by) {
+		return nil, fmt.Errorf("unknown group-by field %q (use %s)", by, strings.Join(GroupFields, ", "))
+	}
+
+	index := map[string]int{}
+	var groups []Group
+	for _, note := range notes {
+		for _, key := range GroupKeys(note, by) {
+			i, ok := index[key]
+			if !ok {
+				i = len(groups)
+				index[key] = i
+				groups = append(groups, Group{Name: key})
+			}
+			groups[i].Notes = append(groups[i].Notes, note)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups, nil
+}
+
+// isGroupField checks if by is a supported group-by field
+func isGroupField(by string) bool {
+	for _, field := range GroupFields {
+		if field == by {
+			return true
+		}
+	}
+	return false
+}
+
+// TagCounts returns how many of the notes carry each tag, keyed by lowercased tag
+func TagCounts(notes []*Note) map[string]int {
+	counts := map[string]int{}
+	for _, note := range notes {
+		for _, tag := range note.Tags {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				counts[strings.ToLower(tag)]++
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,181 @@
+package notes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HabitsTag marks the note that logs habit completions
+const HabitsTag = "habits"
+
+// HabitsTitle is the title of the habits note created by the first logged completion
+const HabitsTitle = "Habits"
+
+// Habit is a habit and the days it was done, oldest first
+type Habit struct {
+	Name string
+	Done []time.Time // Midnight of each day the habit was done, without repeats
+}
+
+// DoneOn checks if the habit was done on the day of t
+func (h Habit) DoneOn(t time.Time) bool {
+	day := startOfDay(t)
+	i := sort.Search(len(h.Done), func(i int) bool { return !h.Done[i].Before(day) })
+	return i < len(h.Done) && h.Done[i].Equal(day)
+}
+
+// Streak returns the number of days in a row, up to today, the habit was done.
+// A streak that ended yesterday still counts, since today is not over yet.
+func (h Habit) Streak(today time.Time) int {
+	day := startOfDay(today)
+	if !h.DoneOn(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for h.DoneOn(day) {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// LongestStreak returns the most days in a row the habit was ever done
+func (h Habit) LongestStreak() int {
+	longest, run := 0, 0
+	for i, day := range h.Done {
+		if i > 0 && h.Done[i-1].AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// startOfDay returns midnight of the day of t, in the local time zone
+func startOfDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// ParseHabits reads the habits logged in a note: one top-level heading per habit,
+// with a list item starting with the date (YYYY-MM-DD) for each day it was done.
+// Days are sorted and repeats dropped.
+func ParseHabits(note *Note) []Habit {
+	marker := headingMarker(note.Format)
+	var habits []Habit
+	for _, line := range strings.Split(note.Content, "\n") {
+		if level := headingLevel(line, marker); level == 1 {
+			name := headingText(line, level)
+			if isOrg(note.Format) && name == "CONTENT" && len(habits) == 0 {
+				continue // burh's heading over the body of org notes
+			}
+			habits = append(habits, Habit{Name: name})
+			continue
+		}
+		if len(habits) == 0 {
+			continue
+		}
+		item := strings.TrimSpace(line)
+		if !strings.HasPrefix(item, "- ") && !strings.HasPrefix(item, "+ ") {
+			continue
+		}
+		fields := strings.Fields(item[2:])
+		if len(fields) == 0 {
+			continue
+		}
+		if day, err := time.ParseInLocation("2006-01-02", fields[0], time.Local); err == nil {
+			habits[len(habits)-1].Done = append(habits[len(habits)-1].Done, day)
+		}
+	}
+	for i := range habits {
+		habits[i].Done = uniqueDays(habits[i].Done)
+	}
+	return habits
+}
+
+// Habits returns the habits logged in the notes tagged "habits", sorted by name.
+// Headings for the same habit in several places are merged.
+func (m *Manager) Habits() ([]Habit, error) {
+	noteList, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*Habit{}
+	var names []string
+	for _, note := range noteList {
+		if !hasTag(note.Tags, HabitsTag) || !WritableFormat(note.Format) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		for _, habit := range ParseHabits(note) {
+			key := strings.ToLower(habit.Name)
+			if byName[key] == nil {
+				byName[key] = &Habit{Name: habit.Name}
+				names = append(names, key)
+			}
+			byName[key].Done = append(byName[key].Done, habit.Done...)
+		}
+	}
+
+	sort.Strings(names)
+	habits := make([]Habit, 0, len(names))
+	for _, key := range names {
+		habit := byName[key]
+		habit.Done = uniqueDays(habit.Done)
+		habits = append(habits, *habit)
+	}
+	return habits, nil
+}
+
+// uniqueDays sorts days and drops repeats
+func uniqueDays(days []time.Time) []time.Time {
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	var unique []time.Time
+	for _, day := range days {
+		if len(unique) == 0 || !unique[len(unique)-1].Equal(day) {
+			unique = append(unique, day)
+		}
+	}
+	return unique
+}
+
+// LogHabit records a habit as done on a day, under the habit's heading in the
+// habits note. The note is created in the given format if there is none yet, and
+// the heading is added if the habit has not been logged before. Logging a day
+// twice is an error.
+func (m *Manager) LogHabit(name string, day time.Time, format string) (*Note, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("habit name cannot be empty")
+	}
+	entry := "- " + startOfDay(day).Format("2006-01-02")
+
+	noteList, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range noteList {
+		if !hasTag(note.Tags, HabitsTag) || !WritableFormat(note.Format) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		for _, habit := range ParseHabits(note) {
+			if strings.EqualFold(habit.Name, name) && habit.DoneOn(day) {
+				return nil, fmt.Errorf("%s is already logged for %s", habit.Name, day.Format("2006-01-02"))
+			}
+		}
+		return m.InsertUnderHeading(note, name, entry)
+	}
+
+	content := headingMarker(format) + " " + name + "\n" + entry
+	return m.CreateNote(HabitsTitle, content, []string{HabitsTag}, format)
+}
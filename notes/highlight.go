@@ -0,0 +1,246 @@
+package notes
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Kinds of highlighted code tokens
+const (
+	TokenPlain   = "plain"
+	TokenKeyword = "keyword"
+	TokenString  = "string"
+	TokenComment = "comment"
+	TokenNumber  = "number"
+)
+
+// Token is a run of code text of one kind
+type Token struct {
+	Text string
+	Kind string
+}
+
+// syntax is what the highlighter knows of a language
+type syntax struct {
+	keywords     map[string]bool
+	lineComment  []string
+	blockComment [2]string
+	quotes       string // Characters that open strings
+}
+
+// words makes a keyword set from a space-separated list
+func words(list string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.Fields(list) {
+		set[w] = true
+	}
+	return set
+}
+
+var (
+	cLike = syntax{
+		keywords: words(`auto break case char const continue default do double else enum extern float for goto if
+			inline int long register return short signed sizeof static struct switch typedef union unsigned void volatile while
+			bool class delete false namespace new nullptr private protected public template this throw true try catch using virtual
+			abstract boolean byte extends final finally implements import instanceof interface package super synchronized throws null`),
+		lineComment:  []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       `"'`,
+	}
+	shell = syntax{
+		keywords:    words(`if then else elif fi case esac for while until do done in function return local export readonly set unset shift exit echo source`),
+		lineComment: []string{"#"},
+		quotes:      `"'`,
+	}
+
+	syntaxes = map[string]syntax{
+		"go": {
+			keywords: words(`break case chan const continue default defer else fallthrough for func go goto if import
+				interface map package range return select struct switch type var true false nil iota`),
+			lineComment:  []string{"//"},
+			blockComment: [2]string{"/*", "*/"},
+			quotes:       "\"'`",
+		},
+		"python": {
+			keywords: words(`False None True and as assert async await break class continue def del elif else except
+				finally for from global if import in is lambda nonlocal not or pass raise return try while with yield self`),
+			lineComment: []string{"#"},
+			quotes:      `"'`,
+		},
+		"javascript": {
+			keywords: words(`async await break case catch class const continue debugger default delete do else export
+				extends false finally for function if import in instanceof let new null return super switch this throw true try
+				typeof undefined var void while with yield interface type enum implements private public readonly`),
+			lineComment:  []string{"//"},
+			blockComment: [2]string{"/*", "*/"},
+			quotes:       "\"'`",
+		},
+		"rust": {
+			keywords: words(`as async await break const continue crate dyn else enum extern false fn for if impl in let loop
+				match mod move mut pub ref return self Self static struct super trait true type unsafe use where while`),
+			lineComment:  []string{"//"},
+			blockComment: [2]string{"/*", "*/"},
+			quotes:       `"`,
+		},
+		"ruby": {
+			keywords: words(`alias and begin break case class def defined? do else elsif end ensure false for if in module
+				next nil not or redo rescue retry return self super then true undef unless until when while yield require`),
+			lineComment: []string{"#"},
+			quotes:      `"'`,
+		},
+		"sql": {
+			keywords: words(`select from where and or not insert into values update set delete create table drop alter index
+				join left right inner outer on as group by order having limit offset distinct null is in like between union all
+				primary key foreign references default case when then else end count sum avg min max
+				SELECT FROM WHERE AND OR NOT INSERT INTO VALUES UPDATE SET DELETE CREATE TABLE DROP ALTER INDEX JOIN LEFT RIGHT
+				INNER OUTER ON AS GROUP BY ORDER HAVING LIMIT OFFSET DISTINCT NULL IS IN LIKE BETWEEN UNION ALL PRIMARY KEY
+				FOREIGN REFERENCES DEFAULT CASE WHEN THEN ELSE END COUNT SUM AVG MIN MAX`),
+			lineComment:  []string{"--"},
+			blockComment: [2]string{"/*", "*/"},
+			quotes:       `'"`,
+		},
+		"lua": {
+			keywords:    words(`and break do else elseif end false for function goto if in local nil not or repeat return then true until while`),
+			lineComment: []string{"--"},
+			quotes:      `"'`,
+		},
+		"c":     cLike,
+		"shell": shell,
+	}
+
+	// Other names and file extensions of the languages above
+	languageAliases = map[string]string{
+		"golang": "go", "py": "python", "python3": "python", "js": "javascript", "ts": "javascript",
+		"typescript": "javascript", "jsx": "javascript", "tsx": "javascript", "rs": "rust", "rb": "ruby",
+		"cpp": "c", "c++": "c", "h": "c", "hpp": "c", "cc": "c", "java": "c", "cs": "c", "csharp": "c", "kotlin": "c",
+		"kt": "c", "swift": "c", "scala": "c", "sh": "shell", "bash": "shell", "zsh": "shell", "fish": "shell",
+		"postgres": "sql", "mysql": "sql", "sqlite": "sql", "psql": "sql",
+	}
+)
+
+// NormalizeLanguage returns the canonical name of a language, e.g. "go" for
+// "golang", or the name lowercased if it is not one the highlighter knows
+func NormalizeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if canonical, ok := languageAliases[lang]; ok {
+		return canonical
+	}
+	return lang
+}
+
+// Highlight splits code into tokens of keywords, strings, comments, numbers, and
+// plain text for a language. Code in a language the highlighter does not know is
+// one plain token.
+func Highlight(code, lang string) []Token {
+	syn, ok := syntaxes[NormalizeLanguage(lang)]
+	if !ok {
+		return []Token{{Text: code, Kind: TokenPlain}}
+	}
+
+	var tokens []Token
+	emit := func(text, kind string) {
+		if text == "" {
+			return
+		}
+		if n := len(tokens); n > 0 && tokens[n-1].Kind == kind {
+			tokens[n-1].Text += text
+			return
+		}
+		tokens = append(tokens, Token{Text: text, Kind: kind})
+	}
+
+	for i := 0; i < len(code); {
+		rest := code[i:]
+
+		if open := syn.blockComment[0]; open != "" && strings.HasPrefix(rest, open) {
+			end := strings.Index(rest[len(open):], syn.blockComment[1])
+			if end == -1 {
+				end = len(rest)
+			} else {
+				end += len(open) + len(syn.blockComment[1])
+			}
+			emit(rest[:end], TokenComment)
+			i += end
+			continue
+		}
+		if lineComment(rest, syn.lineComment) {
+			end := strings.IndexByte(rest, '\n')
+			if end == -1 {
+				end = len(rest)
+			}
+			emit(rest[:end], TokenComment)
+			i += end
+			continue
+		}
+		if strings.IndexByte(syn.quotes, rest[0]) != -1 {
+			end := stringEnd(rest)
+			emit(rest[:end], TokenString)
+			i += end
+			continue
+		}
+
+		r := rune(rest[0])
+		if unicode.IsLetter(r) || r == '_' {
+			end := 1
+			for end < len(rest) && isWordByte(rest[end]) {
+				end++
+			}
+			if end < len(rest) && rest[end] == '?' && syn.keywords[rest[:end+1]] {
+				end++ // Ruby's defined?
+			}
+			if syn.keywords[rest[:end]] {
+				emit(rest[:end], TokenKeyword)
+			} else {
+				emit(rest[:end], TokenPlain)
+			}
+			i += end
+			continue
+		}
+		if unicode.IsDigit(r) {
+			end := 1
+			for end < len(rest) && (isWordByte(rest[end]) || rest[end] == '.') {
+				end++
+			}
+			emit(rest[:end], TokenNumber)
+			i += end
+			continue
+		}
+
+		emit(rest[:1], TokenPlain)
+		i++
+	}
+	return tokens
+}
+
+// lineComment checks if code starts with one of a language's line comment markers
+func lineComment(code string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.HasPrefix(code, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringEnd returns the length of the string literal code starts with: up to the
+// closing quote, skipping escaped characters, or to the end of the line if it is
+// not closed. Backquoted strings may span lines.
+func stringEnd(code string) int {
+	quote := code[0]
+	for i := 1; i < len(code); i++ {
+		switch {
+		case code[i] == '\\' && quote != '`':
+			i++
+		case code[i] == quote:
+			return i + 1
+		case code[i] == '\n' && quote != '`':
+			return i
+		}
+	}
+	return len(code)
+}
+
+// isWordByte checks if a byte can continue an identifier
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
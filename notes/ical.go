@@ -0,0 +1,85 @@
+package notes
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// ICal renders scheduled items as an iCalendar feed. Items with only a date become
+// all-day events and timed items one-hour events; deadlines are prefixed with "Due: "
+// and finished items with "[done] ".
+func ICal(items []Item, now time.Time) string {
+	var sb strings.Builder
+	line := func(s string) {
+		sb.WriteString(foldICalLine(s))
+		sb.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//burh//notes//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:burh")
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, item := range items {
+		summary := item.Summary
+		if item.Kind == ItemDeadline {
+			summary = "Due: " + summary
+		}
+		if item.Done {
+			summary = "[done] " + summary
+		}
+
+		line("BEGIN:VEVENT")
+		line("UID:" + itemUID(item) + "@burh")
+		line("DTSTAMP:" + stamp)
+		if item.HasTime {
+			line("DTSTART:" + item.Date.Format("20060102T150405"))
+			line("DTEND:" + item.Date.Add(time.Hour).Format("20060102T150405"))
+		} else {
+			line("DTSTART;VALUE=DATE:" + item.Date.Format("20060102"))
+			line("DTEND;VALUE=DATE:" + item.Date.AddDate(0, 0, 1).Format("20060102"))
+		}
+		line("SUMMARY:" + escapeICal(summary))
+		line("DESCRIPTION:" + escapeICal("From note: "+item.Note.Title+" ("+item.Note.ID+")"))
+		line("CATEGORIES:" + strings.ToUpper(item.Kind))
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return sb.String()
+}
+
+// itemUID returns a stable identifier for an item, so calendar apps update rather than duplicate it
+func itemUID(item Item) string {
+	sum := sha1.Sum([]byte(item.Note.ID + "\x00" + item.Kind + "\x00" + item.Summary + "\x00" + item.Date.Format(time.RFC3339)))
+	return hex.EncodeToString(sum[:12])
+}
+
+// escapeICal escapes text property values
+func escapeICal(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// foldICalLine splits content lines longer than 75 octets, continuing them with a space
+func foldICalLine(s string) string {
+	if len(s) <= 75 {
+		return s
+	}
+
+	var sb strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > 75 {
+			sb.WriteString("\r\n ")
+			width = 1
+		}
+		sb.WriteRune(r)
+		width += size
+	}
+	return sb.String()
+}
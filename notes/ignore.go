@@ -0,0 +1,122 @@
+package notes
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the file in a notes directory listing what scans skip
+const IgnoreFileName = ".burhignore"
+
+// ignoreRule is one pattern line of an ignore file
+type ignoreRule struct {
+	segments []string // Pattern split at "/"; "**" matches any number of directories
+	negate   bool     // "!pattern" brings back what an earlier pattern ignored
+	dirOnly  bool     // "pattern/" matches only directories
+	anchored bool     // Patterns with a "/" are matched from the notes directory; others at any depth
+}
+
+// ignoreRules are the patterns of a notes directory's ignore file, in order
+type ignoreRules []ignoreRule
+
+// loadIgnoreRules reads the ignore file of a notes directory. Its lines are
+// gitignore-style patterns, such as "assets/", "*.tmp", "drafts/**/old", or
+// "!keep.md"; blank lines and lines starting with "#" are skipped. A directory
+// without one ignores nothing.
+func loadIgnoreRules(notesDir string) (ignoreRules, error) {
+	f, err := os.Open(filepath.Join(notesDir, IgnoreFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rules ignoreRules
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreRule(scanner.Text()); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, scanner.Err()
+}
+
+// parseIgnoreRule parses a line of an ignore file; false means it holds no pattern
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\`) {
+		line = line[1:] // "\#" and "\!" start patterns with those characters
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	rule.anchored = strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return ignoreRule{}, false
+	}
+	rule.segments = strings.Split(line, "/")
+	return rule, true
+}
+
+// ignored checks if a path, relative to the notes directory and "/"-separated, is
+// ignored. The last matching pattern decides.
+func (rules ignoreRules) ignored(rel string, isDir bool) bool {
+	ignored := false
+	parts := strings.Split(rel, "/")
+	for _, rule := range rules {
+		if rule.matches(parts, isDir) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// matches checks if a rule's pattern matches a path's parts
+func (rule ignoreRule) matches(parts []string, isDir bool) bool {
+	if rule.dirOnly && !isDir {
+		return false
+	}
+	if !rule.anchored {
+		return matchSegments(rule.segments, parts[len(parts)-1:])
+	}
+	return matchSegments(rule.segments, parts)
+}
+
+// matchSegments matches path parts against pattern segments, each a path.Match
+// pattern, with "**" standing for any number of parts
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(pattern[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
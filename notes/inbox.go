@@ -0,0 +1,82 @@
+package notes
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SetInboxTag sets the tag quick captures get until they are refiled. An empty
+// tag turns the inbox off.
+func (m *Manager) SetInboxTag(tag string) {
+	m.inboxTag = strings.TrimSpace(tag)
+}
+
+// WithInboxTag returns tags with the inbox tag added, if there is an inbox
+func (m *Manager) WithInboxTag(tags []string) []string {
+	if m.inboxTag == "" || hasTag(tags, m.inboxTag) {
+		return tags
+	}
+	return append(tags, m.inboxTag)
+}
+
+// InInbox checks if a note is waiting to be refiled
+func (m *Manager) InInbox(note *Note) bool {
+	return m.inboxTag != "" && hasTag(note.Tags, m.inboxTag)
+}
+
+// InboxCount returns how many of the notes are waiting to be refiled
+func (m *Manager) InboxCount(notes []*Note) int {
+	count := 0
+	for _, note := range notes {
+		if m.InInbox(note) {
+			count++
+		}
+	}
+	return count
+}
+
+// RefileTargets returns the directories a note can be refiled to: the notes
+// directories and, when subdirectories are scanned, their subdirectories
+func (m *Manager) RefileTargets() []string {
+	var targets []string
+	for _, dir := range m.notesDirs {
+		targets = append(targets, dir)
+		if !m.recursive {
+			continue
+		}
+		var subdirs []string
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() || path == dir {
+				return nil
+			}
+			if skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			subdirs = append(subdirs, path)
+			return nil
+		})
+		sort.Strings(subdirs)
+		targets = append(targets, subdirs...)
+	}
+	return targets
+}
+
+// RefileNote moves a note to a directory and takes it out of the inbox
+func (m *Manager) RefileNote(note *Note, dir string) error {
+	if err := checkUnlocked(note); err != nil {
+		return err
+	}
+	return m.journaled(OpMove, note, func() error {
+		if filepath.Clean(note.Dir) != filepath.Clean(dir) {
+			if err := m.MoveNote(note, dir); err != nil {
+				return err
+			}
+		}
+		if !m.InInbox(note) {
+			return nil
+		}
+		return m.UpdateTags(note, nil, []string{m.inboxTag})
+	})
+}
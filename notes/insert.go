@@ -0,0 +1,141 @@
+package notes
+
+import (
+	"strings"
+	"time"
+)
+
+// PrependContent adds text to the start of a note's content
+func (m *Manager) PrependContent(note *Note, text string) (*Note, error) {
+	if err := m.LoadContent(note); err != nil {
+		return nil, err
+	}
+
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\\n", "\n"))
+	lines := strings.Split(note.Content, "\n")
+
+	// Keep the generated org heading on top so the file stays well-formed
+	insertAt := 0
+	if isOrg(note.Format) && len(lines) > 0 && strings.TrimSpace(lines[0]) == "* CONTENT" {
+		insertAt = 1
+	}
+
+	note.Content = joinLines(lines[:insertAt], []string{text}, lines[insertAt:])
+	return m.saveUpdated(note)
+}
+
+// AppendContent adds text to the end of a note's content, after a blank line
+func (m *Manager) AppendContent(note *Note, text string) (*Note, error) {
+	if err := m.LoadContent(note); err != nil {
+		return nil, err
+	}
+
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\\n", "\n"))
+	if body := strings.TrimRight(note.Content, "\n"); body != "" {
+		text = body + "\n\n" + text
+	}
+	note.Content = text + "\n"
+	return m.saveUpdated(note)
+}
+
+// InsertUnderHeading appends text to the end of the section under the named heading.
+// Org notes use "*" headings and other formats use markdown "#" headings.
+// The heading is created at the end of the note if it does not exist.
+func (m *Manager) InsertUnderHeading(note *Note, heading, text string) (*Note, error) {
+	if err := m.LoadContent(note); err != nil {
+		return nil, err
+	}
+
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\\n", "\n"))
+	marker := headingMarker(note.Format)
+	lines := strings.Split(note.Content, "\n")
+
+	start, level := findHeading(lines, marker, heading)
+	if start == -1 {
+		var sb strings.Builder
+		sb.WriteString(strings.TrimRight(note.Content, "\n"))
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(marker + " " + heading + "\n")
+		sb.WriteString(text)
+		note.Content = sb.String()
+		return m.saveUpdated(note)
+	}
+
+	// Section ends at the next heading of the same or higher level
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if l := headingLevel(lines[i], marker); l > 0 && l <= level {
+			end = i
+			break
+		}
+	}
+
+	// Insert after the last non-blank line of the section
+	insertAt := end
+	for insertAt > start+1 && strings.TrimSpace(lines[insertAt-1]) == "" {
+		insertAt--
+	}
+
+	note.Content = joinLines(lines[:insertAt], []string{text}, lines[insertAt:])
+	return m.saveUpdated(note)
+}
+
+// saveUpdated stamps the modification time and writes the note back to disk
+func (m *Manager) saveUpdated(note *Note) (*Note, error) {
+	note.Modified = time.Now()
+	if err := m.saveNoteToFile(note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// headingMarker returns the heading character used by a note format
+func headingMarker(format string) string {
+	if isOrg(format) {
+		return "*"
+	}
+	return "#"
+}
+
+// headingLevel returns the level of a heading line, or 0 if the line is not a heading
+func headingLevel(line, marker string) int {
+	level := 0
+	for strings.HasPrefix(line[level:], marker) {
+		level++
+	}
+	if level == 0 || level >= len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+// headingText returns the heading title without markers or trailing org tags
+func headingText(line string, level int) string {
+	text := strings.TrimSpace(line[level:])
+	if i := strings.LastIndex(text, " :"); i != -1 && strings.HasSuffix(text, ":") {
+		text = strings.TrimSpace(text[:i])
+	}
+	return text
+}
+
+// findHeading finds a heading by title (case-insensitive) and returns its line index and level
+func findHeading(lines []string, marker, heading string) (int, int) {
+	for i, line := range lines {
+		level := headingLevel(line, marker)
+		if level > 0 && strings.EqualFold(headingText(line, level), strings.TrimSpace(heading)) {
+			return i, level
+		}
+	}
+	return -1, 0
+}
+
+// joinLines concatenates line slices into a single string
+func joinLines(parts ...[]string) string {
+	var all []string
+	for _, part := range parts {
+		all = append(all, part...)
+	}
+	return strings.Join(all, "\n")
+}
@@ -0,0 +1,349 @@
+package notes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Kinds of journaled operations
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+	OpMove   = "move"
+)
+
+// maxJournalBytes caps the file bytes kept in the journal; the oldest operations
+// are dropped past it
+const maxJournalBytes = 8 << 20
+
+// FileState is a note file as it was on disk: its path, the hash of its bytes,
+// and the bytes themselves when they have to be restored
+type FileState struct {
+	Path string `json:"path"`
+	Hash string `json:"hash,omitempty"`
+	Data []byte `json:"data,omitempty"`
+}
+
+// Operation is a change to a note file, with enough of the file before and after
+// to reverse it. From is nil for a create and To is nil for a delete. Only the
+// state an undo or redo would restore keeps its bytes; the one on disk is
+// checked by its hash.
+type Operation struct {
+	Kind   string     `json:"kind"`
+	NoteID string     `json:"note_id"`
+	Title  string     `json:"title"`
+	Time   time.Time  `json:"time"`
+	From   *FileState `json:"from,omitempty"`
+	To     *FileState `json:"to,omitempty"`
+}
+
+// Journal holds the operations that can be undone, most recent last, and those
+// undone that can be redone, most recently undone last
+type Journal struct {
+	Done   []Operation `json:"done"`
+	Undone []Operation `json:"undone,omitempty"`
+}
+
+// SetJournal turns on recording of note operations in a journal file, keeping
+// the last limit of them. A limit of 0 or an empty path turns it off.
+func (m *Manager) SetJournal(path string, limit int) {
+	if limit <= 0 {
+		path = ""
+	}
+	m.journalPath = path
+	m.journalLimit = limit
+}
+
+// journaled runs op, a change to the note's file, and records it in the journal
+// and the audit log as one operation. Journaled changes op makes in turn are part
+// of it, so a retitle that rewrites and renames the file is undone in one step.
+// Journaled operations run one at a time. Once op has succeeded, failing to
+// record it is only a warning.
+func (m *Manager) journaled(kind string, note *Note, op func() error) error {
+	if (m.journalPath == "" && m.auditPath == "") || m.journalNote.Load() == note {
+		return op()
+	}
+	m.journalMu.Lock()
+	defer m.journalMu.Unlock()
+	m.journalNote.Store(note)
+	defer m.journalNote.Store(nil)
+
+	from, err := readFileState(note.Path())
+	if err != nil {
+		return err
+	}
+	if err := op(); err != nil {
+		return err
+	}
+
+	var to *FileState
+	if kind != OpDelete {
+		if to, err = readFileState(note.Path()); err != nil {
+			m.warnf("'%s' was saved, but the change cannot be undone: %w", note.Title, err)
+			return nil
+		}
+	}
+	if from != nil && to != nil && from.Path == to.Path && from.Hash == to.Hash {
+		return nil // Nothing changed
+	}
+	if from == nil {
+		kind = OpCreate
+	}
+	if err := m.audit(kind, from, to); err != nil {
+		m.warnf("%s of '%s' is missing from the audit log: %w", kind, note.Title, err)
+	}
+	if m.journalPath == "" {
+		return nil
+	}
+	if err := m.record(Operation{Kind: kind, NoteID: note.ID, Title: note.Title, Time: time.Now(), From: from, To: to.withoutData()}); err != nil {
+		m.warnf("%s of '%s' cannot be undone: %w", kind, note.Title, err)
+	}
+	return nil
+}
+
+// JournalEdit runs edit, which changes a note's file outside burh, such as in
+// $EDITOR, and journals the change as an update so it can be undone
+func (m *Manager) JournalEdit(note *Note, edit func() error) error {
+	return m.journaled(OpUpdate, note, edit)
+}
+
+// record adds an operation to the journal, dropping the oldest past the limit
+// or past maxJournalBytes. A new operation can't follow undone ones, so they can
+// no longer be redone.
+func (m *Manager) record(op Operation) error {
+	journal, err := LoadJournal(m.journalPath)
+	if err != nil {
+		return err
+	}
+	journal.Done = append(journal.Done, op)
+	if len(journal.Done) > m.journalLimit {
+		journal.Done = journal.Done[len(journal.Done)-m.journalLimit:]
+	}
+	journal.Undone = nil
+	for size := journal.size(); size > maxJournalBytes && len(journal.Done) > 0; {
+		size -= journal.Done[0].size()
+		journal.Done = journal.Done[1:]
+	}
+	return saveJournal(m.journalPath, journal)
+}
+
+// size returns the file bytes kept for an operation
+func (op Operation) size() int {
+	size := 0
+	for _, state := range []*FileState{op.From, op.To} {
+		if state != nil {
+			size += len(state.Data)
+		}
+	}
+	return size
+}
+
+// size returns the file bytes kept in the journal
+func (j *Journal) size() int {
+	size := 0
+	for _, ops := range [][]Operation{j.Done, j.Undone} {
+		for _, op := range ops {
+			size += op.size()
+		}
+	}
+	return size
+}
+
+// Undo reverts the most recent operation in the journal and returns it. It
+// refuses if the note's file changed since, outside the journal.
+func (m *Manager) Undo() (*Operation, error) {
+	return m.replay(true)
+}
+
+// Redo applies the most recently undone operation again and returns it
+func (m *Manager) Redo() (*Operation, error) {
+	return m.replay(false)
+}
+
+// replay undoes the last done operation or redoes the last undone one, moving it
+// to the other list
+func (m *Manager) replay(undo bool) (*Operation, error) {
+	if m.journalPath == "" {
+		return nil, fmt.Errorf("the undo journal is off (set undo_limit)")
+	}
+	m.journalMu.Lock()
+	defer m.journalMu.Unlock()
+
+	journal, err := LoadJournal(m.journalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	stack, other := &journal.Done, &journal.Undone
+	if !undo {
+		stack, other = other, stack
+	}
+	if len(*stack) == 0 {
+		if undo {
+			return nil, fmt.Errorf("nothing to undo")
+		}
+		return nil, fmt.Errorf("nothing to redo")
+	}
+	op := (*stack)[len(*stack)-1]
+
+	verb, current, wanted := "undo", op.To, op.From
+	if !undo {
+		verb, current, wanted = "redo", op.From, op.To
+	}
+	for _, state := range []*FileState{current, wanted} {
+		if state == nil {
+			continue
+		}
+		if err := m.checkWritable(state.Path); err != nil {
+			return nil, fmt.Errorf("cannot %s %s of '%s': %w", verb, op.Kind, op.Title, err)
+		}
+	}
+	if err := swapFile(current, wanted); err != nil {
+		return nil, fmt.Errorf("cannot %s %s of '%s': %w", verb, op.Kind, op.Title, err)
+	}
+	if err := m.audit(verb+" "+op.Kind, current, wanted); err != nil {
+		m.warnf("%s of '%s' is missing from the audit log: %w", verb, op.Title, err)
+	}
+
+	// The state now on disk only needs its hash, and the one replaced its bytes
+	if undo {
+		op.To, op.From = current, wanted.withoutData()
+	} else {
+		op.From, op.To = current, wanted.withoutData()
+	}
+	*stack = (*stack)[:len(*stack)-1]
+	*other = append(*other, op)
+	if err := saveJournal(m.journalPath, journal); err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// swapFile replaces the file state current, which must still be on disk as it
+// was, with wanted. Either may be nil for no file. current is given the bytes
+// it had on disk.
+func swapFile(current, wanted *FileState) error {
+	if current != nil {
+		onDisk, err := readFileState(current.Path)
+		if err != nil {
+			return err
+		}
+		if onDisk == nil || !current.matches(onDisk) {
+			return fmt.Errorf("%s has changed since", current.Path)
+		}
+		current.Data = onDisk.Data
+	}
+	if wanted != nil && (current == nil || current.Path != wanted.Path) {
+		if _, err := os.Stat(wanted.Path); err == nil {
+			return fmt.Errorf("%s already exists", wanted.Path)
+		}
+	}
+
+	if wanted != nil {
+		if err := os.MkdirAll(filepath.Dir(wanted.Path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(wanted.Path, wanted.Data, 0644); err != nil {
+			return err
+		}
+	}
+	if current != nil && (wanted == nil || current.Path != wanted.Path) {
+		if err := os.Remove(current.Path); err != nil {
+			return err
+		}
+		if wanted != nil {
+			return moveSidecar(current.Path, wanted.Path)
+		}
+		return removeSidecar(current.Path)
+	}
+	return nil
+}
+
+// readFileState reads a file's state, nil if there is no file
+func readFileState(path string) (*FileState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return &FileState{Path: path, Hash: hashData(data), Data: data}, nil
+}
+
+// hashData returns the hex SHA-256 hash of a file's bytes
+func hashData(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// withoutData returns a copy of the state that keeps only its path and hash
+func (s *FileState) withoutData() *FileState {
+	if s == nil {
+		return nil
+	}
+	hash := s.Hash
+	if hash == "" {
+		hash = hashData(s.Data)
+	}
+	return &FileState{Path: s.Path, Hash: hash}
+}
+
+// matches reports whether a file read from disk is in this state
+func (s *FileState) matches(onDisk *FileState) bool {
+	if s.Hash == "" {
+		return bytes.Equal(s.Data, onDisk.Data) // Journaled before states were hashed
+	}
+	return s.Hash == onDisk.Hash
+}
+
+// LoadJournal reads the journal of note operations. A missing file yields an
+// empty journal.
+func LoadJournal(path string) (*Journal, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Journal{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	var journal Journal
+	if err := json.Unmarshal(data, &journal); err != nil {
+		return nil, fmt.Errorf("failed to parse journal: %w", err)
+	}
+	return &journal, nil
+}
+
+// saveJournal writes the journal of note operations
+func saveJournal(path string, journal *Journal) error {
+	data, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save journal: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to save journal: %w", err)
+	}
+	return nil
+}
+
+// Journal returns the journal of note operations, empty when it is off
+func (m *Manager) Journal() (*Journal, error) {
+	if m.journalPath == "" {
+		return &Journal{}, nil
+	}
+	return LoadJournal(m.journalPath)
+}
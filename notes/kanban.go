@@ -0,0 +1,72 @@
+package notes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatusKey is the metadata field holding a note's status, e.g. todo or done
+const StatusKey = "status"
+
+// Board arranges notes into kanban columns, either by the value of their status
+// field or by which of the column tags they carry
+type Board struct {
+	ByTag   bool     // Columns are tags rather than status values
+	Columns []string // Column names, left to right
+}
+
+// Column returns the index of the column a note belongs in, or -1 if it has
+// none of the board's statuses or tags. A note with several column tags is in
+// the leftmost one.
+func (b Board) Column(note *Note) int {
+	for i, column := range b.Columns {
+		if b.ByTag && hasTag(note.Tags, column) {
+			return i
+		}
+		if !b.ByTag && strings.EqualFold(strings.TrimSpace(note.Meta[StatusKey]), column) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Arrange sorts notes into the board's columns, keeping their order. Notes that
+// belong in no column are left out.
+func (b Board) Arrange(notes []*Note) [][]*Note {
+	columns := make([][]*Note, len(b.Columns))
+	for _, note := range notes {
+		if i := b.Column(note); i != -1 {
+			columns[i] = append(columns[i], note)
+		}
+	}
+	return columns
+}
+
+// MoveToColumn puts a note in a column of a board and saves it: its status is set
+// to the column name, or its column tags are replaced by the column's tag
+func (m *Manager) MoveToColumn(note *Note, board Board, column int) error {
+	if column < 0 || column >= len(board.Columns) {
+		return fmt.Errorf("no column %d on the board", column+1)
+	}
+	if err := checkUnlocked(note); err != nil {
+		return err
+	}
+
+	name := board.Columns[column]
+	if board.ByTag {
+		var remove []string
+		for _, tag := range note.Tags {
+			if hasTag(board.Columns, tag) && !strings.EqualFold(tag, name) {
+				remove = append(remove, tag)
+			}
+		}
+		return m.UpdateTags(note, []string{name}, remove)
+	}
+
+	if note.Meta == nil {
+		note.Meta = map[string]string{}
+	}
+	note.Meta[StatusKey] = name
+	_, err := m.saveUpdated(note)
+	return err
+}
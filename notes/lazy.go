@@ -0,0 +1,68 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+)
+
+// maxHeaderLine is the longest line the header scanner accepts before falling back to a full read
+const maxHeaderLine = 1024 * 1024
+
+// LoadContent reads a note's body from disk if only its metadata was loaded
+func (m *Manager) LoadContent(note *Note) error {
+	if note.loaded {
+		return nil
+	}
+
+	full, err := m.loadNoteFromFile(note.Path())
+	if err != nil {
+		return err
+	}
+
+	note.Content = full.Content
+	note.loaded = true
+	return nil
+}
+
+// LoadContents loads the body of every note in the list
+func (m *Manager) LoadContents(notes []*Note) error {
+	for _, note := range notes {
+		if err := m.LoadContent(note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadNoteHeader loads only a note's metadata, leaving Content empty
+func (m *Manager) loadNoteHeader(filePath string) (*Note, error) {
+	header, err := m.readHeader(filePath)
+	if err != nil {
+		return m.loadNoteFromFile(filePath)
+	}
+
+	format, handler := fileFormat(filePath)
+	note := m.buildNote(filePath, format, handler.Parse(header, m.isHeaderField))
+	note.Content = ""
+	return note, nil
+}
+
+// readHeader reads the metadata part of a note file, if its format supports that
+func (m *Manager) readHeader(filePath string) (string, error) {
+	_, handler, ok := formatForFile(filePath)
+	if !ok {
+		return "", fmt.Errorf("unknown note format: %s", filePath)
+	}
+	reader, ok := handler.(HeaderReader)
+	if !ok {
+		return "", fmt.Errorf("format of %s cannot read headers alone", filePath)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	return reader.ReadHeader(f, m.isHeaderField)
+}
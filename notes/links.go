@@ -0,0 +1,54 @@
+package notes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// bareURL matches a web address in text, up to the first space or bracket
+var bareURL = regexp.MustCompile(`https?://[^\s<>()\[\]"]+`)
+
+// LinkURLs turns the bare URLs in text into links titled with what pageTitle
+// returns for them, in the link syntax of a format: [Title](url) in markdown,
+// [[url][Title]] in org, and "Title <url>" in text notes. URLs already in a link
+// and those pageTitle fails for are left as they are.
+func LinkURLs(text, format string, pageTitle func(url string) (string, error)) string {
+	var sb strings.Builder
+	last := 0
+	for _, loc := range bareURL.FindAllStringIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		link := strings.TrimRight(text[start:end], ".,;:!?'")
+		end = start + len(link)
+		if start > 0 && strings.ContainsRune("([<", rune(text[start-1])) {
+			continue // Already part of a link
+		}
+		title, err := pageTitle(link)
+		if err != nil || title == "" {
+			continue
+		}
+		sb.WriteString(text[last:start])
+		sb.WriteString(titledLink(link, title, format))
+		last = end
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// IsBareURL checks if text is nothing but a web address
+func IsBareURL(text string) bool {
+	text = strings.TrimSpace(text)
+	return bareURL.FindString(text) == text && text != ""
+}
+
+// titledLink links a URL with a title in the link syntax of a format
+func titledLink(link, title, format string) string {
+	switch markupOf(format) {
+	case MarkdownMarkup:
+		return fmt.Sprintf("[%s](%s)", strings.NewReplacer("[", "(", "]", ")").Replace(title), link)
+	case OrgMarkup:
+		return fmt.Sprintf("[[%s][%s]]", link, strings.NewReplacer("[", "(", "]", ")").Replace(title))
+	default:
+		return fmt.Sprintf("%s <%s>", title, link)
+	}
+}
@@ -0,0 +1,35 @@
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// lockedKey is the header field that marks a note as locked
+const lockedKey = "locked"
+
+// ErrLocked is returned when updating or deleting a locked note
+var ErrLocked = errors.New("note is locked")
+
+// checkUnlocked returns an error wrapping ErrLocked if the note is locked
+func checkUnlocked(note *Note) error {
+	if note.Locked {
+		return fmt.Errorf("%w: %s (run 'burh unlock %s' first)", ErrLocked, note.ID, note.ID)
+	}
+	return nil
+}
+
+// SetLocked locks or unlocks a note. Locked notes cannot be updated or deleted.
+func (m *Manager) SetLocked(note *Note, locked bool) error {
+	if err := m.checkWritable(note.Path()); err != nil {
+		return err
+	}
+	note.Locked = locked
+	return m.writeNoteFile(note)
+}
+
+// isLockedLine checks if a header line is the locked flag
+func isLockedLine(key string) bool {
+	return strings.EqualFold(strings.TrimSpace(key), lockedKey)
+}
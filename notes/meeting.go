@@ -0,0 +1,110 @@
+package notes
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MeetingTag marks meeting notes
+const MeetingTag = "meeting"
+
+// ActionItem is a follow-up from a meeting note: an open checkbox item or a line
+// assigning work with "@name:"
+type ActionItem struct {
+	Note   *Note
+	Person string // Who it is assigned to, "" if nobody is named
+	Text   string
+	Line   int // 1-based line in the note's content
+}
+
+var (
+	assignedLine   = regexp.MustCompile(`^(?:[-*+]\s+)?(?:\[ \]\s+)?@([\w.-]+):\s*(.*?)\s*$`)
+	openCheckbox   = regexp.MustCompile(`^[-*+]\s+\[ \]\s+(.*?)\s*$`)
+	mentionPattern = regexp.MustCompile(`(?:^|\s)@([\w.-]*\w)`)
+	meetingDate    = regexp.MustCompile(`(?i)^(?:[-*+]\s+)?:?date:?\s+(\d{4}-\d{2}-\d{2})`)
+)
+
+// MeetingTemplate returns the skeleton of a new meeting note in a format, with
+// its date and attendees filled in
+func MeetingTemplate(format string, date time.Time, attendees []string) string {
+	marker := headingMarker(format)
+	var sb strings.Builder
+	sb.WriteString("date: " + date.Format("2006-01-02") + "\n")
+	sb.WriteString("attendees: " + strings.Join(attendees, ", ") + "\n\n")
+	sb.WriteString(marker + " Agenda\n- \n\n")
+	sb.WriteString(marker + " Notes\n\n")
+	sb.WriteString(marker + " Action items\n- [ ] ")
+	return sb.String()
+}
+
+// MeetingDate returns the date a meeting note records on its "date:" line, or the
+// day the note was created. The note's content must be loaded.
+func MeetingDate(note *Note) time.Time {
+	for _, line := range strings.Split(note.Content, "\n") {
+		if m := meetingDate.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			if date, err := time.ParseInLocation("2006-01-02", m[1], time.Local); err == nil {
+				return date
+			}
+		}
+	}
+	return startOfDay(note.Created)
+}
+
+// ParseActionItems finds the open action items in a note: lines starting with
+// "@name:", and unchecked "- [ ]" items, assigned to the first @name they mention.
+// Checked items are done and left out.
+func ParseActionItems(note *Note) []ActionItem {
+	var items []ActionItem
+	for i, line := range strings.Split(note.Content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if m := assignedLine.FindStringSubmatch(trimmed); m != nil {
+			if m[2] != "" {
+				items = append(items, ActionItem{Note: note, Person: m[1], Text: m[2], Line: i + 1})
+			}
+			continue
+		}
+		m := openCheckbox.FindStringSubmatch(trimmed)
+		if m == nil || m[1] == "" {
+			continue
+		}
+		item := ActionItem{Note: note, Text: m[1], Line: i + 1}
+		if mention := mentionPattern.FindStringSubmatch(m[1]); mention != nil {
+			item.Person = mention[1]
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// FollowUps returns the open action items of every meeting note, oldest meeting
+// first
+func (m *Manager) FollowUps() ([]ActionItem, error) {
+	noteList, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	type meeting struct {
+		date  time.Time
+		items []ActionItem
+	}
+	var meetings []meeting
+	for _, note := range noteList {
+		if !hasTag(note.Tags, MeetingTag) || !WritableFormat(note.Format) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		meetings = append(meetings, meeting{MeetingDate(note), ParseActionItems(note)})
+	}
+	sort.SliceStable(meetings, func(i, j int) bool { return meetings[i].date.Before(meetings[j].date) })
+
+	var items []ActionItem
+	for _, mt := range meetings {
+		items = append(items, mt.items...)
+	}
+	return items, nil
+}
@@ -1,11 +1,18 @@
 package notes
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode"
+
+	"golang.org/x/text/language"
 )
 
 // Note represents a single note
@@ -18,11 +25,40 @@ type Note struct {
 	Tags     []string  `json:"tags"`
 	Format   string    `json:"format"` // "org", "txt", or "md"
 	Filename string    `json:"filename"`
+	Dir      string    `json:"dir"`              // Directory the note file lives in
+	Locked   bool      `json:"locked,omitempty"` // Locked notes cannot be updated or deleted
+
+	Meta map[string]string `json:"meta,omitempty"` // User-defined metadata fields
+
+	loaded bool // Whether Content has been read from disk (see LoadContent)
+}
+
+// Path returns the full path to the note file
+func (n *Note) Path() string {
+	return filepath.Join(n.Dir, n.Filename)
 }
 
 // Manager handles note operations
 type Manager struct {
-	notesDirs []string // Changed from notesDir to notesDirs
+	notesDirs    []string                 // Changed from notesDir to notesDirs
+	metaKeys     []string                 // Lowercased names of declared metadata fields
+	dirTimeouts  map[string]time.Duration // Per-directory read timeouts ("" applies to all)
+	recursive    bool                     // Whether subdirectories are scanned
+	staleExclude []string                 // Tags whose notes never count as stale
+	searchLocale language.Tag             // Language of the case rules search uses (see SetSearchLocale)
+	searchStem   bool                     // Whether search matches other forms of a word (see SetSearchMatching)
+	searchFuzzy  int                      // Typos allowed per searched word, 0-2
+	inboxTag     string                   // Tag of notes waiting to be refiled, "" for no inbox
+	journalPath  string                   // File recording operations for undo, "" for none (see SetJournal)
+	journalLimit int                      // Operations kept in the journal
+	journalMu    sync.Mutex               // Held while a journaled operation runs
+	journalNote  atomic.Pointer[Note]     // Note of the running journaled operation, whose nested changes are part of it
+	warn         func(err error)          // Reports problems that don't stop an operation (see SetWarnFunc)
+	auditPath    string                   // File every change to a note is logged to, "" for none (see SetAuditLog)
+	readOnlyDirs []string                 // Notes directories never written to (see SetReadOnlyDirs)
+	syncthing    bool                     // Whether the notes directories are synced by Syncthing (see SetSyncMode)
+	excludeTags  []string                 // Tags whose notes lists and searches leave out (see SetExclusions)
+	excludeDirs  []string                 // Directories whose notes lists and searches leave out
 }
 
 // NewManager creates a new note manager
@@ -52,8 +88,40 @@ func (m *Manager) GetNotesDirs() []string {
 	return m.notesDirs
 }
 
-// CreateNote creates a new note with a unique ID
+// SetMetadataKeys sets the metadata field names recognised when parsing and searching notes
+func (m *Manager) SetMetadataKeys(keys []string) {
+	m.metaKeys = nil
+	for _, key := range keys {
+		m.metaKeys = append(m.metaKeys, strings.ToLower(key))
+	}
+}
+
+// isMetaKey checks if key is a declared metadata field
+func (m *Manager) isMetaKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range m.metaKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// isHeaderField checks if a header key is kept when parsing: a declared metadata field or the locked flag
+func (m *Manager) isHeaderField(key string) bool {
+	return m.isMetaKey(key) || isLockedLine(key)
+}
+
+// CreateNote creates a new note with a unique ID in the first notes directory that
+// isn't read-only
 func (m *Manager) CreateNote(title, content string, tags []string, format string) (*Note, error) {
+	return m.createNote(title, content, tags, format)
+}
+
+// createNote creates a note under an ID no file in the notes directory or in dirs
+// already has. IDs only have second resolution, so a taken ID gets a "_2", "_3", ...
+// suffix rather than overwriting a note.
+func (m *Manager) createNote(title, content string, tags []string, format string, dirs ...string) (*Note, error) {
 	now := time.Now()
 
 	// Generate unique ID: timestamp + sanitized title
@@ -61,12 +129,18 @@ func (m *Manager) CreateNote(title, content string, tags []string, format string
 	id := fmt.Sprintf("%s_%s", now.Format("20060102_150405"), sanitizedTitle)
 
 	// Ensure format is valid
-	if format != "org" && format != "txt" && format != "md" {
+	if !WritableFormat(format) {
 		format = "txt"
 	}
 
+	dir := m.writableDir()
+	if dir == "" {
+		return nil, fmt.Errorf("no notes directory to create the note in: %w", ErrReadOnlyDir)
+	}
+	id = freeID(id, fileExtension(format), append([]string{dir}, dirs...))
+
 	// Create filename
-	filename := fmt.Sprintf("%s.%s", id, format)
+	filename := id + fileExtension(format)
 
 	note := &Note{
 		ID:       id,
@@ -77,10 +151,12 @@ func (m *Manager) CreateNote(title, content string, tags []string, format string
 		Tags:     tags,
 		Format:   format,
 		Filename: filename,
+		Dir:      dir,
+		loaded:   true,
 	}
 
 	// Ensure notes directory exists
-	if err := os.MkdirAll(m.notesDirs[0], 0755); err != nil {
+	if err := os.MkdirAll(note.Dir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create notes directory: %w", err)
 	}
 
@@ -92,21 +168,48 @@ func (m *Manager) CreateNote(title, content string, tags []string, format string
 	return note, nil
 }
 
-// GetNote retrieves a note by ID
-func (m *Manager) GetNote(id string) (*Note, error) {
-	// Find the note file
-	files, err := os.ReadDir(m.notesDirs[0]) // Assuming the first directory is the primary one
-	if err != nil {
-		return nil, fmt.Errorf("failed to read notes directory: %w", err)
+// freeID returns id, or id with the first numeric suffix, for which no file with
+// the extension exists in any of dirs
+func freeID(id, ext string, dirs []string) string {
+	candidate := id
+	for n := 2; fileExistsIn(candidate+ext, dirs); n++ {
+		candidate = fmt.Sprintf("%s_%d", id, n)
 	}
+	return candidate
+}
+
+// fileExistsIn reports whether a file with the name exists in any of dirs
+func fileExistsIn(name string, dirs []string) bool {
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNotFound is returned when no note has the ID asked for
+var ErrNotFound = errors.New("note not found")
+
+// GetNote retrieves a note by ID, looking in every notes directory
+func (m *Manager) GetNote(id string) (*Note, error) {
+	for i, notesDir := range m.notesDirs {
+		paths, err := m.noteFiles(notesDir)
+		if err != nil {
+			if i == 0 {
+				return nil, fmt.Errorf("failed to read notes directory: %w", err)
+			}
+			continue
+		}
 
-	for _, file := range files {
-		if !file.IsDir() && strings.HasPrefix(file.Name(), id) {
-			return m.loadNoteFromFile(filepath.Join(m.notesDirs[0], file.Name()))
+		for _, path := range paths {
+			if strings.HasPrefix(filepath.Base(path), id) {
+				return m.loadNoteFromFile(path)
+			}
 		}
 	}
 
-	return nil, fmt.Errorf("note not found: %s", id)
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
 }
 
 // UpdateNote updates an existing note
@@ -116,6 +219,10 @@ func (m *Manager) UpdateNote(id, title, content string, tags []string) (*Note, e
 		return nil, err
 	}
 
+	if err := checkUnlocked(note); err != nil {
+		return nil, err
+	}
+
 	note.Title = title
 	note.Content = content
 	note.Tags = tags
@@ -128,64 +235,260 @@ func (m *Manager) UpdateNote(id, title, content string, tags []string) (*Note, e
 	return note, nil
 }
 
+// SetMeta sets (or clears, when value is empty) a metadata field on a note
+func (m *Manager) SetMeta(id, key, value string) (*Note, error) {
+	if !m.isMetaKey(key) {
+		return nil, fmt.Errorf("unknown metadata field: %s", key)
+	}
+
+	note, err := m.GetNote(id)
+	if err != nil {
+		return nil, err
+	}
+
+	key = strings.ToLower(key)
+	if value == "" {
+		delete(note.Meta, key)
+	} else {
+		if note.Meta == nil {
+			note.Meta = map[string]string{}
+		}
+		note.Meta[key] = value
+	}
+	note.Modified = time.Now()
+
+	if err := m.saveNoteToFile(note); err != nil {
+		return nil, fmt.Errorf("failed to save updated note: %w", err)
+	}
+
+	return note, nil
+}
+
 // DeleteNote deletes a note by ID
 func (m *Manager) DeleteNote(id string) error {
 	note, err := m.GetNote(id)
 	if err != nil {
 		return err
 	}
+	return m.RemoveNote(note)
+}
+
+// RemoveNote deletes a note that has already been resolved, without looking its
+// ID up again
+func (m *Manager) RemoveNote(note *Note) error {
+	if err := checkUnlocked(note); err != nil {
+		return err
+	}
+	if err := m.checkWritable(note.Path()); err != nil {
+		return err
+	}
 
-	filepath := filepath.Join(m.notesDirs[0], note.Filename)
-	return os.Remove(filepath)
+	return m.journaled(OpDelete, note, func() error {
+		if err := os.Remove(note.Path()); err != nil {
+			return err
+		}
+		if err := removeSidecar(note.Path()); err != nil {
+			return err
+		}
+		return m.bury(note)
+	})
 }
 
-// ListNotes returns all notes
+// ListNotes returns all notes.
+// Only metadata is read; call LoadContent before using a note's Content.
 func (m *Manager) ListNotes() ([]*Note, error) {
+	return m.ListNotesContext(context.Background())
+}
+
+// ListNotesContext returns all notes, giving up when ctx is cancelled. A directory
+// that times out is skipped with a warning, keeping the notes read from it so far.
+func (m *Manager) ListNotesContext(ctx context.Context) ([]*Note, error) {
+	return m.listNotes(ctx, false)
+}
+
+// listNotes loads the notes in every directory, with their content when full is set
+func (m *Manager) listNotes(ctx context.Context, full bool) ([]*Note, error) {
 	var allNotes []*Note
 	for _, notesDir := range m.notesDirs {
-		files, err := os.ReadDir(notesDir)
+		dirCtx, cancel := m.dirContext(ctx, notesDir)
+		notes, err := m.listDir(dirCtx, notesDir, full)
+		cancel()
+		if err != nil && !m.skipTimedOut(ctx, dirCtx, notesDir) {
+			return nil, err
+		}
+		allNotes = append(allNotes, notes...)
+	}
+
+	return allNotes, nil
+}
+
+// listDir loads the notes in a single directory
+func (m *Manager) listDir(ctx context.Context, notesDir string, full bool) ([]*Note, error) {
+	var dirNotes []*Note
+	err := m.walkDir(ctx, notesDir, full, func(note *Note) bool {
+		dirNotes = append(dirNotes, note)
+		return true
+	})
+	return dirNotes, err
+}
+
+// walkDir loads the notes in a single directory and passes each to fn as soon as it is read.
+// Returning false from fn stops the walk. Reading happens in its own goroutine so a hung
+// filesystem cannot block past ctx.
+func (m *Manager) walkDir(ctx context.Context, notesDir string, full bool, fn func(*Note) bool) error {
+	if m.excludedDir(notesDir) {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	load := m.loadNoteHeader
+	if full {
+		load = m.loadNoteFromFile
+	}
+
+	found := make(chan *Note)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(found)
+
+		paths, err := m.noteFiles(notesDir)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read notes directory %s: %w", notesDir, err)
+			errc <- fmt.Errorf("failed to read notes directory %s: %w", notesDir, err)
+			return
 		}
 
-		for _, file := range files {
-			if !file.IsDir() && (strings.HasSuffix(file.Name(), ".org") || strings.HasSuffix(file.Name(), ".txt") || strings.HasSuffix(file.Name(), ".md")) {
-				note, err := m.loadNoteFromFile(filepath.Join(notesDir, file.Name()))
-				if err != nil {
-					continue // Skip files that can't be loaded
+		for _, path := range paths {
+			note, err := load(path)
+			if err != nil {
+				continue // Skip files that can't be loaded
+			}
+			if m.excluded(notesDir, note) {
+				continue
+			}
+			select {
+			case found <- note:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case note, ok := <-found:
+			if !ok {
+				select {
+				case err := <-errc:
+					return err
+				default:
+					return nil
 				}
-				allNotes = append(allNotes, note)
 			}
+			if !fn(note) {
+				return nil
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("failed to read notes directory %s: %w", notesDir, ctx.Err())
 		}
 	}
+}
 
-	return allNotes, nil
+// SetDirTimeouts sets how long reading each notes directory may take.
+// The "" key applies to directories without their own entry; zero means no limit.
+func (m *Manager) SetDirTimeouts(timeouts map[string]time.Duration) {
+	m.dirTimeouts = timeouts
+}
+
+// dirTimeout returns how long reading a directory may take, 0 for no limit
+func (m *Manager) dirTimeout(dir string) time.Duration {
+	timeout, ok := m.dirTimeouts[filepath.Clean(dir)]
+	if !ok {
+		timeout = m.dirTimeouts[""]
+	}
+	return timeout
+}
+
+// dirContext derives a context carrying the configured timeout for a directory
+func (m *Manager) dirContext(ctx context.Context, dir string) (context.Context, context.CancelFunc) {
+	timeout := m.dirTimeout(dir)
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
 }
 
-// SearchNotes searches notes by title, content, or tags
+// skipTimedOut checks if reading a directory failed because its own timeout ran
+// out, rather than ctx being cancelled, and warns that it was cut short. Such a
+// directory is skipped so the other directories are still read.
+func (m *Manager) skipTimedOut(ctx, dirCtx context.Context, dir string) bool {
+	if ctx.Err() != nil || !errors.Is(dirCtx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+	m.warnf("reading notes directory %s took longer than %s; some of its notes are missing", dir, m.dirTimeout(dir))
+	return true
+}
+
+// SearchNotes searches notes by title, content, or tags.
+// The query may also contain filter terms such as tag:x or before:2023-01-01 (see ParseQuery).
 func (m *Manager) SearchNotes(query string) ([]*Note, error) {
-	notes, err := m.ListNotes()
+	return m.SearchNotesContext(context.Background(), query)
+}
+
+// SearchNotesContext is SearchNotes with cancellation
+func (m *Manager) SearchNotesContext(ctx context.Context, query string) ([]*Note, error) {
+	var results []*Note
+	err := m.SearchNotesStream(ctx, query, func(note *Note) bool {
+		results = append(results, note)
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
+	return results, nil
+}
 
-	query = strings.ToLower(query)
-	var results []*Note
+// SearchNotesStream runs a SearchNotes query and calls fn with each match as soon as it
+// is found, so callers can show the first hits before the scan finishes.
+// Returning false from fn stops the search.
+func (m *Manager) SearchNotesStream(ctx context.Context, query string, fn func(*Note) bool) error {
+	q, err := m.ParseQuery(query)
+	if err != nil {
+		return err
+	}
 
-	for _, note := range notes {
-		if strings.Contains(strings.ToLower(note.Title), query) ||
-			strings.Contains(strings.ToLower(note.Content), query) ||
-			containsTag(note.Tags, query) {
-			results = append(results, note)
+	// Free text is matched against the body, so read whole files in one pass
+	stopped := false
+	for _, notesDir := range m.notesDirs {
+		dirCtx, cancel := m.dirContext(ctx, notesDir)
+		err := m.walkDir(dirCtx, notesDir, q.needsContent(), func(note *Note) bool {
+			if q.Matches(note) && !fn(note) {
+				stopped = true
+				return false
+			}
+			return true
+		})
+		cancel()
+		if err != nil && !m.skipTimedOut(ctx, dirCtx, notesDir) {
+			return err
+		}
+		if stopped {
+			break
 		}
 	}
 
-	return results, nil
+	return nil
 }
 
 // SearchByTag searches notes by specific tag
 func (m *Manager) SearchByTag(tag string) ([]*Note, error) {
-	notes, err := m.ListNotes()
+	return m.SearchByTagContext(context.Background(), tag)
+}
+
+// SearchByTagContext is SearchByTag with cancellation
+func (m *Manager) SearchByTagContext(ctx context.Context, tag string) ([]*Note, error) {
+	notes, err := m.ListNotesContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -204,7 +507,12 @@ func (m *Manager) SearchByTag(tag string) ([]*Note, error) {
 
 // SearchByDate searches notes by date (supports various formats)
 func (m *Manager) SearchByDate(dateQuery string) ([]*Note, error) {
-	notes, err := m.ListNotes()
+	return m.SearchByDateContext(context.Background(), dateQuery)
+}
+
+// SearchByDateContext is SearchByDate with cancellation
+func (m *Manager) SearchByDateContext(ctx context.Context, dateQuery string) ([]*Note, error) {
+	notes, err := m.ListNotesContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -259,214 +567,115 @@ func (m *Manager) SearchByDate(dateQuery string) ([]*Note, error) {
 
 // saveNoteToFile saves a note to its file
 func (m *Manager) saveNoteToFile(note *Note) error {
-	filepath := filepath.Join(m.notesDirs[0], note.Filename)
-
-	var content string
-	if note.Format == "org" {
-		content = m.formatOrgNote(note)
-	} else {
-		content = m.formatTxtNote(note)
+	if err := checkUnlocked(note); err != nil {
+		return err
 	}
-
-	return os.WriteFile(filepath, []byte(content), 0644)
+	return m.writeNoteFile(note)
 }
 
-// loadNoteFromFile loads a note from its file
-func (m *Manager) loadNoteFromFile(filePath string) (*Note, error) {
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, err
+// writeNoteFile writes a note to disk, locked or not
+func (m *Manager) writeNoteFile(note *Note) error {
+	if note.Dir == "" {
+		note.Dir = m.writableDir()
 	}
 
-	filename := filepath.Base(filePath)
-	ext := filepath.Ext(filename)
-	id := strings.TrimSuffix(filename, ext)
-
-	// Parse content based on format
-	var title, noteContent string
-	var tags []string
-
-	if ext == ".org" {
-		title, noteContent, tags = m.parseOrgNote(string(content))
-	} else {
-		title, noteContent, tags = m.parseTxtNote(string(content))
+	handler := handlerFor(note.Format)
+	if isReadOnly(handler) {
+		return fmt.Errorf("%s notes cannot be edited", note.Format)
 	}
-
-	// Try to extract creation time from ID
-	var created time.Time
-	if len(id) >= 15 {
-		if t, err := time.Parse("20060102_150405", id[:15]); err == nil {
-			created = t
-		}
+	if err := m.checkWritable(note.Path()); err != nil {
+		return err
 	}
-	if created.IsZero() {
-		created = time.Now()
+
+	// Never overwrite a file with a note whose body was not loaded
+	if err := m.LoadContent(note); err != nil {
+		return err
 	}
 
-	return &Note{
-		ID:       id,
-		Title:    title,
-		Content:  noteContent,
-		Created:  created,
-		Modified: time.Now(),
-		Tags:     tags,
-		Format:   strings.TrimPrefix(ext, "."),
-		Filename: filename,
-	}, nil
+	content := handler.Format(note)
+	return m.journaled(OpUpdate, note, func() error {
+		return os.WriteFile(note.Path(), []byte(content), 0644)
+	})
 }
 
-// formatOrgNote formats a note as Org mode
-func (m *Manager) formatOrgNote(note *Note) string {
-	var sb strings.Builder
-
-	sb.WriteString(fmt.Sprintf("#+TITLE: %s\n", note.Title))
-	sb.WriteString(fmt.Sprintf("#+DATE: %s\n", note.Created.Format("2006-01-02")))
-	sb.WriteString(fmt.Sprintf("#+MODIFIED: %s\n", note.Modified.Format("2006-01-02")))
+// loadNoteFromFile loads a note, including its content, from its file
+func (m *Manager) loadNoteFromFile(filePath string) (*Note, error) {
+	format, handler := fileFormat(filePath)
 
-	if len(note.Tags) > 0 {
-		sb.WriteString(fmt.Sprintf("#+TAGS: %s\n", strings.Join(note.Tags, " ")))
+	var parsed Parsed
+	if parser, ok := handler.(FileParser); ok {
+		var err error
+		if parsed, err = parser.ParseFile(filePath, m.isHeaderField); err != nil {
+			return nil, err
+		}
+	} else {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		parsed = handler.Parse(string(content), m.isHeaderField)
 	}
 
-	sb.WriteString("\n")
-	sb.WriteString("* CONTENT\n")
-	sb.WriteString(strings.ReplaceAll(note.Content, "\\n", "\n"))
-
-	return sb.String()
+	note := m.buildNote(filePath, format, parsed)
+	note.loaded = true
+	return note, nil
 }
 
-// formatTxtNote formats a note as plain text
-func (m *Manager) formatTxtNote(note *Note) string {
-	var sb strings.Builder
-
-	sb.WriteString(fmt.Sprintf("Title: %s\n", note.Title))
-	sb.WriteString(fmt.Sprintf("Created: %s\n", note.Created.Format("2006-01-02 15:04:05")))
-	sb.WriteString(fmt.Sprintf("Modified: %s\n", note.Modified.Format("2006-01-02 15:04:05")))
-
-	if len(note.Tags) > 0 {
-		sb.WriteString(fmt.Sprintf("Tags: %s\n", strings.Join(note.Tags, ", ")))
+// fileFormat returns the format of a note file, falling back to plain text
+func fileFormat(filePath string) (string, FormatHandler) {
+	format, handler, ok := formatForFile(filePath)
+	if !ok {
+		return "txt", handlerFor("txt")
 	}
-
-	sb.WriteString("\n")
-	sb.WriteString(strings.ReplaceAll(note.Content, "\\n", "\n"))
-
-	return sb.String()
+	return format, handler
 }
 
-// parseOrgNote parses an Org mode note
-func (m *Manager) parseOrgNote(content string) (title, noteContent string, tags []string) {
-	lines := strings.Split(content, "\n")
+// buildNote turns what was parsed from a note file into a note
+func (m *Manager) buildNote(filePath, format string, parsed Parsed) *Note {
+	filename := filepath.Base(filePath)
+	ext := filepath.Ext(filename)
+	id := strings.TrimSuffix(filename, ext)
 
-	// Collect tags in a set to avoid duplicates
-	tagSet := map[string]struct{}{}
+	// The locked flag is parsed like a metadata field but kept on the note itself
+	locked := parsed.Meta[lockedKey] == "true"
+	delete(parsed.Meta, lockedKey)
 
-	// Helper to add tags from a directive string
-	addTags := func(tagLine string) {
-		// Org filetags can be in forms like ":tag1:tag2:" or "tag1 tag2"
-		trimmed := strings.TrimSpace(tagLine)
-		if trimmed == "" {
-			return
-		}
-		// Replace colons with spaces to normalize, then split
-		normalized := strings.ReplaceAll(trimmed, ":", " ")
-		for _, t := range strings.Fields(normalized) {
-			if t == "" {
-				continue
-			}
-			t = strings.TrimSpace(t)
-			if t == "" {
-				continue
-			}
-			tagSet[t] = struct{}{}
+	// Try to extract creation time from ID
+	var created time.Time
+	if len(id) >= 15 {
+		if t, err := time.Parse("20060102_150405", id[:15]); err == nil {
+			created = t
 		}
 	}
-
-	// Determine content start and extract metadata
-	contentStart := -1
-	for i, raw := range lines {
-		line := strings.TrimSpace(raw)
-		upper := strings.ToUpper(line)
-
-		if strings.HasPrefix(upper, "#+TITLE:") {
-			// Case-insensitive title directive
-			maybe := strings.TrimSpace(line[len("#+TITLE:"):])
-			if maybe != "" {
-				title = maybe
-			}
-			continue
-		}
-		if strings.HasPrefix(upper, "#+FILETAGS:") {
-			addTags(line[len("#+FILETAGS:"):])
-			continue
-		}
-		if strings.HasPrefix(upper, "#+TAGS:") {
-			addTags(line[len("#+TAGS:"):])
-			continue
-		}
-
-		// Headline tags like: * Heading text :tag1:tag2:
-		if strings.HasPrefix(line, "*") {
-			// Find trailing colon block
-			lastSpace := strings.LastIndex(line, " ")
-			if lastSpace != -1 && lastSpace < len(line)-1 {
-				tagBlock := strings.TrimSpace(line[lastSpace+1:])
-				if strings.HasPrefix(tagBlock, ":") && strings.HasSuffix(tagBlock, ":") {
-					addTags(tagBlock)
-				}
-			}
-		}
-
-		// Determine start of content (first non-directive, non-empty line)
-		if contentStart == -1 {
-			if line == "" {
-				continue
-			}
-			if strings.HasPrefix(strings.TrimSpace(line), "#+") {
-				continue
-			}
-			contentStart = i
-		}
+	// The file's modification time also catches edits made outside burh
+	modified := time.Now()
+	if info, err := os.Stat(filePath); err == nil {
+		modified = info.ModTime()
 	}
-
-	if contentStart != -1 {
-		noteContent = strings.TrimSpace(strings.Join(lines[contentStart:], "\n"))
+	if created.IsZero() {
+		// Files not created by burh, such as PDFs, have no timestamp in their name
+		created = modified
 	}
 
-	// Convert tag set to slice
-	for t := range tagSet {
-		tags = append(tags, t)
+	// Files without a title header are named after their file
+	title := parsed.Title
+	if title == "" {
+		title = titleFromID(id)
 	}
 
-	return title, noteContent, tags
-}
-
-// parseTxtNote parses a plain text note
-func (m *Manager) parseTxtNote(content string) (title, noteContent string, tags []string) {
-	lines := strings.Split(content, "\n")
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, "Title:") {
-			title = strings.TrimSpace(strings.TrimPrefix(line, "Title:"))
-		} else if strings.HasPrefix(line, "Tags:") {
-			tagStr := strings.TrimSpace(strings.TrimPrefix(line, "Tags:"))
-			tags = strings.Split(tagStr, ",")
-			for j, tag := range tags {
-				tags[j] = strings.TrimSpace(tag)
-			}
-		} else if strings.HasPrefix(line, "Created:") || strings.HasPrefix(line, "Modified:") {
-			continue // Skip metadata
-		} else if line == "" {
-			continue // Skip empty lines
-		} else {
-			// Start of content
-			contentStart := strings.Index(content, line)
-			if contentStart != -1 {
-				noteContent = strings.TrimSpace(content[contentStart:])
-			}
-			break
-		}
+	return &Note{
+		ID:       id,
+		Title:    title,
+		Content:  parsed.Content,
+		Created:  created,
+		Modified: modified,
+		Tags:     parsed.Tags,
+		Format:   format,
+		Filename: filename,
+		Dir:      filepath.Dir(filePath),
+		Locked:   locked,
+		Meta:     parsed.Meta,
 	}
-
-	return title, noteContent, tags
 }
 
 // sanitizeTitle creates a filesystem-safe title
@@ -482,6 +691,13 @@ func sanitizeTitle(title string) string {
 	title = strings.ReplaceAll(title, "<", "_")
 	title = strings.ReplaceAll(title, ">", "_")
 	title = strings.ReplaceAll(title, "|", "_")
+	// Control characters aren't allowed in Windows file names
+	title = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, title)
 
 	// Convert to lowercase
 	title = strings.ToLower(title)
@@ -491,9 +707,35 @@ func sanitizeTitle(title string) string {
 		title = title[:50]
 	}
 
+	// Windows drops trailing dots and spaces from file names, and can't open
+	// files named after devices such as con or com1
+	title = strings.TrimRight(title, ". ")
+	if windowsReserved[title] {
+		title += "_"
+	}
+
 	return title
 }
 
+// windowsReserved are the device names Windows doesn't allow as file names
+var windowsReserved = map[string]bool{
+	"con": true, "prn": true, "aux": true, "nul": true,
+	"com1": true, "com2": true, "com3": true, "com4": true, "com5": true,
+	"com6": true, "com7": true, "com8": true, "com9": true,
+	"lpt1": true, "lpt2": true, "lpt3": true, "lpt4": true, "lpt5": true,
+	"lpt6": true, "lpt7": true, "lpt8": true, "lpt9": true,
+}
+
+// titleFromID derives a readable title from a note ID, dropping burh's timestamp prefix
+func titleFromID(id string) string {
+	if len(id) > 16 {
+		if _, err := time.Parse("20060102_150405", id[:15]); err == nil && id[15] == '_' {
+			id = id[16:]
+		}
+	}
+	return strings.TrimSpace(strings.NewReplacer("_", " ", "-", " ").Replace(id))
+}
+
 // containsTag checks if a tag list contains a specific tag
 func containsTag(tags []string, query string) bool {
 	for _, tag := range tags {
@@ -0,0 +1,135 @@
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"burh/bench"
+)
+
+// benchVaultSize is the number of notes in the vault the benchmarks run against
+const benchVaultSize = 1000
+
+// benchManager returns a manager for a freshly generated vault
+func benchManager(b *testing.B) *Manager {
+	b.Helper()
+	dir := b.TempDir()
+	if err := bench.GenerateVault(dir, benchVaultSize, 1); err != nil {
+		b.Fatal(err)
+	}
+	return NewManagerWithDirs([]string{dir})
+}
+
+func BenchmarkListNotes(b *testing.B) {
+	m := benchManager(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		notes, err := m.ListNotes()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(notes) != benchVaultSize {
+			b.Fatalf("listed %d notes, want %d", len(notes), benchVaultSize)
+		}
+	}
+}
+
+func BenchmarkSearchNotes(b *testing.B) {
+	m := benchManager(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := m.SearchNotes("project meeting"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSearchByTag(b *testing.B) {
+	m := benchManager(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := m.SearchByTag("work"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSanitizeTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Meeting Notes", "meeting_notes"},
+		{`a/b\c:d*e?f"g<h>i|j`, "a_b_c_d_e_f_g_h_i_j"},
+		{"tab\there", "tab_here"},
+		{"Version 2.", "version_2"},
+		{"trailing...", "trailing"},
+		{"CON", "con_"},
+		{"nul", "nul_"},
+		{"Com1", "com1_"},
+		{"LPT9", "lpt9_"},
+		{"console", "console"},
+		{"com10", "com10"},
+		{"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz", "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwx"},
+		{"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvw. tail", "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvw"},
+	}
+	for _, tt := range tests {
+		if got := sanitizeTitle(tt.title); got != tt.want {
+			t.Errorf("sanitizeTitle(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestParseTextHeader(t *testing.T) {
+	isField := func(key string) bool {
+		switch strings.ToLower(key) {
+		case "status", "language", "expires":
+			return true
+		}
+		return false
+	}
+	tests := []struct {
+		name      string
+		content   string
+		wantMeta  map[string]string
+		wantBody  string
+		wantTitle string
+	}{
+		{"header field", "Title: A\nStatus: done\n\nbody", map[string]string{"status": "done"}, "body", "A"},
+		{"field leading the body", "Title: A\n\nStatus: blocked on review\nmore", nil, "Status: blocked on review\nmore", "A"},
+		{"title in the body", "Title: A\n\nTitle: B", nil, "Title: B", "A"},
+		{"no blank line", "Title: A\nbody\nStatus: x", nil, "body\nStatus: x", "A"},
+	}
+	for _, tt := range tests {
+		parsed := textFormat{}.Parse(tt.content, isField)
+		if parsed.Title != tt.wantTitle || parsed.Content != tt.wantBody || !reflect.DeepEqual(parsed.Meta, tt.wantMeta) {
+			t.Errorf("%s: Parse = %q, %q, %v; want %q, %q, %v", tt.name,
+				parsed.Title, parsed.Content, parsed.Meta, tt.wantTitle, tt.wantBody, tt.wantMeta)
+		}
+	}
+}
+
+func TestFreeID(t *testing.T) {
+	dir, target := t.TempDir(), t.TempDir()
+	for _, path := range []string{filepath.Join(dir, "a.md"), filepath.Join(target, "a_2.md")} {
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		id, ext string
+		want    string
+	}{
+		{"b", ".md", "b"},
+		{"a", ".txt", "a"},
+		{"a", ".md", "a_3"},
+	}
+	for _, tt := range tests {
+		if got := freeID(tt.id, tt.ext, []string{dir, target}); got != tt.want {
+			t.Errorf("freeID(%q, %q) = %q, want %q", tt.id, tt.ext, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ocrSuffix is appended to an image's file name to name the sidecar file holding its OCR text.
+// Sidecars are not note files, so they are never listed themselves.
+const ocrSuffix = ".ocr"
+
+// ImageExtensions are the binary note types that OCR applies to
+var ImageExtensions = []string{".png", ".jpg", ".jpeg", ".gif", ".webp"}
+
+// IsImage reports whether a note is an image file
+func IsImage(note *Note) bool {
+	ext := strings.ToLower(filepath.Ext(note.Filename))
+	for _, e := range ImageExtensions {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// OCRNote runs an OCR command on an image note and stores its output in a sidecar
+// file, which search then reads as the note's content. Images whose sidecar is newer
+// than the image are skipped unless force is set; the result reports whether OCR ran.
+func (m *Manager) OCRNote(note *Note, command string, force bool) (bool, error) {
+	if !IsImage(note) {
+		return false, fmt.Errorf("%s is not an image", note.ID)
+	}
+	if !force {
+		if _, ok := readSidecar(note.Path()); ok {
+			return false, nil
+		}
+	}
+
+	if err := m.checkWritable(note.Path()); err != nil {
+		return false, err
+	}
+	text, err := runExtractor(command, note.Path())
+	if err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(sidecarPath(note.Path()), []byte(text+"\n"), 0644); err != nil {
+		return false, fmt.Errorf("failed to write OCR text: %w", err)
+	}
+
+	note.Content = text
+	note.loaded = true
+	return true, nil
+}
+
+// sidecarPath returns the path of the OCR sidecar file for a file
+func sidecarPath(path string) string {
+	return path + ocrSuffix
+}
+
+// readSidecar returns the OCR text stored for a file, if its sidecar is up to date
+func readSidecar(path string) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false
+	}
+	sidecar := sidecarPath(path)
+	sideInfo, err := os.Stat(sidecar)
+	if err != nil || sideInfo.ModTime().Before(info.ModTime()) {
+		return "", false
+	}
+
+	data, err := os.ReadFile(sidecar)
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(data)), true
+}
+
+// moveSidecar moves a file's OCR sidecar, if it has one, along with the file
+func moveSidecar(from, to string) error {
+	if _, err := os.Stat(sidecarPath(from)); err != nil {
+		return nil
+	}
+	return os.Rename(sidecarPath(from), sidecarPath(to))
+}
+
+// removeSidecar deletes a file's OCR sidecar, if it has one
+func removeSidecar(path string) error {
+	if err := os.Remove(sidecarPath(path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package notes
+
+import (
+	"sort"
+	"time"
+)
+
+// OnThisDay returns the notes created on the month and day of day in earlier
+// years, most recent year first. On February 28 of a year without a leap day,
+// notes from February 29 are included.
+func OnThisDay(noteList []*Note, day time.Time) []*Note {
+	day = day.In(time.Local)
+	leapDay := day.Month() == time.February && day.Day() == 28 &&
+		time.Date(day.Year(), time.February, 29, 0, 0, 0, 0, time.Local).Month() != time.February
+
+	var matches []*Note
+	for _, note := range noteList {
+		created := note.Created.In(time.Local)
+		if created.Year() >= day.Year() || created.Month() != day.Month() {
+			continue
+		}
+		if created.Day() == day.Day() || (leapDay && created.Day() == 29) {
+			matches = append(matches, note)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Created.After(matches[j].Created) })
+	return matches
+}
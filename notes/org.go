@@ -0,0 +1,157 @@
+package notes
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// orgFormat handles Org mode notes: #+KEY: value headers and a "* CONTENT" heading over the body
+type orgFormat struct{}
+
+// Extensions returns the Org mode file extension
+func (orgFormat) Extensions() []string {
+	return []string{".org"}
+}
+
+// Markup returns the markup of Org mode bodies
+func (orgFormat) Markup() string {
+	return OrgMarkup
+}
+
+// Format renders a note as Org mode
+func (orgFormat) Format(note *Note) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("#+TITLE: %s\n", note.Title))
+	sb.WriteString(fmt.Sprintf("#+DATE: %s\n", note.Created.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("#+MODIFIED: %s\n", note.Modified.Format("2006-01-02")))
+
+	if len(note.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("#+TAGS: %s\n", strings.Join(note.Tags, " ")))
+	}
+
+	if note.Locked {
+		sb.WriteString("#+LOCKED: true\n")
+	}
+
+	for _, key := range sortedMetaKeys(note.Meta) {
+		sb.WriteString(fmt.Sprintf("#+%s: %s\n", strings.ToUpper(key), note.Meta[key]))
+	}
+
+	sb.WriteString("\n")
+	// Content read back from a note file already starts with the heading
+	if !strings.HasPrefix(note.Content, "* CONTENT") {
+		sb.WriteString("* CONTENT\n")
+	}
+	sb.WriteString(strings.ReplaceAll(note.Content, "\\n", "\n"))
+
+	return sb.String()
+}
+
+// Parse reads an Org mode note
+func (orgFormat) Parse(content string, isField func(key string) bool) Parsed {
+	var title, noteContent string
+	var tags []string
+	var meta map[string]string
+
+	lines := strings.Split(content, "\n")
+
+	// Collect tags in the order they appear, with a set to avoid duplicates, so a
+	// note's first tag (which it is grouped under) is the same on every read
+	tagSet := map[string]struct{}{}
+
+	// Helper to add tags from a directive string
+	addTags := func(tagLine string) {
+		// Org filetags can be in forms like ":tag1:tag2:" or "tag1 tag2"
+		trimmed := strings.TrimSpace(tagLine)
+		if trimmed == "" {
+			return
+		}
+		// Replace colons with spaces to normalize, then split
+		normalized := strings.ReplaceAll(trimmed, ":", " ")
+		for _, t := range strings.Fields(normalized) {
+			if t == "" {
+				continue
+			}
+			t = strings.TrimSpace(t)
+			if t == "" {
+				continue
+			}
+			if _, ok := tagSet[t]; !ok {
+				tagSet[t] = struct{}{}
+				tags = append(tags, t)
+			}
+		}
+	}
+
+	// Determine content start and extract metadata
+	contentStart := -1
+	for i, raw := range lines {
+		line := strings.TrimSpace(raw)
+		upper := strings.ToUpper(line)
+
+		if strings.HasPrefix(upper, "#+TITLE:") {
+			// Case-insensitive title directive
+			maybe := strings.TrimSpace(line[len("#+TITLE:"):])
+			if maybe != "" {
+				title = maybe
+			}
+			continue
+		}
+		if strings.HasPrefix(upper, "#+FILETAGS:") {
+			addTags(line[len("#+FILETAGS:"):])
+			continue
+		}
+		if strings.HasPrefix(upper, "#+TAGS:") {
+			addTags(line[len("#+TAGS:"):])
+			continue
+		}
+		if contentStart == -1 && strings.HasPrefix(line, "#+") {
+			if key, value, ok := parseField(line[2:], isField); ok {
+				if meta == nil {
+					meta = map[string]string{}
+				}
+				meta[key] = value
+				continue
+			}
+		}
+
+		// Headline tags like: * Heading text :tag1:tag2:
+		if strings.HasPrefix(line, "*") {
+			// Find trailing colon block
+			lastSpace := strings.LastIndex(line, " ")
+			if lastSpace != -1 && lastSpace < len(line)-1 {
+				tagBlock := strings.TrimSpace(line[lastSpace+1:])
+				if strings.HasPrefix(tagBlock, ":") && strings.HasSuffix(tagBlock, ":") {
+					addTags(tagBlock)
+				}
+			}
+		}
+
+		// Determine start of content (first non-directive, non-empty line)
+		if contentStart == -1 {
+			if line == "" {
+				continue
+			}
+			if strings.HasPrefix(strings.TrimSpace(line), "#+") {
+				continue
+			}
+			contentStart = i
+		}
+	}
+
+	if contentStart != -1 {
+		noteContent = strings.TrimSpace(strings.Join(lines[contentStart:], "\n"))
+	}
+
+	return Parsed{Title: title, Content: noteContent, Tags: tags, Meta: meta}
+}
+
+// ReadHeader reads the #+ directives at the top of the file.
+// Org headline tags can appear anywhere, so the rest of the file is scanned for headlines only.
+func (orgFormat) ReadHeader(r io.Reader, isField func(key string) bool) (string, error) {
+	return scanHeader(r,
+		func(line string) bool { return strings.HasPrefix(line, "#+") },
+		func(line string) bool { return strings.HasPrefix(line, "*") })
+}
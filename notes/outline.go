@@ -0,0 +1,89 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Heading is one entry of a note's outline
+type Heading struct {
+	Level int
+	Text  string
+	Line  int // 1-based line number in the text the outline was read from
+}
+
+// Outline returns the headings of a text in order: "*" headings in org and "#"
+// headings otherwise, skipping fenced code blocks and the "* CONTENT" heading burh
+// writes at the top of org notes
+func Outline(text, format string) []Heading {
+	marker := headingMarker(format)
+	org := isOrg(format)
+	var headings []Heading
+	inFence := false
+
+	for i, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !org && strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if org && strings.HasPrefix(strings.ToUpper(trimmed), "#+BEGIN_") {
+			inFence = true
+			continue
+		}
+		if org && strings.HasPrefix(strings.ToUpper(trimmed), "#+END_") {
+			inFence = false
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		level := headingLevel(line, marker)
+		if level == 0 {
+			continue
+		}
+		title := headingText(line, level)
+		if org && level == 1 && title == "CONTENT" && len(headings) == 0 {
+			continue
+		}
+		headings = append(headings, Heading{Level: level, Text: title, Line: i + 1})
+	}
+	return headings
+}
+
+// NoteOutline returns a note's outline with line numbers of the note file, for
+// opening an editor at a heading
+func (m *Manager) NoteOutline(note *Note) ([]Heading, error) {
+	data, err := os.ReadFile(note.Path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read note: %w", err)
+	}
+	return Outline(string(data), note.Format), nil
+}
+
+// FindHeading picks a heading by its 1-based position in the outline or by its
+// text (case-insensitive, exact before prefix)
+func FindHeading(headings []Heading, ref string) (Heading, error) {
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 || n > len(headings) {
+			return Heading{}, fmt.Errorf("heading %d out of range (1-%d)", n, len(headings))
+		}
+		return headings[n-1], nil
+	}
+
+	ref = strings.TrimSpace(ref)
+	for _, h := range headings {
+		if strings.EqualFold(h.Text, ref) {
+			return h, nil
+		}
+	}
+	for _, h := range headings {
+		if strings.HasPrefix(strings.ToLower(h.Text), strings.ToLower(ref)) {
+			return h, nil
+		}
+	}
+	return Heading{}, fmt.Errorf("no heading matching %q", ref)
+}
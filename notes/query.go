@@ -0,0 +1,255 @@
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Query is a parsed note selector.
+// Supported terms are tag:x, format:x, id:x (part of the ID; a leading date may be
+// written 2024-05), before:YYYY-MM-DD, after:YYYY-MM-DD, stale:PERIOD (e.g.
+// stale:1y, not modified within the period), key:value for declared metadata
+// fields, and free text matched against title, content, tags, and ID. A term
+// prefixed with "-" excludes the notes it matches, as in -tag:archive or -draft.
+// Free text ignores case, accents, and character width (see textFolder); with Stem
+// or Fuzzy set, a note also matches when each word of it matches loosely.
+type Query struct {
+	Text   string
+	Tags   []string
+	Format string
+	ID     string // Part of the note's ID, in the ID's own form
+	Before time.Time
+	After  time.Time
+	Meta   map[string]string
+	Not    []*Query // Terms a note must not match
+
+	StaleBefore  time.Time // Only notes last modified before this time
+	StaleExclude []string  // Tags that keep a note from counting as stale
+
+	Stem  bool // Match other forms of the words in Text
+	Fuzzy int  // Typos allowed per word of Text
+
+	locale language.Tag // Case rules for matching Text
+}
+
+// ErrInvalidQuery is returned for a query with a malformed filter term
+var ErrInvalidQuery = errors.New("invalid query")
+
+// queryKeys are the filters a query takes as key:value terms, besides metadata fields
+var queryKeys = []string{"tag", "format", "id", "before", "after", "stale"}
+
+// IsQueryKey reports whether key:value terms with this key filter a query, rather
+// than being text to search for, as a URL is. A leading - (not) is ignored.
+func (m *Manager) IsQueryKey(key string) bool {
+	key = strings.ToLower(strings.TrimPrefix(key, "-"))
+	for _, k := range queryKeys {
+		if k == key {
+			return true
+		}
+	}
+	return m.isMetaKey(key)
+}
+
+// ParseQuery parses a query string into a Query
+func (m *Manager) ParseQuery(query string) (*Query, error) {
+	q := &Query{Meta: map[string]string{}, Stem: m.searchStem, Fuzzy: m.searchFuzzy, locale: m.searchLocale}
+	var text []string
+
+	for _, term := range strings.Fields(query) {
+		if len(term) > 1 && term[0] == '-' {
+			not, err := m.ParseQuery(term[1:])
+			if err != nil {
+				return nil, err
+			}
+			q.Not = append(q.Not, not)
+			continue
+		}
+
+		key, value, found := strings.Cut(term, ":")
+		if !found || value == "" {
+			text = append(text, term)
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "tag":
+			q.Tags = append(q.Tags, strings.ToLower(value))
+		case "format":
+			q.Format = strings.ToLower(value)
+		case "id":
+			q.ID = idTerm(value)
+		case "before":
+			t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid date in %s (expected YYYY-MM-DD)", ErrInvalidQuery, term)
+			}
+			q.Before = t
+		case "after":
+			t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid date in %s (expected YYYY-MM-DD)", ErrInvalidQuery, term)
+			}
+			// after: includes notes created on that day
+			q.After = t
+		case "stale":
+			cutoff, err := AgeCutoff(value, time.Now())
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid period in %s (expected e.g. 30d, 6w, 3m, or 1y)", ErrInvalidQuery, term)
+			}
+			q.StaleBefore = cutoff
+			q.StaleExclude = m.staleExclude
+		default:
+			if m.isMetaKey(key) {
+				q.Meta[strings.ToLower(key)] = value
+				continue
+			}
+			text = append(text, term)
+		}
+	}
+
+	q.Text = newTextFolder(q.locale).Fold(strings.Join(text, " "))
+	return q, nil
+}
+
+// idDate matches a date at the start of an id: term, written with dashes as in
+// 2024-05 or 2024-05-01, optionally followed by a time as in 2024-05-01T14:30
+var idDate = regexp.MustCompile(`^(\d{4})-(\d{2})(?:-(\d{2})(?:t(\d{2})(?::(\d{2})(?::(\d{2}))?)?)?)?`)
+
+// idTerm turns the value of an id: term into the form dates and times take in
+// IDs, so id:2024-05-01T14:30 finds IDs starting 20240501_1430
+func idTerm(value string) string {
+	value = strings.ToLower(value)
+	m := idDate.FindStringSubmatch(value)
+	if m == nil {
+		return value
+	}
+	id := m[1] + m[2] + m[3]
+	if m[4] != "" {
+		id += "_" + m[4] + m[5] + m[6]
+	}
+	return id + value[len(m[0]):]
+}
+
+// needsContent checks if matching the query reads note content, for free text
+// it includes or excludes
+func (q *Query) needsContent() bool {
+	if q.Text != "" {
+		return true
+	}
+	for _, not := range q.Not {
+		if not.needsContent() {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks if a note satisfies every term of the query
+func (q *Query) Matches(note *Note) bool {
+	for _, not := range q.Not {
+		if not.Matches(note) {
+			return false
+		}
+	}
+	for _, tag := range q.Tags {
+		if !hasTag(note.Tags, tag) {
+			return false
+		}
+	}
+	if q.Format != "" && note.Format != q.Format {
+		return false
+	}
+	if q.ID != "" && !strings.Contains(strings.ToLower(note.ID), q.ID) {
+		return false
+	}
+	if !q.Before.IsZero() && !note.Created.Before(q.Before) {
+		return false
+	}
+	if !q.After.IsZero() && note.Created.Before(q.After) {
+		return false
+	}
+	if !q.StaleBefore.IsZero() && !IsStale(note, q.StaleBefore, q.StaleExclude) {
+		return false
+	}
+	for key, value := range q.Meta {
+		if !strings.EqualFold(note.Meta[key], value) {
+			return false
+		}
+	}
+	if q.Text == "" {
+		return true
+	}
+
+	folder := newTextFolder(q.locale)
+	title, content := folder.Fold(note.Title), folder.Fold(note.Content)
+	if strings.Contains(title, q.Text) || strings.Contains(content, q.Text) || strings.Contains(folder.Fold(note.ID), q.Text) {
+		return true
+	}
+	tags := make([]string, len(note.Tags))
+	for i, tag := range note.Tags {
+		tags[i] = folder.Fold(tag)
+		if strings.Contains(tags[i], q.Text) {
+			return true
+		}
+	}
+	if q.Stem || q.Fuzzy > 0 {
+		return q.matchesLoosely(title + "\n" + content + "\n" + strings.Join(tags, " "))
+	}
+	return false
+}
+
+// hasTag checks if a tag list contains exactly the given tag (case-insensitive)
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchLine returns the 1-based line of a note file where a query's free text first
+// matches: the first line holding the whole text, or failing that the first line
+// with one of its words. It returns 0 if the query has no free text or no line matches,
+// for instance when only the title or tags matched.
+func (m *Manager) MatchLine(note *Note, query string) (int, error) {
+	q, err := m.ParseQuery(query)
+	if err != nil {
+		return 0, err
+	}
+	if q.Text == "" {
+		return 0, nil
+	}
+	data, err := os.ReadFile(note.Path())
+	if err != nil {
+		return 0, fmt.Errorf("failed to read note: %w", err)
+	}
+
+	folder := newTextFolder(q.locale)
+	words := searchWords(q.Text)
+	wordMatch := 0
+	for i, line := range strings.Split(string(data), "\n") {
+		line = folder.Fold(line)
+		if strings.Contains(line, q.Text) {
+			return i + 1, nil
+		}
+		if wordMatch != 0 {
+			continue
+		}
+		for _, word := range words {
+			single := *q
+			single.Text = word
+			if strings.Contains(line, word) || ((q.Stem || q.Fuzzy > 0) && single.matchesLoosely(line)) {
+				wordMatch = i + 1
+				break
+			}
+		}
+	}
+	return wordMatch, nil
+}
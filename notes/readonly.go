@@ -0,0 +1,56 @@
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"burh/paths"
+)
+
+// ErrReadOnlyDir is returned when writing to a notes directory configured as read-only
+var ErrReadOnlyDir = errors.New("notes directory is read-only")
+
+// SetReadOnlyDirs sets the notes directories that are listed and searched but never
+// written to, such as a mounted team share. Their subdirectories are read-only too.
+func (m *Manager) SetReadOnlyDirs(dirs []string) {
+	m.readOnlyDirs = nil
+	for _, dir := range dirs {
+		m.readOnlyDirs = append(m.readOnlyDirs, filepath.Clean(dir))
+	}
+}
+
+// IsReadOnlyDir checks if a directory is, or is inside, a read-only notes directory
+func (m *Manager) IsReadOnlyDir(dir string) bool {
+	return m.readOnlyRoot(dir) != ""
+}
+
+// readOnlyRoot returns the read-only notes directory that dir is or is inside, or ""
+func (m *Manager) readOnlyRoot(dir string) string {
+	for _, root := range m.readOnlyDirs {
+		if paths.Within(dir, root) {
+			return root
+		}
+	}
+	return ""
+}
+
+// writableDir returns the first notes directory that isn't read-only, where new
+// notes go, or "" if every one is
+func (m *Manager) writableDir() string {
+	for _, dir := range m.notesDirs {
+		if !m.IsReadOnlyDir(dir) {
+			return dir
+		}
+	}
+	return ""
+}
+
+// checkWritable returns an error wrapping ErrReadOnlyDir if the file at path is in
+// a read-only notes directory
+func (m *Manager) checkWritable(path string) error {
+	if root := m.readOnlyRoot(filepath.Dir(path)); root != "" {
+		return fmt.Errorf("%w: %s", ErrReadOnlyDir, root)
+	}
+	return nil
+}
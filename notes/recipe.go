@@ -0,0 +1,268 @@
+package notes
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RecipeTag marks recipe notes
+const RecipeTag = "recipe"
+
+// Ingredient is one line of a recipe's ingredient list, with its quantity split
+// off when it starts with one
+type Ingredient struct {
+	Text     string  // The line as written, without its list marker
+	Amount   float64 // Quantity, 0 when the line has none
+	AmountTo float64 // Upper end of a range such as "2-3", 0 otherwise
+	Rest     string  // Text after the quantity, e.g. "cups flour"
+	fraction bool    // Whether the quantity was written as a fraction
+}
+
+// Recipe is a recipe note's ingredients and the number of servings it makes
+type Recipe struct {
+	Note        *Note
+	Servings    int // 0 when the recipe does not say
+	Ingredients []Ingredient
+}
+
+var (
+	// A number: "2", "1.5", "1,5", "1/2", "1 1/2", "½", or "1½"
+	quantityPattern = `(?:\d+\s+\d+/\d+|\d+/\d+|\d+\s*[½⅓⅔¼¾⅛]|[½⅓⅔¼¾⅛]|\d+(?:[.,]\d+)?)`
+	quantityPrefix  = regexp.MustCompile(`^(` + quantityPattern + `)(?:\s*(?:-|–|to)\s*(` + quantityPattern + `))?(.*)$`)
+	servingsLine    = regexp.MustCompile(`(?i)^(?:[-*+]\s+)?:?(?:servings|serves|yield):?\s+(\d+)`)
+
+	unicodeFractions = map[rune]float64{'½': 1.0 / 2, '⅓': 1.0 / 3, '⅔': 2.0 / 3, '¼': 1.0 / 4, '¾': 3.0 / 4, '⅛': 1.0 / 8}
+)
+
+// RecipeTemplate returns the skeleton of a new recipe note in a format
+func RecipeTemplate(format string, servings int) string {
+	marker := headingMarker(format)
+	if markupOf(format) == PlainMarkup {
+		marker = "##"
+	}
+	var sb strings.Builder
+	if servings > 0 {
+		sb.WriteString(fmt.Sprintf("servings: %d\n\n", servings))
+	}
+	sb.WriteString(marker + " Ingredients\n- \n\n")
+	sb.WriteString(marker + " Steps\n1. ")
+	return sb.String()
+}
+
+// ParseRecipe reads the servings line and the list under the "Ingredients" heading
+// of a recipe note. The note's content must be loaded.
+func ParseRecipe(note *Note) Recipe {
+	recipe := Recipe{Note: note}
+	lines := strings.Split(note.Content, "\n")
+	marker := headingMarker(note.Format)
+
+	for _, line := range lines {
+		if m := servingsLine.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			recipe.Servings, _ = strconv.Atoi(m[1])
+			break
+		}
+	}
+
+	start, level := findHeading(lines, marker, "Ingredients")
+	if start == -1 {
+		return recipe
+	}
+	for _, line := range lines[start+1:] {
+		if l := headingLevel(line, marker); l > 0 && l <= level {
+			break
+		}
+		item := strings.TrimSpace(line)
+		if len(item) < 2 || !strings.ContainsAny(item[:1], "-*+") || item[1] != ' ' {
+			continue
+		}
+		item = strings.TrimPrefix(strings.TrimSpace(item[2:]), "[ ] ")
+		if item != "" {
+			recipe.Ingredients = append(recipe.Ingredients, ParseIngredient(item))
+		}
+	}
+	return recipe
+}
+
+// ParseIngredient splits the quantity off the start of an ingredient line
+func ParseIngredient(text string) Ingredient {
+	ing := Ingredient{Text: text, Rest: text}
+	m := quantityPrefix.FindStringSubmatch(text)
+	if m == nil {
+		return ing
+	}
+	// "2x4 lumber" or "3rd" are not quantities
+	if m[3] != "" && !strings.HasPrefix(m[3], " ") && !strings.HasPrefix(m[3], ",") && !isUnitStart(m[3]) {
+		return ing
+	}
+	amount, ok := parseQuantity(m[1])
+	if !ok {
+		return ing
+	}
+	ing.Amount = amount
+	ing.fraction = strings.ContainsAny(m[1], "/½⅓⅔¼¾⅛")
+	if m[2] != "" {
+		ing.AmountTo, _ = parseQuantity(m[2])
+	}
+	ing.Rest = m[3]
+	return ing
+}
+
+// isUnitStart checks if text right after a number is a unit written without a
+// space, as in "200g" or "2tbsp"
+func isUnitStart(text string) bool {
+	for _, unit := range []string{"g", "kg", "mg", "ml", "l", "cl", "dl", "oz", "lb", "tsp", "tbsp", "cup"} {
+		if strings.HasPrefix(strings.ToLower(text), unit) {
+			rest := text[len(unit):]
+			return rest == "" || rest[0] == ' ' || rest[0] == 's' || rest[0] == '.' || rest[0] == ','
+		}
+	}
+	return false
+}
+
+// parseQuantity reads a number written as an integer, decimal, fraction, mixed
+// number, or unicode fraction
+func parseQuantity(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	total := 0.0
+	for r, v := range unicodeFractions {
+		if strings.HasSuffix(s, string(r)) {
+			total += v
+			s = strings.TrimSpace(strings.TrimSuffix(s, string(r)))
+			if s == "" {
+				return total, true
+			}
+		}
+	}
+
+	whole, frac, mixed := strings.Cut(s, " ")
+	if !mixed {
+		frac = ""
+		if strings.Contains(whole, "/") {
+			whole, frac = "", whole
+		}
+	}
+	if whole != "" {
+		n, err := strconv.ParseFloat(strings.Replace(whole, ",", ".", 1), 64)
+		if err != nil {
+			return 0, false
+		}
+		total += n
+	}
+	if frac != "" {
+		num, den, ok := strings.Cut(strings.TrimSpace(frac), "/")
+		n, err1 := strconv.Atoi(num)
+		d, err2 := strconv.Atoi(den)
+		if !ok || err1 != nil || err2 != nil || d == 0 {
+			return 0, false
+		}
+		total += float64(n) / float64(d)
+	}
+	return total, true
+}
+
+// Scale returns the ingredient line with its quantity multiplied by factor.
+// Lines without a quantity, like "salt to taste", are returned as they are.
+func (ing Ingredient) Scale(factor float64) string {
+	if ing.Amount == 0 {
+		return ing.Text
+	}
+	text := FormatQuantity(ing.Amount*factor, ing.fraction)
+	if ing.AmountTo != 0 {
+		text += "-" + FormatQuantity(ing.AmountTo*factor, ing.fraction)
+	}
+	return text + ing.Rest
+}
+
+// FormatQuantity writes a quantity for a recipe: whole numbers as they are, a
+// mixed number with a common fraction (halves, thirds, quarters, eighths) when
+// fractions are preferred and one is close, else up to two decimals
+func FormatQuantity(q float64, preferFraction bool) string {
+	whole := math.Floor(q)
+	rest := q - whole
+	fractions := []struct {
+		value float64
+		text  string
+	}{{0, ""}, {1.0 / 8, "1/8"}, {1.0 / 4, "1/4"}, {1.0 / 3, "1/3"}, {1.0 / 2, "1/2"}, {2.0 / 3, "2/3"}, {3.0 / 4, "3/4"}, {1, ""}}
+	for _, f := range fractions {
+		if math.Abs(rest-f.value) > 0.01 {
+			continue
+		}
+		if f.value == 1 {
+			whole++
+		}
+		if f.text == "" {
+			return strconv.FormatFloat(whole, 'f', -1, 64)
+		}
+		if !preferFraction {
+			break
+		}
+		if whole == 0 {
+			return f.text
+		}
+		return fmt.Sprintf("%.0f %s", whole, f.text)
+	}
+	return strconv.FormatFloat(math.Round(q*100)/100, 'f', -1, 64)
+}
+
+// ParseScale reads a scaling factor such as "2x", "x2", "2", "0.5x", or "1/2"
+func ParseScale(s string) (float64, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "x"), "x")
+	if s == "" {
+		return 0, fmt.Errorf("empty scale")
+	}
+	factor, ok := parseQuantity(s)
+	if !ok || factor <= 0 {
+		return 0, fmt.Errorf("invalid scale %q (expected e.g. 2x, 0.5x, or 1/2)", s)
+	}
+	return factor, nil
+}
+
+// Missing returns the ingredients of the recipe not covered by anything on hand.
+// An item covers an ingredient when the ingredient mentions it, ignoring case and
+// a plural "s", so "egg" covers "3 large eggs".
+func (r Recipe) Missing(onHand []string) []Ingredient {
+	var missing []Ingredient
+	for _, ing := range r.Ingredients {
+		text := strings.ToLower(ing.Rest)
+		covered := false
+		for _, item := range onHand {
+			item = strings.ToLower(strings.TrimSpace(item))
+			if len(item) > 1 && (strings.Contains(text, item) || strings.Contains(text, strings.TrimSuffix(item, "s"))) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			missing = append(missing, ing)
+		}
+	}
+	return missing
+}
+
+// Recipes returns every recipe note also tagged tag, or all of them when tag is
+// empty, with their content loaded and parsed
+func (m *Manager) Recipes(tag string) ([]Recipe, error) {
+	noteList, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	var recipes []Recipe
+	for _, note := range noteList {
+		if !hasTag(note.Tags, RecipeTag) || !WritableFormat(note.Format) {
+			continue
+		}
+		if tag != "" && !hasTag(note.Tags, tag) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, ParseRecipe(note))
+	}
+	return recipes, nil
+}
@@ -0,0 +1,83 @@
+package notes
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Record is one entry of a structured note, such as a contact: a heading naming
+// the record followed by "key: value" lines (or an org property drawer)
+type Record struct {
+	Note   *Note
+	Name   string
+	Fields map[string]string // Keyed by lowercase field name
+}
+
+var (
+	mdHeadingLine  = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*$`)
+	orgPropertyRow = regexp.MustCompile(`^:([\w-]+):\s*(.*?)\s*$`)
+	recordFieldRow = regexp.MustCompile(`^(?:[-*+]\s+)?([\w][\w -]*?):\s+(.*?)\s*$`)
+)
+
+// ParseRecords reads the records in a note's content. Each heading starts a record;
+// lines under it like "birthday: 1990-06-12" (or ":BIRTHDAY: 1990-06-12" in org
+// property drawers) are its fields. Headings without fields are skipped.
+func ParseRecords(note *Note) []Record {
+	var records []Record
+	var current *Record
+
+	for _, line := range strings.Split(note.Content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		var heading []string
+		if isOrg(note.Format) {
+			heading = orgHeadingLine.FindStringSubmatch(line)
+			if heading != nil {
+				heading = []string{heading[0], heading[3]}
+			}
+		} else {
+			heading = mdHeadingLine.FindStringSubmatch(trimmed)
+		}
+		if heading != nil {
+			if current != nil && len(current.Fields) > 0 {
+				records = append(records, *current)
+			}
+			current = &Record{Note: note, Name: heading[1], Fields: map[string]string{}}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		if m := orgPropertyRow.FindStringSubmatch(trimmed); m != nil {
+			key := strings.ToLower(m[1])
+			if key != "properties" && key != "end" {
+				current.Fields[key] = m[2]
+			}
+			continue
+		}
+		if m := recordFieldRow.FindStringSubmatch(trimmed); m != nil {
+			current.Fields[strings.ToLower(m[1])] = m[2]
+		}
+	}
+	if current != nil && len(current.Fields) > 0 {
+		records = append(records, *current)
+	}
+	return records
+}
+
+// RecordsWithTag returns the records of every note with the tag, sorted by name.
+// The notes' content must be loaded.
+func RecordsWithTag(notes []*Note, tag string) []Record {
+	var records []Record
+	for _, note := range notes {
+		if hasTag(note.Tags, tag) {
+			records = append(records, ParseRecords(note)...)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return strings.ToLower(records[i].Name) < strings.ToLower(records[j].Name)
+	})
+	return records
+}
@@ -0,0 +1,380 @@
+package notes
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RenderTargets lists the formats a note can be rendered to for pasting into other tools
+var RenderTargets = []string{"plain", "html", "slack", "jira"}
+
+var (
+	renderHeading = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	renderTask    = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	renderBullet  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	renderOrdered = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	renderQuote   = regexp.MustCompile(`^>\s?(.*)$`)
+
+	inlineLink   = regexp.MustCompile(`\[([^\]]+)\]\(((?:[^()\s]|\([^()\s]*\))+)\)`) // URLs may hold balanced parentheses
+	inlineBold   = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	inlineStrike = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	inlineStar   = regexp.MustCompile(`\*(\S(?:[^*]*?\S)?)\*`)
+	inlineUnder  = regexp.MustCompile(`(^|\W)_(\S(?:[^_]*?\S)?)_(\W|$)`)
+)
+
+// Markers standing in for inline styles while a line is rewritten
+const (
+	boldOpen, boldClose     = "\x01", "\x02"
+	strikeOpen, strikeClose = "\x03", "\x04"
+	italOpen, italClose     = "\x05", "\x06"
+	linkMark                = "\x07"
+)
+
+// renderer holds the markup of one render target
+type renderer struct {
+	html   bool
+	escape func(string) string
+	bold   [2]string
+	italic [2]string
+	strike [2]string
+	code   [2]string
+	link   func(text, url string) string
+
+	heading   func(level int, text string) string
+	bullet    func(depth int, text string) string
+	ordered   func(depth, n int, text string) string
+	task      func(depth int, done bool, text string) string
+	quote     func(text string) string
+	codeBlock func(lang string, lines []string) string
+}
+
+// RenderNote renders a note's title and content for pasting into another tool:
+// "plain" text, sanitized "html", Slack mrkdwn ("slack"), or Jira wiki markup ("jira").
+// Org notes are read as their Markdown conversion; plain text notes as Markdown.
+func RenderNote(note *Note, target string) (string, error) {
+	return renderNote(note, target, true)
+}
+
+// RenderContent is RenderNote without the title
+func RenderContent(note *Note, target string) (string, error) {
+	return renderNote(note, target, false)
+}
+
+// renderNote renders a note's content, with its title as a heading if withTitle is set
+func renderNote(note *Note, target string, withTitle bool) (string, error) {
+	r, ok := renderers[target]
+	if !ok {
+		return "", fmt.Errorf("unknown target %q (use %s)", target, strings.Join(RenderTargets, ", "))
+	}
+
+	content := convertContent(note.Content, markupOf(note.Format), MarkdownMarkup)
+	if withTitle {
+		content = "# " + note.Title + "\n\n" + content
+	}
+	return r.render(content), nil
+}
+
+// render converts Markdown text line by line
+func (r renderer) render(content string) string {
+	var out, para, code []string
+	var lists []string // Open HTML list tags, innermost last
+	inCode, lang := false, ""
+
+	flushPara := func() {
+		if len(para) == 0 {
+			return
+		}
+		if r.html {
+			out = append(out, "<p>"+strings.Join(para, "<br>\n")+"</p>")
+		} else {
+			out = append(out, para...)
+		}
+		para = nil
+	}
+	// setLists opens or closes HTML lists so depth lists are open, the innermost of kind
+	setLists := func(depth int, kind string) {
+		if !r.html {
+			return
+		}
+		for len(lists) > depth || (len(lists) == depth && depth > 0 && kind != "" && lists[depth-1] != kind) {
+			out = append(out, "</"+lists[len(lists)-1]+">")
+			lists = lists[:len(lists)-1]
+		}
+		for len(lists) < depth {
+			out = append(out, "<"+kind+">")
+			lists = append(lists, kind)
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if inCode {
+			if strings.HasPrefix(trimmed, "```") {
+				out = append(out, r.codeBlock(lang, code))
+				inCode, code = false, nil
+				continue
+			}
+			code = append(code, line)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") {
+			flushPara()
+			setLists(0, "")
+			inCode, lang = true, strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+			continue
+		}
+
+		if m := renderTask.FindStringSubmatch(line); m != nil {
+			flushPara()
+			depth := listDepth(m[1])
+			setLists(depth+1, "ul")
+			out = append(out, r.task(depth, m[2] != " ", r.inline(m[3])))
+			continue
+		}
+		if m := renderBullet.FindStringSubmatch(line); m != nil {
+			flushPara()
+			depth := listDepth(m[1])
+			setLists(depth+1, "ul")
+			out = append(out, r.bullet(depth, r.inline(m[2])))
+			continue
+		}
+		if m := renderOrdered.FindStringSubmatch(line); m != nil {
+			flushPara()
+			depth := listDepth(m[1])
+			setLists(depth+1, "ol")
+			n, _ := strconv.Atoi(m[2])
+			out = append(out, r.ordered(depth, n, r.inline(m[3])))
+			continue
+		}
+
+		setLists(0, "")
+		switch m := renderHeading.FindStringSubmatch(trimmed); {
+		case m != nil:
+			flushPara()
+			out = append(out, r.heading(len(m[1]), r.inline(m[2])))
+		case trimmed == "":
+			flushPara()
+			if !r.html {
+				out = append(out, "")
+			}
+		case renderQuote.MatchString(trimmed):
+			flushPara()
+			out = append(out, r.quote(r.inline(renderQuote.FindStringSubmatch(trimmed)[1])))
+		default:
+			para = append(para, r.inline(trimmed))
+		}
+	}
+
+	if inCode {
+		out = append(out, r.codeBlock(lang, code))
+	}
+	flushPara()
+	setLists(0, "")
+
+	return strings.TrimSpace(collapseBlankLines(strings.Join(out, "\n"))) + "\n"
+}
+
+// listDepth returns the nesting level of a list item from its indentation
+func listDepth(indent string) int {
+	return len(strings.ReplaceAll(indent, "\t", "  ")) / 2
+}
+
+// inline rewrites code spans, links, and emphasis in one line of text
+func (r renderer) inline(text string) string {
+	parts := strings.Split(text, "`")
+	var sb strings.Builder
+	for i, part := range parts {
+		switch {
+		case i%2 == 1 && i < len(parts)-1:
+			sb.WriteString(r.code[0] + r.escape(part) + r.code[1])
+		case i%2 == 1:
+			sb.WriteString(r.escape("`") + r.emphasis(part)) // Unmatched backtick
+		default:
+			sb.WriteString(r.emphasis(part))
+		}
+	}
+	return sb.String()
+}
+
+// emphasis rewrites links and bold, italic, and struck-through text
+func (r renderer) emphasis(text string) string {
+	text = r.escape(text)
+
+	// Links are set aside first so their URLs are not taken for emphasis
+	var links []string
+	text = inlineLink.ReplaceAllStringFunc(text, func(match string) string {
+		m := inlineLink.FindStringSubmatch(match)
+		links = append(links, r.link(r.styles(m[1]), m[2]))
+		return linkMark + strconv.Itoa(len(links)-1) + linkMark
+	})
+
+	text = r.styles(text)
+	for i, link := range links {
+		text = strings.Replace(text, linkMark+strconv.Itoa(i)+linkMark, link, 1)
+	}
+	return text
+}
+
+// styles rewrites bold, italic, and struck-through text
+func (r renderer) styles(text string) string {
+	text = inlineBold.ReplaceAllStringFunc(text, func(match string) string {
+		m := inlineBold.FindStringSubmatch(match)
+		return boldOpen + m[1] + m[2] + boldClose
+	})
+	text = inlineStrike.ReplaceAllString(text, strikeOpen+"$1"+strikeClose)
+	text = inlineStar.ReplaceAllString(text, italOpen+"$1"+italClose)
+	text = inlineUnder.ReplaceAllString(text, "${1}"+italOpen+"${2}"+italClose+"${3}")
+
+	return strings.NewReplacer(
+		boldOpen, r.bold[0], boldClose, r.bold[1],
+		strikeOpen, r.strike[0], strikeClose, r.strike[1],
+		italOpen, r.italic[0], italClose, r.italic[1],
+	).Replace(text)
+}
+
+// collapseBlankLines squeezes runs of blank lines into one
+func collapseBlankLines(text string) string {
+	for strings.Contains(text, "\n\n\n") {
+		text = strings.ReplaceAll(text, "\n\n\n", "\n\n")
+	}
+	return text
+}
+
+// safeURL reports whether a link target may be used in HTML, Slack, or Jira output
+func safeURL(url string) bool {
+	lower := strings.ToLower(url)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "mailto:")
+}
+
+// noEscape leaves text as it is, for targets without special characters
+func noEscape(s string) string { return s }
+
+// renderers holds the supported render targets
+var renderers = map[string]renderer{
+	"plain": {
+		escape: noEscape,
+		link: func(text, url string) string {
+			if text == url {
+				return url
+			}
+			return text + " (" + url + ")"
+		},
+		heading: func(level int, text string) string { return text },
+		bullet:  func(depth int, text string) string { return strings.Repeat("  ", depth) + "- " + text },
+		ordered: func(depth, n int, text string) string {
+			return fmt.Sprintf("%s%d. %s", strings.Repeat("  ", depth), n, text)
+		},
+		task: func(depth int, done bool, text string) string {
+			box := "[ ]"
+			if done {
+				box = "[x]"
+			}
+			return strings.Repeat("  ", depth) + box + " " + text
+		},
+		quote: func(text string) string { return "> " + text },
+		codeBlock: func(lang string, lines []string) string {
+			for i, line := range lines {
+				lines[i] = "    " + line
+			}
+			return strings.Join(lines, "\n")
+		},
+	},
+	"html": {
+		html:   true,
+		escape: html.EscapeString,
+		bold:   [2]string{"<strong>", "</strong>"},
+		italic: [2]string{"<em>", "</em>"},
+		strike: [2]string{"<del>", "</del>"},
+		code:   [2]string{"<code>", "</code>"},
+		link: func(text, url string) string {
+			if !safeURL(html.UnescapeString(url)) {
+				return text
+			}
+			return `<a href="` + url + `">` + text + "</a>"
+		},
+		heading: func(level int, text string) string { return fmt.Sprintf("<h%d>%s</h%d>", level, text, level) },
+		bullet:  func(depth int, text string) string { return "<li>" + text + "</li>" },
+		ordered: func(depth, n int, text string) string { return "<li>" + text + "</li>" },
+		task: func(depth int, done bool, text string) string {
+			box := "☐"
+			if done {
+				box = "☑"
+			}
+			return "<li>" + box + " " + text + "</li>"
+		},
+		quote: func(text string) string { return "<blockquote>" + text + "</blockquote>" },
+		codeBlock: func(lang string, lines []string) string {
+			return "<pre><code>" + html.EscapeString(strings.Join(lines, "\n")) + "</code></pre>"
+		},
+	},
+	"slack": {
+		escape: func(s string) string {
+			return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+		},
+		bold:   [2]string{"*", "*"},
+		italic: [2]string{"_", "_"},
+		strike: [2]string{"~", "~"},
+		code:   [2]string{"`", "`"},
+		link: func(text, url string) string {
+			if !safeURL(html.UnescapeString(url)) {
+				return text
+			}
+			return "<" + url + "|" + text + ">"
+		},
+		heading: func(level int, text string) string {
+			return "*" + text + "*"
+		},
+		bullet: func(depth int, text string) string { return strings.Repeat("    ", depth) + "• " + text },
+		ordered: func(depth, n int, text string) string {
+			return fmt.Sprintf("%s%d. %s", strings.Repeat("    ", depth), n, text)
+		},
+		task: func(depth int, done bool, text string) string {
+			box := "☐"
+			if done {
+				box = "☑"
+			}
+			return strings.Repeat("    ", depth) + box + " " + text
+		},
+		quote: func(text string) string { return "> " + text },
+		codeBlock: func(lang string, lines []string) string {
+			return "```\n" + strings.Join(lines, "\n") + "\n```"
+		},
+	},
+	"jira": {
+		escape: noEscape,
+		bold:   [2]string{"*", "*"},
+		italic: [2]string{"_", "_"},
+		strike: [2]string{"-", "-"},
+		code:   [2]string{"{{", "}}"},
+		link: func(text, url string) string {
+			if !safeURL(url) {
+				return text
+			}
+			return "[" + text + "|" + url + "]"
+		},
+		heading: func(level int, text string) string {
+			return fmt.Sprintf("h%d. %s", level, text)
+		},
+		bullet:  func(depth int, text string) string { return strings.Repeat("*", depth+1) + " " + text },
+		ordered: func(depth, n int, text string) string { return strings.Repeat("#", depth+1) + " " + text },
+		task: func(depth int, done bool, text string) string {
+			box := "☐"
+			if done {
+				box = "☑"
+			}
+			return strings.Repeat("*", depth+1) + " " + box + " " + text
+		},
+		quote: func(text string) string { return "bq. " + text },
+		codeBlock: func(lang string, lines []string) string {
+			open := "{code}"
+			if lang != "" {
+				open = "{code:" + lang + "}"
+			}
+			return open + "\n" + strings.Join(lines, "\n") + "\n{code}"
+		},
+	},
+}
@@ -0,0 +1,134 @@
+package notes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Replacement is a compiled find-and-replace: a literal string or a regular expression,
+// and the text to put in its place ($1 and ${name} expand groups in regex mode)
+type Replacement struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// NewReplacement compiles a find-and-replace. A literal search replaces with the
+// literal text; a regex search may refer to capture groups in the replacement.
+func NewReplacement(find, replace string, regex, ignoreCase bool) (*Replacement, error) {
+	if find == "" {
+		return nil, fmt.Errorf("nothing to search for")
+	}
+	expr := find
+	if !regex {
+		expr = regexp.QuoteMeta(find)
+		replace = strings.ReplaceAll(replace, "$", "$$")
+	}
+	if ignoreCase {
+		expr = "(?i)" + expr
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return &Replacement{pattern: pattern, replacement: replace}, nil
+}
+
+// Match is one occurrence of a replacement's pattern in a note's content
+type Match struct {
+	Start, End int    // Byte offsets of the match in the content
+	New        string // Text the match is replaced with
+	Line       int    // 1-based line of the content the match starts on
+}
+
+// Find returns the occurrences of the pattern in content, in order
+func (r *Replacement) Find(content string) []Match {
+	var matches []Match
+	for _, loc := range r.pattern.FindAllStringSubmatchIndex(content, -1) {
+		if loc[0] == loc[1] {
+			continue // Empty matches would insert text between every character
+		}
+		replaced := r.pattern.ExpandString(nil, r.replacement, content, loc)
+		matches = append(matches, Match{
+			Start: loc[0],
+			End:   loc[1],
+			New:   string(replaced),
+			Line:  strings.Count(content[:loc[0]], "\n") + 1,
+		})
+	}
+	return matches
+}
+
+// ApplyMatches replaces the given occurrences in content. The matches must come
+// from Find on the same content; any subset of them may be passed.
+func ApplyMatches(content string, matches []Match) string {
+	sorted := append([]Match(nil), matches...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range sorted {
+		sb.WriteString(content[last:m.Start])
+		sb.WriteString(m.New)
+		last = m.End
+	}
+	sb.WriteString(content[last:])
+	return sb.String()
+}
+
+// Hunk is a run of whole lines changed by a set of matches, before and after
+type Hunk struct {
+	Line   int // 1-based line of the content the hunk starts on
+	Before []string
+	After  []string
+}
+
+// ReplaceHunks groups matches into the lines they change, for showing a diff.
+// Matches that share a line form one hunk.
+func ReplaceHunks(content string, matches []Match) []Hunk {
+	sorted := append([]Match(nil), matches...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var hunks []Hunk
+	for i := 0; i < len(sorted); {
+		start := strings.LastIndex(content[:sorted[i].Start], "\n") + 1
+		end := lineEnd(content, sorted[i].End)
+		j := i + 1
+		for j < len(sorted) && sorted[j].Start <= end {
+			end = max(end, lineEnd(content, sorted[j].End))
+			j++
+		}
+
+		before := content[start:end]
+		shifted := make([]Match, 0, j-i)
+		for _, m := range sorted[i:j] {
+			shifted = append(shifted, Match{Start: m.Start - start, End: m.End - start, New: m.New})
+		}
+		hunks = append(hunks, Hunk{
+			Line:   sorted[i].Line,
+			Before: strings.Split(before, "\n"),
+			After:  strings.Split(ApplyMatches(before, shifted), "\n"),
+		})
+		i = j
+	}
+	return hunks
+}
+
+// lineEnd returns the offset of the end of the line containing offset
+func lineEnd(content string, offset int) int {
+	if i := strings.IndexByte(content[offset:], '\n'); i != -1 {
+		return offset + i
+	}
+	return len(content)
+}
+
+// ReplaceContent saves new content for a note, refusing locked and read-only notes
+func (m *Manager) ReplaceContent(note *Note, content string) error {
+	if err := checkUnlocked(note); err != nil {
+		return err
+	}
+	note.Content = content
+	_, err := m.saveUpdated(note)
+	return err
+}
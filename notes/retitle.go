@@ -0,0 +1,117 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ReplaceInTitle replaces every occurrence of match in a title. Unless caseSensitive
+// is set, match is found regardless of case and the replacement follows the case of
+// each occurrence: with match "mtg", "MTG" becomes "MEETING", "mtg" becomes "meeting",
+// and "Mtg" becomes "Meeting". It reports whether anything was replaced.
+func ReplaceInTitle(title, match, replace string, caseSensitive bool) (string, bool) {
+	if match == "" {
+		return title, false
+	}
+	if caseSensitive {
+		return strings.ReplaceAll(title, match, replace), strings.Contains(title, match)
+	}
+
+	pattern := regexp.MustCompile("(?i)" + regexp.QuoteMeta(match))
+	found := false
+	result := pattern.ReplaceAllStringFunc(title, func(occurrence string) string {
+		found = true
+		return matchCase(occurrence, replace)
+	})
+	return result, found
+}
+
+// matchCase gives replace the case of occurrence: all upper, all lower, or
+// capitalized. Any other mix of case leaves replace as typed.
+func matchCase(occurrence, replace string) string {
+	letters := []rune(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return r
+		}
+		return -1
+	}, occurrence))
+
+	switch {
+	case len(letters) == 0:
+		return replace
+	case len(letters) > 1 && string(letters) == strings.ToUpper(string(letters)):
+		return strings.ToUpper(replace)
+	case string(letters) == strings.ToLower(string(letters)):
+		return strings.ToLower(replace)
+	case unicode.IsUpper(letters[0]) && string(letters[1:]) == strings.ToLower(string(letters[1:])):
+		r := []rune(replace)
+		for i, c := range r {
+			if unicode.IsLetter(c) {
+				r[i] = unicode.ToUpper(c)
+				break
+			}
+		}
+		return string(r)
+	}
+	return replace
+}
+
+// RetitledID returns the ID a note gets with a new title: burh's timestamp prefix
+// followed by the new title's slug. Notes whose ID has no timestamp keep their ID.
+func RetitledID(note *Note, title string) string {
+	id := note.ID
+	if len(id) < 16 || id[15] != '_' {
+		return id
+	}
+	if _, err := time.Parse("20060102_150405", id[:15]); err != nil {
+		return id
+	}
+	return id[:16] + sanitizeTitle(title)
+}
+
+// RetitleNote changes a note's title and renames its file to match, so the ID's
+// slug follows the title. The timestamp part of the ID is kept.
+func (m *Manager) RetitleNote(note *Note, title string) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+	if err := checkUnlocked(note); err != nil {
+		return err
+	}
+	if isReadOnly(handlerFor(note.Format)) {
+		return fmt.Errorf("%s notes cannot be edited", note.Format)
+	}
+	if err := m.LoadContent(note); err != nil {
+		return err
+	}
+
+	id := RetitledID(note, title)
+	if id == note.ID {
+		return m.RenameNote(note, title)
+	}
+
+	oldPath := note.Path()
+	filename := id + filepath.Ext(note.Filename)
+	if _, err := os.Stat(filepath.Join(note.Dir, filename)); err == nil {
+		return fmt.Errorf("a file named %s already exists in %s", filename, note.Dir)
+	}
+
+	return m.journaled(OpUpdate, note, func() error {
+		note.Title = title
+		note.ID = id
+		note.Filename = filename
+		if _, err := m.saveUpdated(note); err != nil {
+			return fmt.Errorf("failed to write retitled note: %w", err)
+		}
+		if err := os.Remove(oldPath); err != nil {
+			return fmt.Errorf("retitled note written but failed to remove %s: %w", oldPath, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,86 @@
+package notes
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SetRecursive controls whether subdirectories of the notes directories are scanned.
+// Hidden directories and archive folders are always skipped.
+func (m *Manager) SetRecursive(recursive bool) {
+	m.recursive = recursive
+}
+
+// noteFiles returns the paths of the note files in a notes directory, leaving out
+// what its ignore file matches. The primary notes directory may not exist yet: it
+// is created when the first note is saved.
+func (m *Manager) noteFiles(notesDir string) ([]string, error) {
+	if _, err := os.Stat(notesDir); errors.Is(err, os.ErrNotExist) && len(m.notesDirs) > 0 && notesDir == m.notesDirs[0] {
+		return nil, nil
+	}
+	rules, err := loadIgnoreRules(notesDir)
+	if err != nil {
+		return nil, err
+	}
+	var buried tombstones
+	if m.syncthing {
+		if buried, err = loadTombstones(notesDir); err != nil {
+			return nil, err
+		}
+	}
+
+	if !m.recursive {
+		files, err := os.ReadDir(notesDir)
+		if err != nil {
+			return nil, err
+		}
+		var paths []string
+		for _, file := range files {
+			path := filepath.Join(notesDir, file.Name())
+			if !file.IsDir() && isNoteFile(file.Name()) && !rules.ignored(file.Name(), false) && !m.syncSkipped(buried, path, file) {
+				paths = append(paths, path)
+			}
+		}
+		return paths, nil
+	}
+
+	var paths []string
+	err = filepath.WalkDir(notesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == notesDir {
+				return err
+			}
+			return nil // Skip unreadable subdirectories
+		}
+		if path == notesDir {
+			return nil
+		}
+		rel, _ := filepath.Rel(notesDir, path)
+		ignored := rules.ignored(filepath.ToSlash(rel), d.IsDir())
+		if d.IsDir() {
+			if skipDir(d.Name()) || ignored {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isNoteFile(d.Name()) && !ignored && !m.syncSkipped(buried, path, d) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths, err
+}
+
+// isNoteFile checks if a file name has the extension of a registered format
+func isNoteFile(name string) bool {
+	_, _, ok := formatForFile(name)
+	return ok
+}
+
+// skipDir checks if a subdirectory is excluded from recursive scans
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || name == ArchiveDirName
+}
@@ -0,0 +1,136 @@
+package notes
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of scheduled items
+const (
+	ItemDeadline  = "deadline"
+	ItemScheduled = "scheduled"
+)
+
+// Item is a dated entry found in a note: an org DEADLINE or SCHEDULED timestamp,
+// a due:/scheduled: date in a Markdown or text line, or a "due" metadata field
+type Item struct {
+	Note    *Note
+	Kind    string // ItemDeadline or ItemScheduled
+	Summary string
+	Date    time.Time
+	HasTime bool // Whether Date includes a time of day
+	Done    bool
+}
+
+var (
+	orgHeadingLine = regexp.MustCompile(`^(\*+)\s+(?:(TODO|DONE)\s+)?(.*?)(?:\s+:[\w@:]+:)?\s*$`)
+	orgTimestamp   = regexp.MustCompile(`(DEADLINE|SCHEDULED):\s*<(\d{4}-\d{2}-\d{2})(?:\s+[^\s\d>]+)?(?:\s+(\d{1,2}:\d{2}))?[^>]*>`)
+	textDate       = regexp.MustCompile(`(?i)\b(due|scheduled):(\d{4}-\d{2}-\d{2})(?:[T ](\d{1,2}:\d{2}))?|@due\((\d{4}-\d{2}-\d{2})\)`)
+	textTask       = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(?:\[([ xX])\]\s+)?`)
+)
+
+// ScheduledItems returns the dated items in the notes, earliest first.
+// The notes' content must be loaded.
+func ScheduledItems(notes []*Note) []Item {
+	var items []Item
+	for _, note := range notes {
+		if due, ok := parseItemDate(note.Meta["due"], ""); ok {
+			items = append(items, Item{Note: note, Kind: ItemDeadline, Summary: note.Title, Date: due})
+		}
+		if isOrg(note.Format) {
+			items = append(items, orgItems(note)...)
+		} else {
+			items = append(items, textItems(note)...)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Date.Before(items[j].Date)
+	})
+	return items
+}
+
+// orgItems finds DEADLINE and SCHEDULED timestamps, each belonging to the heading above it,
+// or the note when that is the "* CONTENT" heading
+func orgItems(note *Note) []Item {
+	var items []Item
+	summary, done := note.Title, false
+	for _, line := range strings.Split(note.Content, "\n") {
+		if m := orgHeadingLine.FindStringSubmatch(line); m != nil {
+			summary, done = m[3], m[2] == "DONE"
+			if m[1] == "*" && summary == "CONTENT" {
+				// The heading burh writes over the body; items under it are the note's
+				summary = note.Title
+			}
+			continue
+		}
+		for _, m := range orgTimestamp.FindAllStringSubmatch(line, -1) {
+			date, ok := parseItemDate(m[2], m[3])
+			if !ok {
+				continue
+			}
+			items = append(items, Item{
+				Note:    note,
+				Kind:    strings.ToLower(m[1]),
+				Summary: summary,
+				Date:    date,
+				HasTime: m[3] != "",
+				Done:    done,
+			})
+		}
+	}
+	return items
+}
+
+// textItems finds due:DATE, scheduled:DATE, and @due(DATE) in Markdown and text lines.
+// The rest of the line, without list markers and checkboxes, is the summary.
+func textItems(note *Note) []Item {
+	var items []Item
+	for _, line := range strings.Split(note.Content, "\n") {
+		matches := textDate.FindAllStringSubmatch(line, -1)
+		if matches == nil {
+			continue
+		}
+
+		summary := strings.TrimSpace(textDate.ReplaceAllString(line, ""))
+		done := false
+		if m := textTask.FindStringSubmatch(summary); m != nil {
+			done = strings.EqualFold(m[1], "x")
+			summary = strings.TrimSpace(summary[len(m[0]):])
+		}
+		summary = strings.TrimSpace(strings.TrimLeft(summary, "#"))
+		if summary == "" {
+			summary = note.Title
+		}
+
+		for _, m := range matches {
+			kind, day, clock := ItemDeadline, m[2], m[3]
+			if m[4] != "" {
+				day = m[4]
+			} else if strings.EqualFold(m[1], "scheduled") {
+				kind = ItemScheduled
+			}
+			date, ok := parseItemDate(day, clock)
+			if !ok {
+				continue
+			}
+			items = append(items, Item{Note: note, Kind: kind, Summary: summary, Date: date, HasTime: clock != "", Done: done})
+		}
+	}
+	return items
+}
+
+// parseItemDate parses a YYYY-MM-DD date and an optional HH:MM time in local time
+func parseItemDate(day, clock string) (time.Time, bool) {
+	if day == "" {
+		return time.Time{}, false
+	}
+	if clock != "" {
+		t, err := time.ParseInLocation("2006-01-02 15:04", day+" "+clock, time.Local)
+		return t, err == nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", day, time.Local)
+	return t, err == nil
+}
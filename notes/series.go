@@ -0,0 +1,205 @@
+package notes
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Series is a recurring note, such as a weekly review: an instance of it is due
+// on each date of its schedule. Instances carry the series' tag and start with
+// a "date:" line, and a "previous:" line linking the instance before.
+type Series struct {
+	Name     string
+	Schedule Schedule
+	Title    string // Title of each instance; {date} is its date
+	Tags     []string
+	Format   string
+	Template string // Content below the date and previous lines; {date} and {title} are filled in
+}
+
+// Schedule is when a series' instances are due: on Start, then every Days days
+// or Months months after
+type Schedule struct {
+	Start  time.Time
+	Days   int
+	Months int
+}
+
+// scheduleAliases are the words a schedule may be written with instead of a period
+var scheduleAliases = map[string]string{
+	"daily":     "1d",
+	"weekly":    "1w",
+	"biweekly":  "2w",
+	"monthly":   "1m",
+	"quarterly": "3m",
+	"yearly":    "1y",
+}
+
+// ParseSchedule reads how often a series recurs: a weekday such as "monday" for
+// every week on that day, a word such as "weekly" or "biweekly", or a period such
+// as "2w" or "1m" counted from start (YYYY-MM-DD). Start is only optional for
+// weekdays.
+func ParseSchedule(every, start string) (Schedule, error) {
+	every = strings.ToLower(strings.TrimSpace(every))
+	var s Schedule
+	if start != "" {
+		date, err := time.ParseInLocation("2006-01-02", start, time.Local)
+		if err != nil {
+			return s, fmt.Errorf("invalid start date %q (expected YYYY-MM-DD)", start)
+		}
+		s.Start = date
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if every != strings.ToLower(day.String()) {
+			continue
+		}
+		if s.Start.IsZero() {
+			s.Start = time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local)
+		}
+		for s.Start.Weekday() != day {
+			s.Start = s.Start.AddDate(0, 0, 1)
+		}
+		s.Days = 7
+		return s, nil
+	}
+
+	if alias, ok := scheduleAliases[every]; ok {
+		every = alias
+	}
+	days, months, err := parsePeriod(every)
+	if err != nil || days+months == 0 {
+		return s, fmt.Errorf("invalid schedule %q (expected a weekday, e.g. monday, a word such as weekly, or a period such as 2w)", every)
+	}
+	if s.Start.IsZero() {
+		return s, fmt.Errorf("a %s schedule needs a start date", every)
+	}
+	s.Days, s.Months = days, months
+	return s, nil
+}
+
+// Next returns the first due date of the schedule on or after the day of t
+func (s Schedule) Next(t time.Time) time.Time {
+	day := startOfDay(t)
+	date := s.Start
+	for k := 1; date.Before(day); k++ {
+		date = s.Start.AddDate(0, k*s.Months, k*s.Days)
+	}
+	return date
+}
+
+// SeriesTag returns the tag of a series' instances: its name in lowercase, with
+// dashes between the words and numbers, e.g. "weekly-review" or "1-1-with-sam"
+func SeriesTag(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
+
+// SeriesInstances returns a series' instances with their content loaded, and
+// the date of each
+func (m *Manager) SeriesInstances(s Series) ([]*Note, []time.Time, error) {
+	tagged, err := m.SearchByTag(SeriesTag(s.Name))
+	if err != nil {
+		return nil, nil, err
+	}
+	var instances []*Note
+	var dates []time.Time
+	for _, note := range tagged {
+		if !WritableFormat(note.Format) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, nil, err
+		}
+		instances = append(instances, note)
+		dates = append(dates, MeetingDate(note))
+	}
+	return instances, dates, nil
+}
+
+// GenerateDue creates the instances of a series due from now through the later
+// of through and the series' next date, skipping those that already exist, and
+// returns the new ones. Each links to the instance dated before it.
+func (m *Manager) GenerateDue(s Series, now, through time.Time) ([]*Note, error) {
+	instances, dates, err := m.SeriesInstances(s)
+	if err != nil {
+		return nil, err
+	}
+
+	due := []time.Time{s.Schedule.Next(now)}
+	for {
+		next := s.Schedule.Next(due[len(due)-1].AddDate(0, 0, 1))
+		if next.After(through) {
+			break
+		}
+		due = append(due, next)
+	}
+
+	var created []*Note
+	for _, date := range due {
+		var previous *Note
+		var previousDate time.Time
+		exists := false
+		for i, note := range instances {
+			switch {
+			case dates[i].Equal(date):
+				exists = true
+			case dates[i].Before(date) && (previous == nil || dates[i].After(previousDate)):
+				previous, previousDate = note, dates[i]
+			}
+		}
+		if exists {
+			continue
+		}
+
+		title := strings.ReplaceAll(s.Title, "{date}", date.Format("2006-01-02"))
+		tags := append([]string{SeriesTag(s.Name)}, s.Tags...)
+		note, err := m.CreateNote(title, m.seriesContent(s, title, date, previous), tags, s.Format)
+		if err != nil {
+			return created, err
+		}
+		created = append(created, note)
+		instances = append(instances, note)
+		dates = append(dates, date)
+	}
+	return created, nil
+}
+
+// seriesContent returns the content of a new instance: its date, a link to the
+// previous instance if there is one, and the series' template
+func (m *Manager) seriesContent(s Series, title string, date time.Time, previous *Note) string {
+	var sb strings.Builder
+	sb.WriteString("date: " + date.Format("2006-01-02") + "\n")
+	if previous != nil {
+		sb.WriteString("previous: " + m.noteLink(previous, s.Format) + "\n")
+	}
+	if s.Template != "" {
+		body := strings.ReplaceAll(s.Template, "{date}", date.Format("2006-01-02"))
+		body = strings.ReplaceAll(body, "{title}", title)
+		sb.WriteString("\n" + body)
+	}
+	return sb.String()
+}
+
+// noteLink links to a note from a new note in the primary notes directory, in
+// the link syntax of a format: [Title](file.md), [[file:file.org][Title]], or
+// the title and ID in plain text
+func (m *Manager) noteLink(note *Note, format string) string {
+	target := note.Filename
+	if rel, err := filepath.Rel(m.notesDirs[0], note.Path()); err == nil {
+		target = filepath.ToSlash(rel)
+	}
+	switch markupOf(format) {
+	case MarkdownMarkup:
+		return fmt.Sprintf("[%s](%s)", note.Title, target)
+	case OrgMarkup:
+		return fmt.Sprintf("[[file:%s][%s]]", target, note.Title)
+	default:
+		return fmt.Sprintf("%s (%s)", note.Title, note.ID)
+	}
+}
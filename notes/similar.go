@@ -0,0 +1,46 @@
+package notes
+
+import (
+	"sort"
+	"strings"
+)
+
+// SimilarTitles returns the notes whose titles closely match title: the same words
+// in any order, ignoring case, accents, and punctuation, or the same words but for
+// a typo or two. Notes with the same words come first.
+func (m *Manager) SimilarTitles(title string) ([]*Note, error) {
+	folder := newTextFolder(m.searchLocale)
+	want := searchWords(folder.Fold(title))
+	if len(want) == 0 {
+		return nil, nil
+	}
+	wantText := strings.Join(want, " ")
+	wantKey := wordSetKey(want)
+
+	list, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+	var same, near []*Note
+	for _, note := range list {
+		words := searchWords(folder.Fold(note.Title))
+		if len(words) == 0 {
+			continue
+		}
+		switch {
+		case wordSetKey(words) == wantKey:
+			same = append(same, note)
+		case withinEdits(wantText, strings.Join(words, " "), maxEdits(wantText, 2)):
+			near = append(near, note)
+		}
+	}
+	return append(same, near...), nil
+}
+
+// wordSetKey returns the words of a title sorted, so titles with the same words
+// in a different order have the same key
+func wordSetKey(words []string) string {
+	sorted := append([]string(nil), words...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, " ")
+}
@@ -0,0 +1,117 @@
+package notes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SnippetTag marks notes holding a code snippet
+const SnippetTag = "snippet"
+
+// LanguageKey is the metadata field naming a snippet's language
+const LanguageKey = "language"
+
+// Snippet is the code of a snippet note and its language
+type Snippet struct {
+	Note     *Note
+	Language string // Canonical language name (see NormalizeLanguage), "" if unknown
+	Code     string
+}
+
+// SnippetFromNote reads the snippet in a note: the first fenced code block (or org
+// source block), or the whole content if it has none. The language is the note's
+// language field, or else the one the block names.
+func SnippetFromNote(note *Note) Snippet {
+	s := Snippet{Note: note, Code: strings.Trim(note.Content, "\n")}
+	if isOrg(note.Format) {
+		s.Code = strings.TrimPrefix(s.Code, "* CONTENT\n")
+	}
+
+	lang := ""
+	var code []string
+	inBlock := false
+	for _, line := range strings.Split(note.Content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		upper := strings.ToUpper(trimmed)
+		if !inBlock {
+			if strings.HasPrefix(trimmed, "```") {
+				lang, inBlock = strings.TrimSpace(trimmed[3:]), true
+			} else if strings.HasPrefix(upper, "#+BEGIN_SRC") {
+				lang, inBlock = strings.TrimSpace(trimmed[len("#+BEGIN_SRC"):]), true
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(upper, "#+END_SRC") {
+			s.Code = strings.Join(code, "\n")
+			break
+		}
+		code = append(code, line)
+	}
+
+	// The block may name options after the language, as in "#+BEGIN_SRC sh :results output"
+	if fields := strings.Fields(lang); len(fields) > 0 {
+		lang = fields[0]
+	}
+	if meta := note.Meta[LanguageKey]; meta != "" {
+		lang = meta
+	}
+	s.Language = NormalizeLanguage(lang)
+	return s
+}
+
+// Snippets returns the snippet notes, in a language when lang is not empty, sorted
+// by title
+func (m *Manager) Snippets(lang string) ([]Snippet, error) {
+	noteList, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	lang = NormalizeLanguage(lang)
+	var snippets []Snippet
+	for _, note := range noteList {
+		if !hasTag(note.Tags, SnippetTag) || !WritableFormat(note.Format) {
+			continue
+		}
+		if err := m.LoadContent(note); err != nil {
+			return nil, err
+		}
+		snippet := SnippetFromNote(note)
+		if lang == "" || snippet.Language == lang {
+			snippets = append(snippets, snippet)
+		}
+	}
+	sort.SliceStable(snippets, func(i, j int) bool {
+		return strings.ToLower(snippets[i].Note.Title) < strings.ToLower(snippets[j].Note.Title)
+	})
+	return snippets, nil
+}
+
+// FindSnippet finds a snippet by note ID or title, ignoring case: an exact match,
+// or else the only snippet whose title contains the name
+func FindSnippet(snippets []Snippet, name string) (Snippet, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	var matches []Snippet
+	for _, s := range snippets {
+		title := strings.ToLower(s.Note.Title)
+		if title == name || strings.ToLower(s.Note.ID) == name {
+			return s, nil
+		}
+		if strings.Contains(title, name) {
+			matches = append(matches, s)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Snippet{}, fmt.Errorf("no snippet matching %q", name)
+	case 1:
+		return matches[0], nil
+	default:
+		var titles []string
+		for _, s := range matches {
+			titles = append(titles, s.Note.Title)
+		}
+		return Snippet{}, fmt.Errorf("%q matches several snippets: %s", name, strings.Join(titles, "; "))
+	}
+}
@@ -0,0 +1,42 @@
+package notes
+
+import (
+	"sort"
+	"strings"
+)
+
+// SortKeys are the orders SortNotes knows: by creation date, modification time,
+// format, title, or tags
+var SortKeys = []string{"date", "modified", "format", "title", "tags"}
+
+// SortNotes orders notes by one of SortKeys, breaking ties by ID. Unknown keys
+// sort by creation date.
+func SortNotes(list []*Note, by string, descending bool) {
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		c := compareNotes(a, b, by)
+		if c == 0 {
+			c = strings.Compare(a.ID, b.ID)
+		}
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// compareNotes compares two notes on a single sort key
+func compareNotes(a, b *Note, by string) int {
+	switch by {
+	case "modified":
+		return a.Modified.Compare(b.Modified)
+	case "format":
+		return strings.Compare(a.Format, b.Format)
+	case "title":
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case "tags":
+		return strings.Compare(strings.Join(a.Tags, ","), strings.Join(b.Tags, ","))
+	default:
+		return a.Created.Compare(b.Created)
+	}
+}
@@ -0,0 +1,92 @@
+package notes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SetStaleExcludeTags sets the tags whose notes never count as stale, e.g. "reference"
+func (m *Manager) SetStaleExcludeTags(tags []string) {
+	m.staleExclude = tags
+}
+
+// AgeCutoff parses a period such as "30d", "6w", "3m", or "1y" and returns the time that long before now
+func AgeCutoff(age string, now time.Time) (time.Time, error) {
+	days, months, err := parsePeriod(age)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.AddDate(0, -months, -days), nil
+}
+
+// AddPeriod parses a period such as "30d", "6w", "3m", or "1y" and returns the time that long after t
+func AddPeriod(period string, t time.Time) (time.Time, error) {
+	days, months, err := parsePeriod(period)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.AddDate(0, months, days), nil
+}
+
+// parsePeriod splits a period such as "30d", "6w", "3m", or "1y" into days and months
+func parsePeriod(period string) (days, months int, err error) {
+	period = strings.ToLower(strings.TrimSpace(period))
+	if len(period) < 2 {
+		return 0, 0, fmt.Errorf("invalid period %q (expected e.g. 30d, 6w, 3m, or 1y)", period)
+	}
+
+	n, err := strconv.Atoi(period[:len(period)-1])
+	if err != nil || n < 0 {
+		return 0, 0, fmt.Errorf("invalid period %q (expected e.g. 30d, 6w, 3m, or 1y)", period)
+	}
+
+	switch period[len(period)-1] {
+	case 'd':
+		return n, 0, nil
+	case 'w':
+		return 7 * n, 0, nil
+	case 'm':
+		return 0, n, nil
+	case 'y':
+		return 0, 12 * n, nil
+	default:
+		return 0, 0, fmt.Errorf("invalid period %q (expected e.g. 30d, 6w, 3m, or 1y)", period)
+	}
+}
+
+// IsStale reports whether a note was last modified before cutoff and has none of the excluded tags
+func IsStale(note *Note, cutoff time.Time, exclude []string) bool {
+	if !note.Modified.Before(cutoff) {
+		return false
+	}
+	for _, tag := range exclude {
+		if hasTag(note.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// StaleNotes returns the notes not modified since cutoff, least recently modified first.
+// Notes with one of the manager's excluded tags are left out.
+func (m *Manager) StaleNotes(cutoff time.Time) ([]*Note, error) {
+	all, err := m.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []*Note
+	for _, note := range all {
+		if IsStale(note, cutoff, m.staleExclude) {
+			stale = append(stale, note)
+		}
+	}
+
+	sort.SliceStable(stale, func(i, j int) bool {
+		return stale[i].Modified.Before(stale[j].Modified)
+	})
+	return stale, nil
+}
@@ -0,0 +1,188 @@
+package notes
+
+// stem reduces an English word to its stem with the Porter algorithm, so that
+// "running", "runs", and "run" all become "run". Short words and words that are
+// not plain lowercase ASCII letters are returned unchanged.
+func stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	s := &stemmer{b: []byte(word)}
+	s.step1()
+	s.applyRules(step2Rules, 0)
+	s.applyRules(step3Rules, 0)
+	s.applyRules(step4Rules, 1)
+	s.step5()
+	return string(s.b)
+}
+
+// stemRule replaces a suffix when the measure of the rest of the word is high enough
+type stemRule struct {
+	suffix, replacement string
+}
+
+// Suffix rules of steps 2-4. Where one suffix ends another, the longer comes first:
+// only the first matching suffix is considered.
+var (
+	step2Rules = []stemRule{
+		{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"}, {"izer", "ize"},
+		{"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"},
+		{"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"},
+		{"fulness", "ful"}, {"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+		{"logi", "log"},
+	}
+	step3Rules = []stemRule{
+		{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"}, {"ical", "ic"},
+		{"ful", ""}, {"ness", ""},
+	}
+	step4Rules = []stemRule{
+		{"al", ""}, {"ance", ""}, {"ence", ""}, {"er", ""}, {"ic", ""}, {"able", ""}, {"ible", ""},
+		{"ant", ""}, {"ement", ""}, {"ment", ""}, {"ent", ""}, {"ion", ""}, {"ou", ""}, {"ism", ""},
+		{"ate", ""}, {"iti", ""}, {"ous", ""}, {"ive", ""}, {"ize", ""},
+	}
+)
+
+// stemmer holds the word being stemmed
+type stemmer struct {
+	b []byte
+}
+
+// cons reports whether b[i] is a consonant; y is one unless it follows a consonant
+func (s *stemmer) cons(i int) bool {
+	switch s.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !s.cons(i-1)
+	}
+	return true
+}
+
+// measure counts the vowel-consonant sequences in b[:n]
+func (s *stemmer) measure(n int) int {
+	m, i := 0, 0
+	for i < n && s.cons(i) {
+		i++
+	}
+	for i < n {
+		for i < n && !s.cons(i) {
+			i++
+		}
+		if i >= n {
+			break
+		}
+		for i < n && s.cons(i) {
+			i++
+		}
+		m++
+	}
+	return m
+}
+
+// hasVowel reports whether b[:n] contains a vowel
+func (s *stemmer) hasVowel(n int) bool {
+	for i := 0; i < n; i++ {
+		if !s.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleCons reports whether b[:n] ends in a double consonant
+func (s *stemmer) doubleCons(n int) bool {
+	return n >= 2 && s.b[n-1] == s.b[n-2] && s.cons(n-1)
+}
+
+// cvc reports whether b[:n] ends consonant-vowel-consonant, the last not w, x, or y,
+// as in "hop" (which takes an e back: "hoping" becomes "hope")
+func (s *stemmer) cvc(n int) bool {
+	if n < 3 || !s.cons(n-3) || s.cons(n-2) || !s.cons(n-1) {
+		return false
+	}
+	last := s.b[n-1]
+	return last != 'w' && last != 'x' && last != 'y'
+}
+
+// ends reports whether the word ends with the suffix
+func (s *stemmer) ends(suffix string) bool {
+	return len(s.b) >= len(suffix) && string(s.b[len(s.b)-len(suffix):]) == suffix
+}
+
+// replace swaps the last n bytes for the replacement
+func (s *stemmer) replace(n int, replacement string) {
+	s.b = append(s.b[:len(s.b)-n], replacement...)
+}
+
+// step1 removes plurals and -ed or -ing, and turns a final y after a vowel-containing stem into i
+func (s *stemmer) step1() {
+	switch {
+	case s.ends("sses"), s.ends("ies"):
+		s.replace(2, "")
+	case s.ends("ss"):
+	case s.ends("s"):
+		s.replace(1, "")
+	}
+
+	if s.ends("eed") {
+		if s.measure(len(s.b)-3) > 0 {
+			s.replace(1, "")
+		}
+	} else if (s.ends("ed") && s.hasVowel(len(s.b)-2)) || (s.ends("ing") && s.hasVowel(len(s.b)-3)) {
+		if s.ends("ed") {
+			s.replace(2, "")
+		} else {
+			s.replace(3, "")
+		}
+		n := len(s.b)
+		switch {
+		case s.ends("at"), s.ends("bl"), s.ends("iz"):
+			s.replace(0, "e")
+		case s.doubleCons(n) && s.b[n-1] != 'l' && s.b[n-1] != 's' && s.b[n-1] != 'z':
+			s.replace(1, "")
+		case s.measure(n) == 1 && s.cvc(n):
+			s.replace(0, "e")
+		}
+	}
+
+	if s.ends("y") && s.hasVowel(len(s.b)-1) {
+		s.b[len(s.b)-1] = 'i'
+	}
+}
+
+// applyRules replaces the first matching suffix if the rest of the word has a measure above minMeasure.
+// Step 4's -ion is only removed after s or t.
+func (s *stemmer) applyRules(rules []stemRule, minMeasure int) {
+	for _, rule := range rules {
+		if !s.ends(rule.suffix) {
+			continue
+		}
+		n := len(s.b) - len(rule.suffix)
+		if rule.suffix == "ion" && (n == 0 || (s.b[n-1] != 's' && s.b[n-1] != 't')) {
+			return
+		}
+		if s.measure(n) > minMeasure {
+			s.replace(len(rule.suffix), rule.replacement)
+		}
+		return
+	}
+}
+
+// step5 removes a final e and reduces a final double l on longer words
+func (s *stemmer) step5() {
+	if s.ends("e") {
+		n := len(s.b) - 1
+		if m := s.measure(n); m > 1 || (m == 1 && !s.cvc(n)) {
+			s.b = s.b[:n]
+		}
+	}
+	if n := len(s.b); s.measure(n) > 1 && s.doubleCons(n) && s.b[n-1] == 'l' {
+		s.b = s.b[:n-1]
+	}
+}
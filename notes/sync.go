@@ -0,0 +1,224 @@
+package notes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"burh/paths"
+)
+
+// SyncSyncthing is the sync mode for notes directories kept in sync by Syncthing
+const SyncSyncthing = "syncthing"
+
+// TombstoneFileName is the file in a notes directory recording the notes deleted
+// from it, by ID, so copies brought back by sync can be recognized. It lives in
+// the synced directory so every device sees it.
+const TombstoneFileName = ".burh-deleted.json"
+
+// conflictPattern matches the marker Syncthing puts in the name of a conflicting
+// copy, e.g. "note.sync-conflict-20240101-120000-ABCDEFG.md"
+var conflictPattern = regexp.MustCompile(`\.sync-conflict-\d{8}-\d{6}-[A-Z0-9]{7}`)
+
+// Kinds of sync issues
+const (
+	SyncConflict    = "conflict"    // A conflicting copy of a note made by Syncthing
+	SyncResurrected = "resurrected" // A deleted note brought back, unchanged, by a device that still had it
+)
+
+// SyncIssue is a file in a notes directory left by syncing that isn't a note of its own
+type SyncIssue struct {
+	Kind     string
+	Path     string
+	Original string // For a conflict, the note it is a copy of
+}
+
+// tombstones maps the IDs of deleted notes to when they were deleted
+type tombstones map[string]time.Time
+
+// SetSyncMode makes the Manager aware of how the notes directories are synced: ""
+// for not at all, or SyncSyncthing. Syncthing's conflict copies are then left out
+// of the notes, deleted notes are remembered, and copies of them that sync brings
+// back unchanged are left out too.
+func (m *Manager) SetSyncMode(mode string) {
+	m.syncthing = mode == SyncSyncthing
+}
+
+// IsConflictFile checks if a file name is a Syncthing conflict copy
+func IsConflictFile(name string) bool {
+	return conflictPattern.MatchString(name)
+}
+
+// conflictOriginal returns the path of the file a conflict copy was made of
+func conflictOriginal(path string) string {
+	return filepath.Join(filepath.Dir(path), conflictPattern.ReplaceAllString(filepath.Base(path), ""))
+}
+
+// fileID returns the ID of the note in a file, its name without the extension
+func fileID(path string) string {
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// loadTombstones reads a notes directory's tombstones. A missing file yields none.
+func loadTombstones(notesDir string) (tombstones, error) {
+	data, err := os.ReadFile(filepath.Join(notesDir, TombstoneFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return tombstones{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", TombstoneFileName, err)
+	}
+	t := tombstones{}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse %s in %s: %w", TombstoneFileName, notesDir, err)
+	}
+	return t, nil
+}
+
+// buried checks if the note file at path was deleted and hasn't changed since, so
+// it is back only because sync restored it
+func (t tombstones) buried(path string, modTime time.Time) bool {
+	deleted, ok := t[fileID(path)]
+	return ok && !modTime.After(deleted)
+}
+
+// syncSkipped checks if a file found in a scan is a sync leftover rather than a
+// note, when sync awareness is on
+func (m *Manager) syncSkipped(t tombstones, path string, d fs.DirEntry) bool {
+	if !m.syncthing {
+		return false
+	}
+	if IsConflictFile(d.Name()) {
+		return true
+	}
+	info, err := d.Info()
+	return err == nil && t.buried(path, info.ModTime())
+}
+
+// bury records in the tombstones of its notes directory that a note was deleted
+func (m *Manager) bury(note *Note) error {
+	if !m.syncthing {
+		return nil
+	}
+	root := m.notesDirOf(note.Dir)
+	t, err := loadTombstones(root)
+	if err != nil {
+		return err
+	}
+	t[note.ID] = time.Now()
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(root, TombstoneFileName), data, 0644)
+}
+
+// notesDirOf returns the notes directory that dir is or is inside
+func (m *Manager) notesDirOf(dir string) string {
+	for _, notesDir := range m.notesDirs {
+		if paths.Within(dir, notesDir) {
+			return notesDir
+		}
+	}
+	return dir
+}
+
+// SyncIssues finds the conflict copies and resurrected notes in the notes directories
+func (m *Manager) SyncIssues() ([]SyncIssue, error) {
+	var issues []SyncIssue
+	for _, notesDir := range m.notesDirs {
+		t, err := loadTombstones(notesDir)
+		if err != nil {
+			return nil, err
+		}
+		err = filepath.WalkDir(notesDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != notesDir && (!m.recursive || skipDir(d.Name())) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !isNoteFile(d.Name()) {
+				return nil
+			}
+			if IsConflictFile(d.Name()) {
+				issues = append(issues, SyncIssue{Kind: SyncConflict, Path: path, Original: conflictOriginal(path)})
+				return nil
+			}
+			if info, err := d.Info(); err == nil && t.buried(path, info.ModTime()) {
+				issues = append(issues, SyncIssue{Kind: SyncResurrected, Path: path})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Path < issues[j].Path })
+	return issues, nil
+}
+
+// ResolveSyncIssue clears up a sync issue and describes what it did. A
+// resurrected note is deleted again. Of a conflict copy and its note, the one
+// changed last is kept as the note and the other removed. Each step is
+// journaled, so Undo reverts it.
+func (m *Manager) ResolveSyncIssue(issue SyncIssue) (string, error) {
+	if err := m.checkWritable(issue.Path); err != nil {
+		return "", err
+	}
+	copyNote := &Note{ID: fileID(issue.Path), Title: fileID(issue.Path), Dir: filepath.Dir(issue.Path), Filename: filepath.Base(issue.Path)}
+
+	if issue.Kind == SyncResurrected {
+		err := m.journaled(OpDelete, copyNote, func() error {
+			if err := os.Remove(issue.Path); err != nil {
+				return err
+			}
+			return removeSidecar(issue.Path)
+		})
+		return "deleted again", err
+	}
+
+	copyInfo, err := os.Stat(issue.Path)
+	if err != nil {
+		return "", err
+	}
+	originalInfo, err := os.Stat(issue.Original)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && copyInfo.ModTime().After(originalInfo.ModTime())) {
+		// Two steps, so undoing both brings back the note and the conflict copy
+		original := &Note{ID: fileID(issue.Original), Title: fileID(issue.Original), Dir: filepath.Dir(issue.Original), Filename: filepath.Base(issue.Original)}
+		if err := m.journaled(OpDelete, original, func() error {
+			if err := os.Remove(issue.Original); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			return nil
+		}); err != nil {
+			return "", err
+		}
+		err := m.journaled(OpMove, copyNote, func() error {
+			if err := os.Rename(issue.Path, issue.Original); err != nil {
+				return err
+			}
+			copyNote.Filename = original.Filename
+			return nil
+		})
+		return "kept the conflict copy, which is newer", err
+	}
+	if err != nil {
+		return "", err
+	}
+	err = m.journaled(OpDelete, copyNote, func() error {
+		return os.Remove(issue.Path)
+	})
+	return "kept the note, which is newer", err
+}
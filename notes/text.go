@@ -0,0 +1,117 @@
+package notes
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// textFormat handles plain text and Markdown notes, which share a "Key: value" header
+type textFormat struct {
+	ext    string
+	markup string
+}
+
+// Extensions returns the format's file extension
+func (f textFormat) Extensions() []string {
+	return []string{f.ext}
+}
+
+// Markup returns the markup of the format's bodies: plain text or Markdown
+func (f textFormat) Markup() string {
+	return f.markup
+}
+
+// Format renders a note with a plain text header
+func (textFormat) Format(note *Note) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Title: %s\n", note.Title))
+	sb.WriteString(fmt.Sprintf("Created: %s\n", note.Created.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("Modified: %s\n", note.Modified.Format("2006-01-02 15:04:05")))
+
+	if len(note.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("Tags: %s\n", strings.Join(note.Tags, ", ")))
+	}
+
+	if note.Locked {
+		sb.WriteString("Locked: true\n")
+	}
+
+	for _, key := range sortedMetaKeys(note.Meta) {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", strings.ToUpper(key[:1])+key[1:], note.Meta[key]))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(strings.ReplaceAll(note.Content, "\\n", "\n"))
+
+	return sb.String()
+}
+
+// Parse reads a note with a plain text header. The header ends at the first
+// blank line or body line; field-like lines after it are content.
+func (textFormat) Parse(content string, isField func(key string) bool) Parsed {
+	var title, noteContent string
+	var tags []string
+	var meta map[string]string
+
+	lines := strings.Split(content, "\n")
+
+	offset := 0
+	inHeader, headerDone := false, false
+	for _, line := range lines {
+		lineStart := offset
+		offset += len(line) + 1
+
+		if line == "" {
+			if inHeader {
+				inHeader, headerDone = false, true
+			}
+			continue // Skip empty lines
+		}
+		if !headerDone {
+			if strings.HasPrefix(line, "Title:") {
+				inHeader = true
+				title = strings.TrimSpace(strings.TrimPrefix(line, "Title:"))
+				continue
+			} else if strings.HasPrefix(line, "Tags:") {
+				inHeader = true
+				tagStr := strings.TrimSpace(strings.TrimPrefix(line, "Tags:"))
+				tags = strings.Split(tagStr, ",")
+				for j, tag := range tags {
+					tags[j] = strings.TrimSpace(tag)
+				}
+				continue
+			} else if strings.HasPrefix(line, "Created:") || strings.HasPrefix(line, "Modified:") {
+				inHeader = true
+				continue // Skip metadata
+			} else if key, value, ok := parseField(line, isField); ok {
+				inHeader = true
+				if meta == nil {
+					meta = map[string]string{}
+				}
+				meta[key] = value
+				continue
+			}
+		}
+
+		// Start of content
+		noteContent = strings.TrimSpace(content[lineStart:])
+		break
+	}
+
+	return Parsed{Title: title, Content: noteContent, Tags: tags, Meta: meta}
+}
+
+// ReadHeader reads the header lines at the top of the file
+func (textFormat) ReadHeader(r io.Reader, isField func(key string) bool) (string, error) {
+	return scanHeader(r, func(line string) bool {
+		for _, prefix := range []string{"Title:", "Tags:", "Created:", "Modified:"} {
+			if strings.HasPrefix(line, prefix) {
+				return true
+			}
+		}
+		_, _, ok := parseField(line, isField)
+		return ok
+	}, nil)
+}
@@ -0,0 +1,93 @@
+package notes
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// minTermLength is the shortest word counted as a topic term
+const minTermLength = 3
+
+// TermCount is a word and how many times it appears
+type TermCount struct {
+	Term  string
+	Count int
+}
+
+// stopwords are common English words that say nothing about a note's topic
+var stopwords = makeSet(strings.Fields(`
+	about above after again against all also and any are aren because been before being below
+	between both but can cannot could did didn does doesn doing don down during each few for
+	from further get got had hadn has hasn have haven having her here hers herself him himself
+	his how into isn its itself just let like make made more most much must mustn myself need
+	nor not now off once one only other ought our ours ourselves out over own same shan she
+	should shouldn some such than that the their theirs them themselves then there these they
+	this those through too under until use used using very via was wasn way well were weren
+	what when where which while who whom why will with won would wouldn yes yet you your yours
+	yourself yourselves http https www com
+`))
+
+// makeSet builds a lookup set from a word list
+func makeSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// TopTerms counts the meaningful words in the notes' titles and contents and returns
+// the n most frequent, most frequent first. Stopwords, numbers, and short words are skipped.
+func TopTerms(notes []*Note, n int) []TermCount {
+	counts := map[string]int{}
+	for _, note := range notes {
+		for _, term := range terms(note.Title + "\n" + note.Content) {
+			counts[term]++
+		}
+	}
+
+	top := make([]TermCount, 0, len(counts))
+	for term, count := range counts {
+		top = append(top, TermCount{Term: term, Count: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Term < top[j].Term
+	})
+
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// terms splits text into lowercase words worth counting
+func terms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	var result []string
+	for _, word := range words {
+		word = strings.Trim(word, "'")
+		word = strings.TrimSuffix(word, "'s")
+		if len([]rune(word)) < minTermLength || stopwords[word] || isNumber(word) {
+			continue
+		}
+		result = append(result, word)
+	}
+	return result
+}
+
+// isNumber reports whether a word is made only of digits
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,16 @@
+package notes
+
+import "fmt"
+
+// SetWarnFunc sets where problems that don't stop an operation are reported, such
+// as a change that was made but could not be journaled. Without one they are dropped.
+func (m *Manager) SetWarnFunc(warn func(err error)) {
+	m.warn = warn
+}
+
+// warnf reports a problem that doesn't stop the running operation
+func (m *Manager) warnf(format string, args ...any) {
+	if m.warn != nil {
+		m.warn(fmt.Errorf(format, args...))
+	}
+}
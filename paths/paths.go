@@ -0,0 +1,50 @@
+// Package paths compares file paths the way the OS does: on Windows, case doesn't
+// matter and / and \ are the same separator
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Same checks if two paths name the same file or directory, without touching the
+// filesystem
+func Same(a, b string) bool {
+	return equal(filepath.Clean(a), filepath.Clean(b))
+}
+
+// Within checks if path is dir or inside it
+func Within(path, dir string) bool {
+	path, dir = filepath.Clean(path), filepath.Clean(dir)
+	if equal(path, dir) {
+		return true
+	}
+	prefix := dir
+	if !strings.HasSuffix(prefix, string(filepath.Separator)) {
+		prefix += string(filepath.Separator) // Roots such as / and C:\ already end in one
+	}
+	return len(path) > len(prefix) && equal(path[:len(prefix)], prefix)
+}
+
+// ExpandHome expands a leading ~ to the user's home directory. "~/notes" and, on
+// Windows, "~\notes" both work.
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path // Return original path if we can't get home dir
+	}
+	return filepath.Join(homeDir, path[1:])
+}
+
+// equal compares two cleaned paths
+func equal(a, b string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
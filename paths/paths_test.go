@@ -0,0 +1,103 @@
+package paths
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// onOS reports whether a test case for goos ("" for every OS, "unix" for every
+// OS but Windows) applies here
+func onOS(goos string) bool {
+	switch goos {
+	case "":
+		return true
+	case "unix":
+		return runtime.GOOS != "windows"
+	default:
+		return runtime.GOOS == goos
+	}
+}
+
+func TestSame(t *testing.T) {
+	tests := []struct {
+		goos string
+		a, b string
+		want bool
+	}{
+		{"", "notes", "notes", true},
+		{"", "notes/", "notes", true},
+		{"", "notes/work/..", "notes", true},
+		{"", "notes", "other", false},
+		{"unix", "/home/a/Notes", "/home/a/notes", false},
+		{"windows", `C:\Users\a\Notes`, `c:\users\a\notes`, true},
+		{"windows", `C:\Users\a\notes`, `C:/Users/a/notes`, true},
+		{"windows", `C:\Users\a\notes\`, `C:\Users\a\notes`, true},
+		{"windows", `C:\notes`, `D:\notes`, false},
+	}
+	for _, tt := range tests {
+		if !onOS(tt.goos) {
+			continue
+		}
+		if got := Same(tt.a, tt.b); got != tt.want {
+			t.Errorf("Same(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWithin(t *testing.T) {
+	tests := []struct {
+		goos      string
+		path, dir string
+		want      bool
+	}{
+		{"", "notes", "notes", true},
+		{"", "notes/work/a.md", "notes", true},
+		{"", "notes-old/a.md", "notes", false},
+		{"", "notes", "notes/work", false},
+		{"unix", "/notes/a.md", "/", true},
+		{"unix", "/home/a/Notes/x.md", "/home/a/notes", false},
+		{"windows", `C:\Notes\work\a.md`, `c:\notes`, true},
+		{"windows", `C:/notes/a.md`, `C:\notes`, true},
+		{"windows", `C:\notes\a.md`, `C:\`, true},
+		{"windows", `C:\notes-old\a.md`, `C:\notes`, false},
+		{"windows", `D:\notes\a.md`, `C:\notes`, false},
+	}
+	for _, tt := range tests {
+		if !onOS(tt.goos) {
+			continue
+		}
+		if got := Within(tt.path, tt.dir); got != tt.want {
+			t.Errorf("Within(%q, %q) = %v, want %v", tt.path, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	tests := []struct {
+		goos string
+		path string
+		want string
+	}{
+		{"", "~", home},
+		{"", "~/notes", filepath.Join(home, "notes")},
+		{"", "notes/~", "notes/~"},
+		{"", "~other/notes", "~other/notes"},
+		{"", "/abs/notes", "/abs/notes"},
+		{"unix", `~\notes`, `~\notes`},
+		{"windows", `~\notes`, filepath.Join(home, "notes")},
+		{"windows", `C:\notes`, `C:\notes`},
+	}
+	for _, tt := range tests {
+		if !onOS(tt.goos) {
+			continue
+		}
+		if got := ExpandHome(tt.path); got != tt.want {
+			t.Errorf("ExpandHome(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page layout, in points
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 56
+	bodySize     = 10
+	bodyLeading  = 13
+	headerSize   = 10
+	titleSize    = 16
+	footerSize   = 9
+	footerOffset = 30
+)
+
+// winAnsi maps characters outside Latin-1 to their WinAnsiEncoding bytes or ASCII stand-ins
+var winAnsi = map[rune]string{
+	'€': "\x80", '…': "\x85", '‘': "\x91", '’': "\x92", '“': "\x93", '”': "\x94",
+	'•': "\x95", '–': "\x96", '—': "\x97", '─': "-", '☐': "[ ]", '☑': "[x]", '✓': "v",
+}
+
+// PDF lays the document out as an A4 PDF: the title and header on the first page,
+// the body in a monospaced font, and page numbers in the footer
+func (d Document) PDF() []byte {
+	var pages []*bytes.Buffer
+	page := &bytes.Buffer{}
+	pages = append(pages, page)
+
+	y := pageHeight - margin
+	text(page, "F3", titleSize, margin, y, d.Title)
+	y -= titleSize + 8
+	for _, line := range d.Header {
+		text(page, "F2", headerSize, margin, y, line)
+		y -= headerSize + 4
+	}
+	fmt.Fprintf(page, "0.5 w %d %d m %d %d l S\n", margin, y, pageWidth-margin, y)
+	y -= bodyLeading + 6
+
+	for _, line := range d.bodyLines() {
+		if y < margin {
+			page = &bytes.Buffer{}
+			pages = append(pages, page)
+			y = pageHeight - margin
+		}
+		text(page, "F1", bodySize, margin, y, line)
+		y -= bodyLeading
+	}
+
+	for i, p := range pages {
+		text(p, "F2", footerSize, pageWidth/2-15, footerOffset, fmt.Sprintf("%d / %d", i+1, len(pages)))
+	}
+
+	return assemble(pages)
+}
+
+// text writes one line of text at a position
+func text(w *bytes.Buffer, font string, size, x, y int, s string) {
+	fmt.Fprintf(w, "BT /%s %d Tf %d %d Td (%s) Tj ET\n", font, size, x, y, pdfString(s))
+}
+
+// pdfString encodes text for a PDF string literal in WinAnsiEncoding
+func pdfString(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case winAnsi[r] != "":
+			sb.WriteString(winAnsi[r])
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			sb.WriteByte(byte(r))
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+// assemble writes the PDF objects, one page and content stream per page buffer
+func assemble(pages []*bytes.Buffer) []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are the catalog, page tree, and fonts; pages follow in pairs
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, p := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Contents %d 0 R "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R >> >> >>", pageWidth, pageHeight, 7+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.Len(), p.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
@@ -0,0 +1,103 @@
+package printer
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// lineWidth is the number of characters a printed body line is wrapped at
+const lineWidth = 80
+
+// Document is a note laid out for printing: a title, a few header lines such as
+// the date and tags, and the body text
+type Document struct {
+	Title  string
+	Header []string
+	Body   string
+}
+
+// Text lays the document out as plain text for a line printer
+func (d Document) Text() string {
+	var sb strings.Builder
+	sb.WriteString(d.Title + "\n")
+	for _, line := range d.Header {
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString(strings.Repeat("─", lineWidth) + "\n\n")
+	for _, line := range d.bodyLines() {
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// bodyLines returns the body wrapped to lineWidth
+func (d Document) bodyLines() []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(d.Body, "\n"), "\n") {
+		lines = append(lines, wrap(strings.ReplaceAll(line, "\t", "    "), lineWidth)...)
+	}
+	return lines
+}
+
+// Print sends the document to a printer, or the default printer if name is empty,
+// using lp on macOS and Linux
+func Print(d Document, name string) error {
+	if runtime.GOOS == "windows" {
+		return fmt.Errorf("printing is not supported on Windows; use --pdf and print the file")
+	}
+
+	args := []string{"-t", d.Title}
+	if name != "" {
+		args = append(args, "-d", name)
+	}
+	cmd := exec.Command("lp", args...)
+	cmd.Stdin = strings.NewReader(d.Text())
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to print: %v %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// wrap breaks a line into lines of at most width characters at spaces,
+// keeping the line's indentation on continuation lines
+func wrap(line string, width int) []string {
+	if len([]rune(line)) <= width {
+		return []string{line}
+	}
+
+	indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+	if len(indent) > width/2 {
+		indent = ""
+	}
+
+	var lines []string
+	current := indent
+	for _, word := range strings.Fields(line) {
+		// Hard-split words longer than a whole line
+		for len([]rune(indent+word)) > width {
+			if strings.TrimSpace(current) != "" {
+				lines = append(lines, current)
+			}
+			cut := width - len(indent)
+			lines = append(lines, indent+string([]rune(word)[:cut]))
+			word = string([]rune(word)[cut:])
+			current = indent
+		}
+
+		switch {
+		case strings.TrimSpace(current) == "":
+			current = indent + word
+		case len([]rune(current))+1+len([]rune(word)) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = indent + word
+		}
+	}
+	if strings.TrimSpace(current) != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
@@ -0,0 +1,462 @@
+// Package qr encodes text as a QR code (byte mode, error correction level L)
+// and renders it for the terminal.
+package qr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxBytes is the most data a QR code can hold at error correction level L
+const MaxBytes = 2953
+
+// eccL is the format bit pattern of error correction level L
+const eccL = 1
+
+// blockLayout describes how a version's codewords are split into blocks at level L:
+// error correction codewords per block, then the count and data size of each block group
+type blockLayout struct {
+	ecc                 int
+	blocks1, dataWords1 int
+	blocks2, dataWords2 int
+}
+
+// layouts holds the level L block layout of versions 1-40
+var layouts = [41]blockLayout{
+	{},
+	{7, 1, 19, 0, 0}, {10, 1, 34, 0, 0}, {15, 1, 55, 0, 0}, {20, 1, 80, 0, 0}, {26, 1, 108, 0, 0},
+	{18, 2, 68, 0, 0}, {20, 2, 78, 0, 0}, {24, 2, 97, 0, 0}, {30, 2, 116, 0, 0}, {18, 2, 68, 2, 69},
+	{20, 4, 81, 0, 0}, {24, 2, 92, 2, 93}, {26, 4, 107, 0, 0}, {30, 3, 115, 1, 116}, {22, 5, 87, 1, 88},
+	{24, 5, 98, 1, 99}, {28, 1, 107, 5, 108}, {30, 5, 120, 1, 121}, {28, 3, 113, 4, 114}, {28, 3, 107, 5, 108},
+	{28, 4, 116, 4, 117}, {28, 2, 111, 7, 112}, {30, 4, 121, 5, 122}, {30, 6, 117, 4, 118}, {26, 8, 106, 4, 107},
+	{28, 10, 114, 2, 115}, {30, 8, 122, 4, 123}, {30, 3, 117, 10, 118}, {30, 7, 116, 7, 117}, {30, 5, 115, 10, 116},
+	{30, 13, 115, 3, 116}, {30, 17, 115, 0, 0}, {30, 17, 115, 1, 116}, {30, 13, 115, 6, 116}, {30, 12, 121, 7, 122},
+	{30, 6, 121, 14, 122}, {30, 17, 122, 4, 123}, {30, 4, 122, 18, 123}, {30, 20, 117, 4, 118}, {30, 19, 118, 6, 119},
+}
+
+// dataWords returns the number of data codewords of a version
+func (l blockLayout) dataWords() int {
+	return l.blocks1*l.dataWords1 + l.blocks2*l.dataWords2
+}
+
+// Code is an encoded QR symbol
+type Code struct {
+	Size     int
+	modules  [][]bool
+	function [][]bool // Modules that belong to finder, timing, alignment, and format patterns
+}
+
+// Dark reports whether the module at column x, row y is dark
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+// Encode encodes data in the smallest version that fits it
+func Encode(data []byte) (*Code, error) {
+	version := 0
+	for v := 1; v <= 40; v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= layouts[v].dataWords()*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("%d bytes is too much for a QR code (at most %d)", len(data), MaxBytes)
+	}
+
+	c := &Code{Size: version*4 + 17}
+	c.modules = newGrid(c.Size)
+	c.function = newGrid(c.Size)
+	c.drawFunctionPatterns(version)
+	c.drawCodewords(interleave(version, dataCodewords(version, data)))
+
+	// Pick the mask with the lowest penalty
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		c.applyMask(mask) // Masking twice undoes it
+	}
+	c.applyMask(best)
+	c.drawFormatBits(best)
+	return c, nil
+}
+
+// newGrid returns a size by size grid of light modules
+func newGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+// set colors a function module
+func (c *Code) set(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.function[y][x] = true
+}
+
+// drawFunctionPatterns draws the finder, timing, and alignment patterns, the version
+// information, and placeholders for the format information
+func (c *Code) drawFunctionPatterns(version int) {
+	for i := 0; i < c.Size; i++ {
+		c.set(6, i, i%2 == 0)
+		c.set(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	positions := alignmentPositions(version, c.Size)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Skip the three corners taken by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(x, y)
+		}
+	}
+
+	c.drawFormatBits(0)
+	c.drawVersion(version)
+}
+
+// drawFinder draws a finder pattern and its separator centered at x, y
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= c.Size || yy < 0 || yy >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.set(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignment draws an alignment pattern centered at x, y
+func (c *Code) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// alignmentPositions returns the row and column centers of a version's alignment patterns
+func alignmentPositions(version, size int) []int {
+	if version == 1 {
+		return nil
+	}
+	count := version/7 + 2
+	step := (version*4 + count*2 + 1) / (count*2 - 2) * 2
+	if version == 32 {
+		step = 26
+	}
+
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, pos := count-1, size-7; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+	return positions
+}
+
+// drawFormatBits draws both copies of the error correction level and mask
+func (c *Code) drawFormatBits(mask int) {
+	data := eccL<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+
+	for i := 0; i <= 5; i++ {
+		c.set(8, i, bit(i))
+	}
+	c.set(8, 7, bit(6))
+	c.set(8, 8, bit(7))
+	c.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.set(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.set(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.set(8, c.Size-15+i, bit(i))
+	}
+	c.set(8, c.Size-8, true) // Always dark
+}
+
+// drawVersion draws both copies of the version information, used from version 7 up
+func (c *Code) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 != 0
+		a, b := c.Size-11+i%3, i/3
+		c.set(a, b, dark)
+		c.set(b, a, dark)
+	}
+}
+
+// dataCodewords encodes data in byte mode and pads it to the version's capacity
+func dataCodewords(version int, data []byte) []byte {
+	var bits bitBuffer
+	bits.append(0x4, 4)
+	if version >= 10 {
+		bits.append(len(data), 16)
+	} else {
+		bits.append(len(data), 8)
+	}
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacity := layouts[version].dataWords() * 8
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	words := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			words[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return words
+}
+
+// interleave splits data into blocks, adds error correction to each, and interleaves them
+func interleave(version int, data []byte) []byte {
+	layout := layouts[version]
+	divisor := rsDivisor(layout.ecc)
+
+	var blocks, eccs [][]byte
+	for i := 0; i < layout.blocks1+layout.blocks2; i++ {
+		size := layout.dataWords1
+		if i >= layout.blocks1 {
+			size = layout.dataWords2
+		}
+		block := data[:size]
+		data = data[size:]
+		blocks = append(blocks, block)
+		eccs = append(eccs, rsRemainder(block, divisor))
+	}
+
+	var result []byte
+	for i := 0; i < max(layout.dataWords1, layout.dataWords2); i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < layout.ecc; i++ {
+		for _, ecc := range eccs {
+			result = append(result, ecc[i])
+		}
+	}
+	return result
+}
+
+// drawCodewords places the codeword bits in the zigzag pattern, skipping function modules
+func (c *Code) drawCodewords(words []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert // Moving upwards
+				}
+				if !c.function[y][x] && i < len(words)*8 {
+					c.modules[y][x] = (words[i/8]>>(7-i%8))&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask flips the data modules selected by a mask pattern
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			var flip bool
+			switch mask {
+			case 0:
+				flip = (x+y)%2 == 0
+			case 1:
+				flip = y%2 == 0
+			case 2:
+				flip = x%3 == 0
+			case 3:
+				flip = (x+y)%3 == 0
+			case 4:
+				flip = (x/3+y/2)%2 == 0
+			case 5:
+				flip = x*y%2+x*y%3 == 0
+			case 6:
+				flip = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				flip = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if flip && !c.function[y][x] {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the symbol is to scan; lower is better
+func (c *Code) penalty() int {
+	score := 0
+	line := make([]bool, c.Size)
+	for _, vertical := range []bool{false, true} {
+		for i := 0; i < c.Size; i++ {
+			for j := 0; j < c.Size; j++ {
+				if vertical {
+					line[j] = c.modules[j][i]
+				} else {
+					line[j] = c.modules[i][j]
+				}
+			}
+			score += linePenalty(line)
+		}
+	}
+
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x < c.Size-1 && y < c.Size-1 {
+				v := c.modules[y][x]
+				if c.modules[y][x+1] == v && c.modules[y+1][x] == v && c.modules[y+1][x+1] == v {
+					score += 3
+				}
+			}
+		}
+	}
+
+	total := c.Size * c.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return score + k*10
+}
+
+// finderLike are module sequences that resemble a finder pattern
+var finderLike = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+// linePenalty scores runs of same-colored modules and finder-like sequences in one row or column
+func linePenalty(line []bool) int {
+	score := 0
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			score += 3 + run - 5
+		}
+		run = 1
+	}
+
+	for i := 0; i+11 <= len(line); i++ {
+		for _, pattern := range finderLike {
+			match := true
+			for j, v := range pattern {
+				if line[i+j] != v {
+					match = false
+					break
+				}
+			}
+			if match {
+				score += 40
+			}
+		}
+	}
+	return score
+}
+
+// Terminal renders the code with half-block characters, two module rows per text line,
+// inside a quiet zone. Light modules are drawn as blocks, which suits light text on a dark
+// terminal; invert draws dark modules as blocks instead, for dark text on a light one.
+func (c *Code) Terminal(invert bool) string {
+	const quiet = 4
+	lit := func(x, y int) bool {
+		x, y = x-quiet, y-quiet
+		dark := x >= 0 && y >= 0 && x < c.Size && y < c.Size && c.modules[y][x]
+		return dark == invert
+	}
+
+	var sb strings.Builder
+	total := c.Size + 2*quiet
+	for y := 0; y < total; y += 2 {
+		for x := 0; x < total; x++ {
+			top, bottom := lit(x, y), lit(x, y+1)
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// bitBuffer collects bits most significant first
+type bitBuffer []bool
+
+// append adds the low n bits of value
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,252 @@
+// Package rpc serves notes to editor plugins over a local socket with JSON-RPC 2.0.
+// Each request and response is one JSON object on its own line.
+//
+// Methods:
+//
+//	list   {"limit": 50}                         → notes, newest first; limit 0 for all
+//	search {"query": "tag:work meeting"}         → notes matching a search query
+//	get    {"id": "20240101_120000_title"}       → a note with its content
+//	create {"title": "...", "content": "...", "tags": ["a"], "format": "md"} → the new note
+//
+// Notes are sent as in 'burh list --format json', plus their full "path". Only
+// get sends content.
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"sync"
+
+	"burh/notes"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeServerError    = -32000
+)
+
+// maxRequestSize caps a request line, enough for a large note created in one call
+const maxRequestSize = 16 << 20
+
+// Server answers requests about notes on a Unix socket
+type Server struct {
+	manager  *notes.Manager
+	listener net.Listener
+	path     string
+	mu       sync.Mutex // Requests run one at a time, as the Manager isn't safe for concurrent use
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"` // Absent for notifications, which get no response
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// noteResult is a note as sent to clients, with the path to open it at
+type noteResult struct {
+	*notes.Note
+	Path string `json:"path"`
+}
+
+// Listen opens the socket at path, which only the current user can connect to. A
+// socket left behind by a server that is no longer running is replaced.
+func Listen(path string, manager *notes.Manager) (*Server, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("a server is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return &Server{manager: manager, listener: listener, path: path}, nil
+}
+
+// Serve answers requests until ctx is done, then closes the socket
+func (s *Server) Serve(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		s.listener.Close()
+	}()
+	defer os.Remove(s.path)
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle answers the requests on one connection until the client closes it
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), maxRequestSize)
+	encoder := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			encoder.Encode(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &Error{codeParseError, err.Error()}})
+			continue
+		}
+		result, rpcErr := s.call(req)
+		if req.ID == nil {
+			continue // A notification
+		}
+		if err := encoder.Encode(response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rpcErr}); err != nil {
+			return
+		}
+	}
+}
+
+// call runs a request's method
+func (s *Server) call(req request) (any, *Error) {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return nil, &Error{codeInvalidRequest, `expected "jsonrpc": "2.0" and a method`}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch req.Method {
+	case "list":
+		var params struct {
+			Limit int `json:"limit"`
+		}
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		noteList, err := s.manager.ListNotes()
+		if err != nil {
+			return nil, serverError(err)
+		}
+		sort.SliceStable(noteList, func(i, j int) bool { return noteList[i].Created.After(noteList[j].Created) })
+		if params.Limit > 0 && len(noteList) > params.Limit {
+			noteList = noteList[:params.Limit]
+		}
+		return results(noteList), nil
+
+	case "search":
+		var params struct {
+			Query string `json:"query"`
+		}
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		noteList, err := s.manager.SearchNotes(params.Query)
+		if err != nil {
+			return nil, serverError(err)
+		}
+		return results(noteList), nil
+
+	case "get":
+		var params struct {
+			ID string `json:"id"`
+		}
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		if params.ID == "" {
+			return nil, &Error{codeInvalidParams, "id is required"}
+		}
+		note, err := s.manager.GetNote(params.ID)
+		if err != nil {
+			return nil, serverError(err)
+		}
+		if err := s.manager.LoadContent(note); err != nil {
+			return nil, serverError(err)
+		}
+		return noteResult{note, note.Path()}, nil
+
+	case "create":
+		var params struct {
+			Title   string   `json:"title"`
+			Content string   `json:"content"`
+			Tags    []string `json:"tags"`
+			Format  string   `json:"format"`
+		}
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		if params.Title == "" {
+			return nil, &Error{codeInvalidParams, "title is required"}
+		}
+		if params.Format == "" {
+			params.Format = "txt"
+		}
+		note, err := s.manager.CreateNote(params.Title, params.Content, params.Tags, params.Format)
+		if err != nil {
+			return nil, serverError(err)
+		}
+		return noteResult{note, note.Path()}, nil
+	}
+	return nil, &Error{codeMethodNotFound, fmt.Sprintf("unknown method %q", req.Method)}
+}
+
+// decodeParams reads a request's params into v; missing params leave v as is
+func decodeParams(params json.RawMessage, v any) *Error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &Error{codeInvalidParams, err.Error()}
+	}
+	return nil
+}
+
+// results converts notes for sending without their content
+func results(noteList []*notes.Note) []noteResult {
+	out := make([]noteResult, len(noteList))
+	for i, note := range noteList {
+		copied := *note
+		copied.Content = ""
+		out[i] = noteResult{&copied, note.Path()}
+	}
+	return out
+}
+
+// serverError reports a failed method as a JSON-RPC error
+func serverError(err error) *Error {
+	return &Error{codeServerError, err.Error()}
+}
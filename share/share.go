@@ -0,0 +1,174 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// page wraps a rendered note in a standalone read-only HTML page
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>%s</title>
+<style>
+body { max-width: 46em; margin: 2em auto; padding: 0 1em; font: 16px/1.5 sans-serif; color: #2E3440; }
+pre { background: #ECEFF4; padding: 1em; overflow-x: auto; }
+code { background: #ECEFF4; padding: 0 .2em; }
+blockquote { border-left: 3px solid #88C0D0; margin-left: 0; padding-left: 1em; color: #4C566A; }
+</style>
+</head>
+<body>
+%s
+</body>
+</html>
+`
+
+// Server serves one note at an unguessable URL until it is stopped
+type Server struct {
+	token    string
+	listener net.Listener
+	srv      *http.Server
+	once     bool
+	served   chan struct{}
+}
+
+// NewServer starts listening on addr (e.g. ":8080", or ":0" for any free port) for a
+// note page. body is the note rendered as HTML. With once set, the server stops
+// after the page has been viewed one time.
+func NewServer(addr, title, body string, once bool) (*Server, error) {
+	token, err := NewToken()
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s := &Server{token: token, listener: listener, once: once, served: make(chan struct{}, 1)}
+	content := fmt.Sprintf(page, html.EscapeString(title), body)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+s.token || r.Method != http.MethodGet {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+		io.WriteString(w, content)
+		select {
+		case s.served <- struct{}{}:
+		default:
+		}
+	})
+	s.srv = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	return s, nil
+}
+
+// URLs returns the addresses the page can be opened at, one per local network address
+func (s *Server) URLs() []string {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+
+	var hosts []string
+	if ip := net.ParseIP(host); ip != nil && !ip.IsUnspecified() {
+		hosts = []string{host}
+	} else {
+		hosts = localAddresses()
+	}
+
+	urls := make([]string, len(hosts))
+	for i, h := range hosts {
+		urls[i] = fmt.Sprintf("http://%s/%s", net.JoinHostPort(h, port), s.token)
+	}
+	return urls
+}
+
+// Serve serves the page until ctx is done, ttl has passed, or, in once mode, the page was viewed
+func (s *Server) Serve(ctx context.Context, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, ttl)
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() { errc <- s.srv.Serve(s.listener) }()
+
+	served := s.served
+	if !s.once {
+		served = nil
+	}
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	case <-served:
+		// Give the response time to reach the client before closing
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelShutdown()
+	if err := s.srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Paste uploads text to a paste service by POSTing it as the request body
+// and returns the service's response, which is usually the paste's URL
+func Paste(endpoint, text string) (string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(endpoint, "text/plain; charset=utf-8", strings.NewReader(text))
+	if err != nil {
+		return "", fmt.Errorf("failed to upload note: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read paste response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("paste service returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// NewToken returns a random URL path that cannot be guessed
+func NewToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// localAddresses returns the machine's non-loopback IPv4 addresses, or localhost if there are none
+func localAddresses() []string {
+	var hosts []string
+	addrs, _ := net.InterfaceAddrs()
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.To4() == nil {
+			continue
+		}
+		hosts = append(hosts, ipNet.IP.String())
+	}
+	if len(hosts) == 0 {
+		hosts = []string{"localhost"}
+	}
+	return hosts
+}
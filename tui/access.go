@@ -0,0 +1,34 @@
+package tui
+
+import "strings"
+
+// markSelected marks the selected row with ">" when colors alone can't show it:
+// with accessibility.markers on, or when colors are off
+func (s *Styles) markSelected(row string) string {
+	if !s.markers {
+		return row
+	}
+	if strings.HasPrefix(row, " ") {
+		return ">" + row[1:]
+	}
+	return ">" + row
+}
+
+// warn renders a warning, marked with "[!]" besides its color when markers are on
+func (s *Styles) warn(text string) string {
+	return s.warning.Render(s.flag(text))
+}
+
+// fail renders an error, marked with "[!]" besides its color when markers are on
+func (s *Styles) fail(text string) string {
+	return s.error.Render(s.flag(text))
+}
+
+// flag puts "[!]" before a message, after its indentation, when markers are on
+func (s *Styles) flag(text string) string {
+	if !s.markers {
+		return text
+	}
+	trimmed := strings.TrimLeft(text, " ")
+	return text[:len(text)-len(trimmed)] + "[!] " + trimmed
+}
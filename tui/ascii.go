@@ -0,0 +1,23 @@
+package tui
+
+import "strings"
+
+// asciiGlyphs swaps the box-drawing and other non-ASCII characters the TUI draws
+// with for ASCII ones of the same width, for display.ascii
+var asciiGlyphs = strings.NewReplacer(
+	// Double borders and separators
+	"╔", "+", "╗", "+", "╚", "+", "╝", "+", "═", "=", "║", "|",
+	// Rounded borders and rules
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+", "─", "-", "│", "|",
+	// Markers, arrows, and the cursor
+	"▸", ">", "▾", "v", "•", "*", "▲", "^", "▼", "v", "↑", "^", "↓", "v",
+	"←", "<", "→", ">", "↳", ">", "✕", "x", "█", "_",
+)
+
+// asciiOnly draws a rendered view with ASCII characters when display.ascii is on
+func (m *Model) asciiOnly(view string) string {
+	if !m.config.Display.Ascii {
+		return view
+	}
+	return asciiGlyphs.Replace(view)
+}
@@ -0,0 +1,25 @@
+package tui
+
+import (
+	"fmt"
+
+	"burh/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cloneSelected duplicates the selected note, then reloads the list with the copy selected
+func (m *Model) cloneSelected() tea.Cmd {
+	if len(m.notes) == 0 || m.selected >= len(m.notes) {
+		return nil
+	}
+
+	clone, err := m.noteManager.CloneNote(m.notes[m.selected], "")
+	if err != nil {
+		m.flash = err.Error()
+		return nil
+	}
+	m.flash = fmt.Sprintf("Cloned as '%s'", clone.Title)
+	m.restore = &config.Session{SelectedID: clone.ID}
+	return m.loadNotesCmd()
+}
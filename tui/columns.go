@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"burh/notes"
+)
+
+// tagsReserve is the width kept for the tags column when titles fit the terminal
+const tagsReserve = 30
+
+// titleColumnWidth returns the width of the list's title column: titleWidth, or
+// with title_overflow "fit" whatever the terminal has left beside the other columns
+func (m *Model) titleColumnWidth() int {
+	if m.config.TitleOverflow != "fit" {
+		return titleWidth
+	}
+	// The border and indent take four cells, and two spaces separate each column
+	rest := getTerminalWidth() - 4 - numberWidth - dateWidth - 2 - formatWidth - 2 - 2 - tagsReserve
+	return max(titleWidth, rest)
+}
+
+// wrapTitle splits a title too long for its column into two lines, breaking at
+// the last space that leaves the first line at least half full. The second line
+// is truncated if it is still too long.
+func wrapTitle(title string, width int) (string, string) {
+	r := []rune(title)
+	if len(r) <= width {
+		return title, ""
+	}
+	cut := width
+	if i := strings.LastIndex(string(r[:width+1]), " "); i > 0 && len([]rune(title[:i])) >= width/2 {
+		cut = len([]rune(title[:i]))
+	}
+	return string(r[:cut]), truncate(strings.TrimSpace(string(r[cut:])), width)
+}
+
+// editedWidth is the width of the list's optional "Edited" column, e.g. "11mo ago"
+const editedWidth = 8
+
+// editedColumnWidth returns the width the "Edited" column and the space before it
+// take, 0 when modified_column is off
+func (m *Model) editedColumnWidth() int {
+	if !m.config.ModifiedColumn {
+		return 0
+	}
+	return editedWidth + 2
+}
+
+// editedCell returns a note's cell in the "Edited" column, "" when it is off
+func (m *Model) editedCell(note *notes.Note) string {
+	if !m.config.ModifiedColumn {
+		return ""
+	}
+	return fmt.Sprintf("%-*s  ", editedWidth, notes.Ago(note.Modified, time.Now()))
+}
+
+// editedStatus describes when and by whom the selected note was last edited, for
+// the status bar; "" when modified_column is off
+func (m *Model) editedStatus() string {
+	if !m.config.ModifiedColumn || m.state != "list" || len(m.notes) == 0 || m.selected >= len(m.notes) {
+		return ""
+	}
+	note := m.notes[m.selected]
+	status := "edited " + notes.Ago(note.Modified, time.Now())
+	if editor := m.lastEditors[note.ID]; editor != "" {
+		status += " by " + editor
+	}
+	return status
+}
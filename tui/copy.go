@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"fmt"
+
+	"burh/clipboard"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copiedMsg reports the result of copying to the clipboard
+type copiedMsg struct {
+	flash string
+}
+
+// copySelected puts the selected note's content, path, or ID on the clipboard
+// in the background, since the clipboard tool may be slow, and reports the
+// result in the status bar
+func (m *Model) copySelected(what string) tea.Cmd {
+	if len(m.notes) == 0 || m.selected >= len(m.notes) {
+		return nil
+	}
+	note := m.notes[m.selected]
+
+	var text string
+	switch what {
+	case "content":
+		if err := m.noteManager.LoadContent(note); err != nil {
+			m.flash = err.Error()
+			return nil
+		}
+		text = note.Content
+	case "path":
+		text = note.Path()
+	case "ID":
+		text = note.ID
+	}
+
+	title := note.Title
+	return func() tea.Msg {
+		if err := clipboard.Write(text); err != nil {
+			return copiedMsg{flash: err.Error()}
+		}
+		return copiedMsg{flash: fmt.Sprintf("Copied %s of '%s'", what, title)}
+	}
+}
+
+// pasteImage adds the image on the clipboard to the end of the selected note and
+// reports whether it did
+func (m *Model) pasteImage() bool {
+	if len(m.notes) == 0 || m.selected >= len(m.notes) {
+		return false
+	}
+	note := m.notes[m.selected]
+
+	data, err := clipboard.ReadImage()
+	if err != nil {
+		m.flash = err.Error()
+		return false
+	}
+	_, path, err := m.noteManager.AddImage(note.ID, data)
+	if err != nil {
+		m.flash = err.Error()
+		return false
+	}
+	m.flash = fmt.Sprintf("Saved %s and linked it in '%s'", path, note.Title)
+	return true
+}
@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"burh/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// saveDraft keeps the create or edit form's input on disk after every change, so
+// it can be restored if the TUI crashes or the form is left by accident. An empty
+// form has nothing worth keeping.
+func (m *Model) saveDraft() {
+	if m.titleInput == "" && m.tagsInput == "" && m.contentInput == "" {
+		m.clearDraft()
+		return
+	}
+	draft := &config.Draft{
+		Form:    m.state,
+		Title:   m.titleInput,
+		Tags:    m.tagsInput,
+		Format:  m.formatInput,
+		Content: m.contentInput,
+		SavedAt: time.Now(),
+	}
+	if m.state == "edit" && m.currentNote != nil {
+		draft.NoteID = m.currentNote.ID
+	}
+	if err := config.SaveDraft(draft); err != nil {
+		m.flash = err.Error()
+	}
+}
+
+// clearDraft drops the saved draft once the form's input is saved or discarded
+func (m *Model) clearDraft() {
+	if err := config.ClearDraft(); err != nil {
+		m.flash = err.Error()
+	}
+}
+
+// handleRestoreDraftKey answers the prompt to restore a draft left by an earlier run
+func (m *Model) handleRestoreDraftKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		m.restoreDraft()
+	case "n", "esc":
+		m.clearDraft()
+		m.draft = nil
+		m.state = "list"
+	case "ctrl+c":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// restoreDraft reopens the form the draft came from with its input filled in
+func (m *Model) restoreDraft() {
+	draft := m.draft
+	m.draft = nil
+	m.state = "create"
+	if draft.Form == "edit" {
+		note, err := m.noteManager.GetNote(draft.NoteID)
+		if err != nil {
+			// The note is gone; keep the writing as a new note instead
+			m.flash = fmt.Sprintf("%s no longer exists; restored as a new note", draft.NoteID)
+		} else {
+			m.currentNote = note
+			m.state = "edit"
+		}
+	}
+	m.titleInput = draft.Title
+	m.tagsInput = draft.Tags
+	m.formatInput = draft.Format
+	if m.formatInput == "" {
+		m.formatInput = "txt"
+	}
+	m.contentInput = draft.Content
+	m.currentField = 0
+	m.loadTagCounts()
+}
+
+// renderRestoreDraft renders the prompt to restore a draft
+func (m *Model) renderRestoreDraft() string {
+	var sb strings.Builder
+
+	sb.WriteString(m.styles.title.Render("UNSAVED DRAFT"))
+	sb.WriteString("\n\n")
+
+	what := "a new note"
+	if m.draft.Form == "edit" {
+		what = "an edit of " + m.draft.NoteID
+	}
+	sb.WriteString(fmt.Sprintf("  You left %s unsaved on %s.\n\n", what, m.draft.SavedAt.Format("2006-01-02 15:04")))
+	if m.draft.Title != "" {
+		sb.WriteString("  Title: " + m.draft.Title + "\n")
+	}
+	if m.draft.Content != "" {
+		sb.WriteString("  Content: " + truncate(m.draft.Content, 60) + "\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(m.styles.muted.Render("  y: restore it | n: discard it"))
+
+	return m.styles.border.Render(sb.String())
+}
+
+// keepDraft saves a draft after a key is handled in the create or edit form, while
+// the form is still open. Saving the form clears the draft; leaving it keeps it.
+func (m *Model) keepDraft(model tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	if m.state == "create" || m.state == "edit" {
+		m.saveDraft()
+	}
+	return model, cmd
+}
+
+// requestQuit quits, first asking when confirmations.quit_with_draft is on and
+// form input would be left behind as a draft
+func (m *Model) requestQuit() (tea.Model, tea.Cmd) {
+	if !m.config.Confirmations.QuitWithDraft || !m.hasDraft() {
+		return m.quit()
+	}
+	m.quitFrom = m.state
+	m.state = "confirm_quit"
+	return m, nil
+}
+
+// hasDraft checks if the open form has input, or a draft was kept from one left earlier
+func (m *Model) hasDraft() bool {
+	if m.state == "create" || m.state == "edit" {
+		return m.titleInput != "" || m.tagsInput != "" || m.contentInput != ""
+	}
+	draft, _ := config.LoadDraft()
+	return draft != nil
+}
+
+// handleConfirmQuitKey answers the prompt to quit with a draft left
+func (m *Model) handleConfirmQuitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter", "ctrl+c":
+		return m.quit()
+	case "n", "esc":
+		m.state = m.quitFrom
+	}
+	return m, nil
+}
+
+// renderConfirmQuit renders the prompt to quit with a draft left
+func (m *Model) renderConfirmQuit() string {
+	var sb strings.Builder
+
+	sb.WriteString(m.styles.title.Render("UNSAVED DRAFT"))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.styles.warn("  Quit with unsaved input? It is kept as a draft and offered when burh next starts."))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.styles.muted.Render("  y: quit | n: go back"))
+
+	return m.styles.border.Render(sb.String())
+}
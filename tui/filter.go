@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filterChip is one active search filter, shown as a removable chip above the list
+type filterChip struct {
+	key   string // "text" for free text, otherwise the query key, e.g. "tag" or "after"
+	value string
+}
+
+// label returns the chip text, e.g. "tag: work"
+func (c filterChip) label() string {
+	return c.key + ": " + c.value
+}
+
+// term returns the chip as a search query term
+func (c filterChip) term() string {
+	if c.key == "text" {
+		return c.value
+	}
+	return c.key + ":" + c.value
+}
+
+// queryChips splits a search query into chips: one per filter term, such as
+// tag:work, plus one for all the free text, including terms like URLs that only
+// look like filters
+func (m *Model) queryChips(query string) []filterChip {
+	var chips []filterChip
+	var text []string
+	for _, term := range strings.Fields(query) {
+		key, value, found := strings.Cut(term, ":")
+		if !found || value == "" || !m.noteManager.IsQueryKey(key) {
+			text = append(text, term)
+			continue
+		}
+		chips = append(chips, filterChip{key: strings.ToLower(key), value: value})
+	}
+	if len(text) > 0 {
+		chips = append(chips, filterChip{key: "text", value: strings.Join(text, " ")})
+	}
+	return chips
+}
+
+// setFilters replaces the active filters, made by the given kind of search, and focuses the last chip
+func (m *Model) setFilters(kind string, chips []filterChip) {
+	m.filterKind = kind
+	m.filters = chips
+	m.chipFocus = len(chips) - 1
+}
+
+// filterQuery joins chips back into a search query
+func filterQuery(chips []filterChip) string {
+	terms := make([]string, len(chips))
+	for i, chip := range chips {
+		terms[i] = chip.term()
+	}
+	return strings.Join(terms, " ")
+}
+
+// filterText describes the active filters for the status bar
+func (m *Model) filterText() string {
+	labels := make([]string, len(m.filters))
+	for i, chip := range m.filters {
+		labels[i] = chip.label()
+	}
+	return strings.Join(labels, ", ")
+}
+
+// removeFilter drops the chip at index i and reruns the search with the chips that are left
+func (m *Model) removeFilter(i int) tea.Cmd {
+	if i < 0 || i >= len(m.filters) {
+		return nil
+	}
+	chips := append(append([]filterChip{}, m.filters[:i]...), m.filters[i+1:]...)
+	if len(chips) == 0 {
+		return m.loadNotesCmd()
+	}
+	return m.streamSearchCmd(filterQuery(chips))
+}
+
+// renderChips renders the active filters, highlighting the focused chip
+func (m *Model) renderChips() string {
+	var sb strings.Builder
+	sb.WriteString(" ")
+	for i, chip := range m.filters {
+		style := m.styles.info
+		if i == m.chipFocus {
+			style = m.styles.selected
+		}
+		sb.WriteString(" ")
+		sb.WriteString(style.Render("[" + chip.label() + " ✕]"))
+	}
+	sb.WriteString(m.styles.muted.Render("   tab: next filter | x: remove | X: clear all"))
+	return sb.String()
+}
@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"burh/notes"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+// focusTickMsg asks focus mode to autosave; seq ties it to one focus session
+type focusTickMsg struct {
+	seq int
+}
+
+// enterFocus opens the selected note in focus mode: its content alone, full screen,
+// typed at the end
+func (m *Model) enterFocus() tea.Cmd {
+	if len(m.notes) == 0 || m.selected >= len(m.notes) {
+		return nil
+	}
+	note := m.notes[m.selected]
+	if !notes.WritableFormat(note.Format) {
+		m.flash = fmt.Sprintf("'%s' is a %s file and cannot be edited here", note.Title, note.Format)
+		return nil
+	}
+	if note.Locked {
+		m.flash = fmt.Sprintf("'%s' is locked (L to unlock)", note.Title)
+		return nil
+	}
+	if m.noteManager.IsReadOnlyDir(note.Dir) {
+		m.flash = fmt.Sprintf("'%s' is in a read-only directory", note.Title)
+		return nil
+	}
+	if err := m.noteManager.LoadContent(note); err != nil {
+		m.flash = err.Error()
+		return nil
+	}
+
+	m.cancelLoad()
+	m.flash = ""
+	m.state = "focus"
+	m.focusNote = note
+	m.focusText = note.Content
+	m.focusDirty = false
+	m.focusSavedAt = time.Time{}
+	m.focusSeq++
+	return m.focusTickCmd()
+}
+
+// focusTickCmd schedules the next autosave, if autosave is on
+func (m *Model) focusTickCmd() tea.Cmd {
+	interval, _ := m.config.FocusAutosaveInterval()
+	if interval <= 0 {
+		return nil
+	}
+	seq := m.focusSeq
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return focusTickMsg{seq: seq}
+	})
+}
+
+// handleFocusTick autosaves unsaved writing and schedules the next save
+func (m *Model) handleFocusTick(msg focusTickMsg) tea.Cmd {
+	if m.state != "focus" || msg.seq != m.focusSeq {
+		return nil
+	}
+	m.saveFocus()
+	return m.focusTickCmd()
+}
+
+// saveFocus writes the text to the note if it changed since the last save
+func (m *Model) saveFocus() {
+	if !m.focusDirty {
+		return
+	}
+	note := m.focusNote
+	if _, err := m.noteManager.UpdateNote(note.ID, note.Title, m.focusText, note.Tags); err != nil {
+		m.flash = err.Error()
+		return
+	}
+	m.focusDirty = false
+	m.focusSavedAt = time.Now()
+}
+
+// handleFocusKey handles key events in focus mode: typing appends to the note
+func (m *Model) handleFocusKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.saveFocus()
+		return m.quit()
+	case tea.KeyEsc:
+		m.saveFocus()
+		m.focusSeq++ // Stop the autosave ticks
+		m.focusNote = nil
+		m.state = "list"
+		return m, m.loadNotesCmd()
+	case tea.KeyCtrlS:
+		m.saveFocus()
+		return m, nil
+	case tea.KeyEnter:
+		m.focusText += "\n"
+	case tea.KeyTab:
+		m.focusText += "\t"
+	case tea.KeySpace:
+		m.focusText += " "
+	case tea.KeyBackspace:
+		if runes := []rune(m.focusText); len(runes) > 0 {
+			m.focusText = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes:
+		m.focusText += string(msg.Runes)
+	default:
+		return m, nil
+	}
+	m.focusDirty = true
+	return m, nil
+}
+
+// renderFocus renders focus mode: the note's text wrapped in a centered column,
+// scrolled to the end where the writing happens, and the word count in the corner
+func (m *Model) renderFocus() string {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		width, height = 80, 24
+	}
+	column := m.config.FocusWidth
+	if column <= 0 || column > width-4 {
+		column = max(20, width-4)
+	}
+
+	text := strings.ReplaceAll(m.focusText, "\t", "    ")
+	wrapped := lipgloss.NewStyle().Width(column).Render(text + "█")
+	lines := strings.Split(wrapped, "\n")
+	// Leave a blank line above and the counter line below
+	if visible := height - 2; visible > 0 && len(lines) > visible {
+		lines = lines[len(lines)-visible:]
+	}
+
+	margin := strings.Repeat(" ", max(0, (width-column)/2))
+	var sb strings.Builder
+	sb.WriteString("\n")
+	for _, line := range lines {
+		sb.WriteString(margin + line + "\n")
+	}
+	for i := len(lines) + 2; i < height; i++ {
+		sb.WriteString("\n")
+	}
+
+	words := len(strings.Fields(m.focusText))
+	status := fmt.Sprintf("%d words", words)
+	if words == 1 {
+		status = "1 word"
+	}
+	switch {
+	case m.flash != "":
+		status = m.flash + " · " + status
+	case m.focusDirty:
+		status += " · unsaved"
+	case !m.focusSavedAt.IsZero():
+		status += " · saved " + m.focusSavedAt.Format("15:04:05")
+	}
+	status += " · esc: done"
+	sb.WriteString(m.styles.muted.Render(lipgloss.PlaceHorizontal(width-1, lipgloss.Right, status)))
+	return sb.String()
+}
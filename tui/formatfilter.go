@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"burh/notes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cycleFormatFilter steps a format: filter on the current keyword search through
+// the note formats, md, org, txt, and back to none
+func (m *Model) cycleFormatFilter() tea.Cmd {
+	names := notes.FormatNames()
+	next := names[0]
+	var chips []filterChip
+	if m.filterKind == "keyword" {
+		for _, chip := range m.filters {
+			if chip.key != "format" {
+				chips = append(chips, chip)
+				continue
+			}
+			next = ""
+			for i, name := range names {
+				if name == chip.value && i+1 < len(names) {
+					next = names[i+1]
+				}
+			}
+		}
+	}
+
+	if next != "" {
+		chips = append(chips, filterChip{key: "format", value: next})
+	}
+	if len(chips) == 0 {
+		return m.loadNotesCmd()
+	}
+	return m.streamSearchCmd(filterQuery(chips))
+}
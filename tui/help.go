@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"burh/config"
+	"burh/i18n"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpMotions are the note list's keys that keys: can't move, as the help lists them
+var helpMotions = []config.KeyAction{
+	{Key: "j/k", Help: "down/up"},
+	{Key: "5j", Help: "move 5 notes"},
+	{Key: "gg/G", Help: "first/last"},
+	{Key: "20G", Help: "note 20"},
+	{Key: "12", Help: "open row 12"},
+	{Key: "ctrl+d/u", Help: "half page"},
+}
+
+// actionKey returns the key a note list action is bound to: its key under keys:,
+// or its default key
+func (m *Model) actionKey(action string) string {
+	if key, ok := m.config.Keys[action]; ok {
+		return key
+	}
+	return config.DefaultKey(action)
+}
+
+// handleHelpKey closes the help on any key
+func (m *Model) handleHelpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m.requestQuit()
+	}
+	m.state = "list"
+	return m, nil
+}
+
+// renderHelp renders every note list key with what it does, in as many columns
+// as fit the terminal. Keys moved under keys: are shown where they are now.
+func (m *Model) renderHelp() string {
+	var sb strings.Builder
+
+	terminalWidth := getTerminalWidth()
+	centeredHeader, _ := centerText(i18n.T("BURH - KEYS"), terminalWidth)
+	sb.WriteString(m.styles.title.Render(centeredHeader))
+	sb.WriteString("\n\n")
+
+	var entries []config.KeyAction
+	for _, action := range config.KeyActions {
+		entries = append(entries, config.KeyAction{Key: m.actionKey(action.Name), Help: action.Help})
+	}
+	entries = append(entries, helpMotions...)
+
+	keyWidth, entryWidth := 0, 0
+	for _, e := range entries {
+		keyWidth = max(keyWidth, lipgloss.Width(e.Key))
+	}
+	for _, e := range entries {
+		entryWidth = max(entryWidth, keyWidth+2+lipgloss.Width(i18n.T(e.Help)))
+	}
+	entryWidth += 4
+
+	columns := max(1, (terminalWidth-4)/entryWidth)
+	rows := (len(entries) + columns - 1) / columns
+	for row := 0; row < rows; row++ {
+		sb.WriteString("  ")
+		for col := 0; col < columns; col++ {
+			i := col*rows + row
+			if i >= len(entries) {
+				break
+			}
+			key := fmt.Sprintf("%-*s", keyWidth, entries[i].Key)
+			cell := m.styles.primary.Render(key) + "  " + i18n.T(entries[i].Help)
+			sb.WriteString(cell + strings.Repeat(" ", max(0, entryWidth-lipgloss.Width(cell))))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(m.styles.muted.Render("  " + i18n.T("Any key: back to the list")))
+
+	return m.styles.border.Render(sb.String())
+}
@@ -0,0 +1,373 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	_ "image/gif" // Decoders for image.Decode
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+const (
+	// imageMaxRows is the most lines an image takes in the outline preview
+	imageMaxRows = 10
+	// imageMarker marks where an image goes in a rendered view: the first of
+	// imageMarker+0, imageMarker+1, ... for each image, all one cell wide. They are
+	// private use characters, so never in notes, and are hidden before drawing.
+	imageMarker = '\uE000'
+	// imageDrawDelay gives the renderer time to draw a frame before images are
+	// drawn over it
+	imageDrawDelay = 50 * time.Millisecond
+	// cellWidth and cellHeight are the pixel size assumed for a terminal cell when
+	// scaling sixel images, which unlike the others can't be sized in cells
+	cellWidth  = 10
+	cellHeight = 20
+	// kittyDeleteAll deletes every image kitty shows
+	kittyDeleteAll = "\x1b_Ga=d,d=A,q=2\x1b\\"
+)
+
+// imageExtensions are the image files the preview can show
+var imageExtensions = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true}
+
+// imageLinkPatterns match a line that is only an image link: ![alt](path) in
+// markdown, [[file:path]] or [[path]] in org, as 'burh paste-image' writes them
+var imageLinkPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^!\[[^\]]*\]\(([^)\s]+)(?:\s+"[^"]*")?\)$`),
+	regexp.MustCompile(`^\[\[(?:file:)?([^\]]+)\]\]$`),
+}
+
+// previewImage is an image placed in the rendered outline preview
+type previewImage struct {
+	path       string
+	cols, rows int
+}
+
+// imageDrawMsg asks the TUI to draw the preview's images over the rendered frame
+type imageDrawMsg struct{}
+
+// imageProtocol returns the terminal graphics protocol images are drawn with:
+// display.images if set, or with auto the one the terminal is known to support.
+// It is empty when images are shown as placeholders instead, including in tmux,
+// which doesn't pass graphics through by default.
+func (m *Model) imageProtocol() string {
+	switch m.config.Display.Images {
+	case "kitty", "iterm", "sixel":
+		return m.config.Display.Images
+	case "off":
+		return ""
+	}
+	if os.Getenv("TMUX") != "" {
+		return ""
+	}
+	termName, program := os.Getenv("TERM"), os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || strings.Contains(termName, "kitty") || program == "ghostty":
+		return "kitty"
+	case program == "iTerm.app" || program == "WezTerm":
+		return "iterm"
+	case strings.HasPrefix(termName, "foot") || strings.HasPrefix(termName, "mlterm"):
+		return "sixel"
+	}
+	return ""
+}
+
+// imageRef returns the image file a note line links to, as written in the note.
+// Links to web images and to files that aren't images are ignored.
+func imageRef(line string) string {
+	line = strings.TrimSpace(line)
+	ref := line // A path alone, as in text notes
+	for _, pattern := range imageLinkPatterns {
+		if match := pattern.FindStringSubmatch(line); match != nil {
+			ref = match[1]
+			break
+		}
+	}
+	if strings.Contains(ref, "://") || strings.ContainsAny(ref, " \t") || !imageExtensions[strings.ToLower(filepath.Ext(ref))] {
+		return ""
+	}
+	return ref
+}
+
+// renderPreviewImage renders the image a preview line links to in at most width
+// columns and rows lines: space marked for it to be drawn in, or a placeholder
+// naming it when the terminal can't show images. It returns the lines taken, 0 if
+// the line isn't an image.
+func (m *Model) renderPreviewImage(line, noteDir string, width, rows int) (string, int) {
+	ref := imageRef(line)
+	if ref == "" {
+		return "", 0
+	}
+	path := filepath.FromSlash(ref)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(noteDir, path)
+	}
+	cols, imgRows, err := imageCells(path, width, min(rows, imageMaxRows))
+	if err != nil {
+		// Room for the warning marker
+		return m.styles.warn(truncate("[image not found: "+ref+"]", width-4)) + "\n", 1
+	}
+	if m.imageProtocol() == "" || len(m.previewImages) >= 64 {
+		return m.styles.info.Render(truncate("[image: "+ref+"]", width)) + "\n", 1
+	}
+
+	marker := string(rune(imageMarker + len(m.previewImages)))
+	m.previewImages = append(m.previewImages, previewImage{path: path, cols: cols, rows: imgRows})
+	return marker + strings.Repeat("\n", imgRows), imgRows
+}
+
+// imageCells returns the columns and lines an image fits in, keeping its aspect
+// ratio, taking a cell to be twice as tall as it is wide
+func imageCells(path string, maxCols, maxRows int) (int, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, err
+	}
+	if cfg.Width == 0 || cfg.Height == 0 || maxCols <= 0 || maxRows <= 0 {
+		return 0, 0, fmt.Errorf("%s is empty", path)
+	}
+
+	rows := maxRows
+	cols := rows * 2 * cfg.Width / cfg.Height
+	if cols > maxCols {
+		cols = maxCols
+		rows = max(1, cols*cfg.Height/(2*cfg.Width))
+	}
+	return max(1, cols), rows, nil
+}
+
+// hideImageMarkers blanks the image markers in a rendered view
+func hideImageMarkers(view string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= imageMarker && r < imageMarker+64 {
+			return ' '
+		}
+		return r
+	}, view)
+}
+
+// drawImagesCmd schedules drawing the preview's images once the frame is shown
+func drawImagesCmd() tea.Cmd {
+	return tea.Tick(imageDrawDelay, func(time.Time) tea.Msg {
+		return imageDrawMsg{}
+	})
+}
+
+// drawImages draws the outline preview's images over the rendered frame, writing
+// straight to the terminal since the renderer can't carry graphics. Images that
+// moved or are no longer shown are removed first: kitty deletes them, and the
+// others are erased by clearing the screen and drawing again.
+func (m *Model) drawImages() tea.Cmd {
+	var view string
+	if m.state == "outline" {
+		view = m.renderState() + "\n" + m.renderStatusBar()
+	}
+
+	// Find each image's marker; lines above the terminal's top are cut by the renderer
+	lines := strings.Split(view, "\n")
+	_, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		height = len(lines)
+	}
+	top := max(0, len(lines)-height)
+	var seqs []string
+	var key strings.Builder
+	for row := top; row < len(lines); row++ {
+		for i, img := range m.previewImages {
+			at := strings.IndexRune(lines[row], rune(imageMarker+i))
+			if at < 0 || row-top+img.rows > height {
+				continue // Not shown, or would scroll the screen
+			}
+			col := lipgloss.Width(lines[row][:at])
+			fmt.Fprintf(&key, "%d,%d,%d,%d,%s;", row, col, img.cols, img.rows, img.path)
+			seq, err := m.encodeImage(img)
+			if err != nil {
+				continue
+			}
+			// Save the cursor, draw at the marker (1-based), and put the cursor back
+			seqs = append(seqs, fmt.Sprintf("\x1b7\x1b[%d;%dH%s\x1b8", row-top+1, col+1, seq))
+		}
+	}
+	if key.String() == m.drawnImages {
+		return nil
+	}
+
+	protocol := m.imageProtocol()
+	if m.drawnImages != "" && protocol != "kitty" {
+		// Erase the old images, then draw again on the repainted screen
+		m.drawnImages = ""
+		return tea.Sequence(tea.ClearScreen, drawImagesCmd())
+	}
+	out := strings.Join(seqs, "")
+	if protocol == "kitty" {
+		out = kittyDeleteAll + out
+	}
+	os.Stdout.WriteString(out)
+	m.drawnImages = key.String()
+	return nil
+}
+
+// clearImages removes the drawn images before the TUI leaves the screen, for an
+// editor or on quitting. Only kitty's need deleting; the others go with the text.
+func (m *Model) clearImages() {
+	if m.drawnImages != "" && m.imageProtocol() == "kitty" {
+		os.Stdout.WriteString(kittyDeleteAll)
+	}
+	m.drawnImages = ""
+}
+
+// encodeImage returns the escape sequence that draws an image with the terminal's
+// protocol, scaled to the cells it was given. Sequences are kept for redraws.
+func (m *Model) encodeImage(img previewImage) (string, error) {
+	protocol := m.imageProtocol()
+	cacheKey := fmt.Sprintf("%s:%s:%dx%d", protocol, img.path, img.cols, img.rows)
+	if seq, ok := m.imageCache[cacheKey]; ok {
+		return seq, nil
+	}
+	data, err := os.ReadFile(img.path)
+	if err != nil {
+		return "", err
+	}
+
+	var seq string
+	switch protocol {
+	case "iterm":
+		seq = fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a",
+			len(data), img.cols, img.rows, base64.StdEncoding.EncodeToString(data))
+	case "kitty":
+		seq, err = kittyImage(data, img.cols, img.rows)
+	case "sixel":
+		seq, err = sixelImage(data, img.cols*cellWidth, img.rows*cellHeight)
+	}
+	if err != nil {
+		return "", err
+	}
+	if m.imageCache == nil {
+		m.imageCache = make(map[string]string)
+	}
+	m.imageCache[cacheKey] = seq
+	return seq, nil
+}
+
+// kittyImage draws PNG data, converting other formats, with the kitty graphics
+// protocol in chunks of at most 4096 bytes. q=2 stops the terminal replying, which
+// would arrive as key presses, and C=1 leaves the cursor where it is.
+func kittyImage(data []byte, cols, rows int) (string, error) {
+	if !bytes.HasPrefix(data, []byte("\x89PNG")) {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return "", err
+		}
+		data = buf.Bytes()
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var sb strings.Builder
+	for i := 0; i < len(encoded); i += 4096 {
+		chunk := encoded[i:min(i+4096, len(encoded))]
+		more := 0
+		if i+4096 < len(encoded) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(&sb, "\x1b_Ga=T,f=100,c=%d,r=%d,C=1,q=2,m=%d;%s\x1b\\", cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(&sb, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return sb.String(), nil
+}
+
+// sixelImage draws an image scaled to fit width by height pixels as sixels, in
+// the 256 colors of the Plan 9 palette
+func sixelImage(data []byte, width, height int) (string, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	bounds := src.Bounds()
+	scale := min(float64(width)/float64(bounds.Dx()), float64(height)/float64(bounds.Dy()))
+	w, h := max(1, int(float64(bounds.Dx())*scale)), max(1, int(float64(bounds.Dy())*scale))
+
+	// Scale by nearest neighbour, then dither to the palette
+	scaled := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			scaled.Set(x, y, src.At(bounds.Min.X+x*bounds.Dx()/w, bounds.Min.Y+y*bounds.Dy()/h))
+		}
+	}
+	img := image.NewPaletted(scaled.Bounds(), palette.Plan9)
+	draw.FloydSteinberg.Draw(img, img.Bounds(), scaled, image.Point{})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\x1bPq\"1;1;%d;%d", w, h)
+	for i, c := range img.Palette {
+		r, g, b, _ := c.RGBA()
+		fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, b*100/0xffff)
+	}
+	// Each band is six pixel rows, drawn once per color in it
+	for band := 0; band < h; band += 6 {
+		bits := make(map[uint8][]byte)
+		for dy := 0; dy < 6 && band+dy < h; dy++ {
+			for x := 0; x < w; x++ {
+				c := img.ColorIndexAt(x, band+dy)
+				if bits[c] == nil {
+					bits[c] = make([]byte, w)
+				}
+				bits[c][x] |= 1 << dy
+			}
+		}
+		colors := make([]int, 0, len(bits))
+		for c := range bits {
+			colors = append(colors, int(c))
+		}
+		sort.Ints(colors)
+		for _, c := range colors {
+			fmt.Fprintf(&sb, "#%d", c)
+			writeSixels(&sb, bits[uint8(c)])
+			sb.WriteString("$") // Back to the start of the band
+		}
+		sb.WriteString("-") // Next band
+	}
+	sb.WriteString("\x1b\\")
+	return sb.String(), nil
+}
+
+// writeSixels writes a row of sixels, run-length encoding repeats
+func writeSixels(sb *strings.Builder, row []byte) {
+	for x := 0; x < len(row); {
+		run := 1
+		for x+run < len(row) && row[x+run] == row[x] {
+			run++
+		}
+		char := rune(63 + row[x])
+		if run > 3 {
+			fmt.Fprintf(sb, "!%d%c", run, char)
+		} else {
+			sb.WriteString(strings.Repeat(string(char), run))
+		}
+		x += run
+	}
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startInline opens a single-line prompt on the selected row for its title or tags
+func (m *Model) startInline(field string) {
+	if len(m.notes) == 0 || m.selected >= len(m.notes) {
+		return
+	}
+	note := m.notes[m.selected]
+	m.inlineField = field
+	m.inlineError = ""
+	if field == "title" {
+		m.inlineInput = note.Title
+	} else {
+		m.inlineInput = strings.Join(note.Tags, ", ")
+	}
+}
+
+// handleInlineKey handles key events while the inline prompt is open
+func (m *Model) handleInlineKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.inlineField = ""
+	case "enter":
+		m.saveInline()
+	case "backspace":
+		if len(m.inlineInput) > 0 {
+			r := []rune(m.inlineInput)
+			m.inlineInput = string(r[:len(r)-1])
+		}
+	case "ctrl+u":
+		m.inlineInput = ""
+	case " ":
+		m.inlineInput += " "
+	default:
+		if msg.Type == tea.KeyRunes {
+			m.inlineInput += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+// saveInline writes the prompt's value to the selected note and closes the prompt
+func (m *Model) saveInline() {
+	note := m.notes[m.selected]
+
+	var err error
+	if m.inlineField == "title" {
+		err = m.noteManager.RenameNote(note, m.inlineInput)
+	} else {
+		var tags []string
+		for _, tag := range strings.Split(m.inlineInput, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		// Drop every current tag and add the edited list
+		err = m.noteManager.UpdateTags(note, tags, note.Tags)
+	}
+	if err != nil {
+		m.inlineError = err.Error()
+		return
+	}
+
+	m.inlineField = ""
+	m.inlineError = ""
+}
@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"burh/notes"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// board returns the kanban board set up in the config
+func (m *Model) board() notes.Board {
+	columns := m.config.KanbanColumns
+	if len(columns) == 0 {
+		columns = []string{"todo", "doing", "done"}
+	}
+	return notes.Board{ByTag: m.config.KanbanBy == "tag", Columns: columns}
+}
+
+// enterKanban shows the listed notes on the kanban board
+func (m *Model) enterKanban() {
+	m.kanbanColumn, m.kanbanRow = 0, 0
+	m.state = "kanban"
+}
+
+// kanbanSelected returns the selected note on the board, or nil if its column is empty
+func (m *Model) kanbanSelected(columns [][]*notes.Note) *notes.Note {
+	column := columns[m.kanbanColumn]
+	if m.kanbanRow < len(column) {
+		return column[m.kanbanRow]
+	}
+	return nil
+}
+
+// handleKanbanKey handles key events on the kanban board
+func (m *Model) handleKanbanKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	board := m.board()
+	columns := board.Arrange(m.notes)
+	m.flash = ""
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m.quit()
+	case "b", "esc":
+		m.state = "list"
+	case "j", "down":
+		if m.kanbanRow < len(columns[m.kanbanColumn])-1 {
+			m.kanbanRow++
+		}
+	case "k", "up":
+		if m.kanbanRow > 0 {
+			m.kanbanRow--
+		}
+	case "left", "shift+tab":
+		if m.kanbanColumn > 0 {
+			m.kanbanColumn--
+		}
+	case "right", "tab":
+		if m.kanbanColumn < len(columns)-1 {
+			m.kanbanColumn++
+		}
+	case "h", "l":
+		// Move the selected note one column over, following it
+		note := m.kanbanSelected(columns)
+		to := m.kanbanColumn + 1
+		if msg.String() == "h" {
+			to = m.kanbanColumn - 1
+		}
+		if note == nil || to < 0 || to >= len(columns) {
+			break
+		}
+		if err := m.noteManager.MoveToColumn(note, board, to); err != nil {
+			m.flash = err.Error()
+			break
+		}
+		m.kanbanColumn = to
+		for i, n := range board.Arrange(m.notes)[to] {
+			if n == note {
+				m.kanbanRow = i
+			}
+		}
+		return m, nil
+	case "enter":
+		if note := m.kanbanSelected(columns); note != nil {
+			return m, m.openNote(note)
+		}
+	}
+
+	m.kanbanRow = min(m.kanbanRow, max(0, len(columns[m.kanbanColumn])-1))
+	return m, nil
+}
+
+// renderKanban renders the board's columns side by side
+func (m *Model) renderKanban() string {
+	var sb strings.Builder
+
+	terminalWidth := getTerminalWidth()
+	centeredHeader, _ := centerText("BURH - BOARD", terminalWidth)
+	sb.WriteString(m.styles.title.Render(centeredHeader))
+	sb.WriteString("\n\n")
+
+	help := m.styles.muted.Render("  j/k: note | ←/→: column | h/l: move note | enter: edit | b/esc: list | q: quit")
+	sb.WriteString(help)
+	sb.WriteString("\n\n")
+
+	board := m.board()
+	columns := board.Arrange(m.notes)
+	m.kanbanRow = min(m.kanbanRow, max(0, len(columns[m.kanbanColumn])-1))
+
+	width := max(20, (terminalWidth-8)/len(columns)-3)
+	var boxes []string
+	for i, column := range columns {
+		var col strings.Builder
+		col.WriteString(m.styles.primary.Render(truncate(fmt.Sprintf("%s (%d)", board.Columns[i], len(column)), width-2)))
+		col.WriteString("\n\n")
+		if len(column) == 0 {
+			col.WriteString(m.styles.muted.Render("No notes"))
+		}
+
+		start := 0
+		if i == m.kanbanColumn {
+			start = max(0, m.kanbanRow-m.pageSize+1)
+		}
+		for j := start; j < len(column) && j < start+m.pageSize; j++ {
+			style := m.styles.item
+			row := truncate(column[j].Title, width-2)
+			if i == m.kanbanColumn && j == m.kanbanRow {
+				style = m.styles.selected
+				row = m.styles.markSelected(row)
+			}
+			col.WriteString(style.Render(row))
+			col.WriteString("\n")
+		}
+
+		borderColor := m.styles.theme.Muted
+		if i == m.kanbanColumn {
+			borderColor = m.styles.theme.Primary
+		}
+		boxes = append(boxes, lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color(borderColor)).
+			Width(width).
+			Render(col.String()), " ")
+	}
+	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, boxes...))
+
+	return m.styles.border.Render(sb.String())
+}
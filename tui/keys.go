@@ -0,0 +1,21 @@
+package tui
+
+import "burh/config"
+
+// listKey returns the default key of the note list action a pressed key is
+// bound to under keys:, which the list's key handling is written against, and
+// whether keys: binds it. A default key whose action was moved to another key
+// does nothing, unless keys: gives it to another action.
+func (m *Model) listKey(pressed string) (string, bool) {
+	for action, key := range m.config.Keys {
+		if key == pressed {
+			return config.DefaultKey(action), true
+		}
+	}
+	for action := range m.config.Keys {
+		if config.DefaultKey(action) == pressed {
+			return "", false
+		}
+	}
+	return pressed, false
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+)
+
+// listChrome is how many lines of the list view aren't note rows: the border, header,
+// help, column header, pagination, page hints, and status bar, with room for the
+// filter chips or the on-this-day banner
+const listChrome = 16
+
+// fitPageSize sets how many notes a page shows: page_size from the config, or as
+// many rows as fit in a terminal of the height at the configured density
+func (m *Model) fitPageSize(height int) {
+	if m.config.PageSize > 0 {
+		m.pageSize = m.config.PageSize
+		return
+	}
+	if height <= 0 {
+		_, h, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			h = 45 // Fits the 29 rows shown before pages followed the terminal
+		}
+		height = h
+	}
+	m.pageSize = max(5, (height-listChrome)/m.rowHeight())
+}
+
+// rowHeight returns the lines each note row takes at the configured density
+func (m *Model) rowHeight() int {
+	if m.config.ListDensity == "comfortable" {
+		return 2 // A blank line after each row
+	}
+	return 1
+}
+
+// handleWindowSize refits the page to a resized terminal, keeping the selected
+// note in view
+func (m *Model) handleWindowSize(msg tea.WindowSizeMsg) {
+	m.fitPageSize(msg.Height)
+	if m.selected >= m.startIndex+m.pageSize {
+		m.startIndex = m.selected - m.pageSize + 1
+	}
+}
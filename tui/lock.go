@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"fmt"
+
+	"burh/notes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openNote opens a note in the editor unless it is locked or in a read-only directory.
+// Binary notes, such as PDFs, open in their default application.
+func (m *Model) openNote(note *notes.Note) tea.Cmd {
+	return m.openNoteAt(note, 0)
+}
+
+// openNoteAt is openNote with the editor's cursor on a 1-based line of the note file
+func (m *Model) openNoteAt(note *notes.Note, line int) tea.Cmd {
+	if !notes.WritableFormat(note.Format) {
+		return openFileCmd(note.Path())
+	}
+	if note.Locked {
+		m.flash = fmt.Sprintf("'%s' is locked (L to unlock)", note.Title)
+		m.splitStatus = m.flash
+		return nil
+	}
+	if m.noteManager.IsReadOnlyDir(note.Dir) {
+		m.flash = fmt.Sprintf("'%s' is in a read-only notes directory", note.Title)
+		m.splitStatus = m.flash
+		return nil
+	}
+	return openEditorCmd(m.noteManager, note, line)
+}
+
+// matchLine returns the line of a note where the active keyword search matches, so
+// the editor can open there, or 0 when no keyword search is active
+func (m *Model) matchLine(note *notes.Note) int {
+	if m.filterKind != "keyword" || len(m.filters) == 0 {
+		return 0
+	}
+	line, _ := m.noteManager.MatchLine(note, filterQuery(m.filters))
+	return line
+}
+
+// toggleLock locks or unlocks the selected note
+func (m *Model) toggleLock() {
+	if len(m.notes) == 0 || m.selected >= len(m.notes) {
+		return
+	}
+	note := m.notes[m.selected]
+
+	if err := m.noteManager.SetLocked(note, !note.Locked); err != nil {
+		m.flash = err.Error()
+		return
+	}
+	if note.Locked {
+		m.flash = fmt.Sprintf("Locked '%s'", note.Title)
+	} else {
+		m.flash = fmt.Sprintf("Unlocked '%s'", note.Title)
+	}
+}
@@ -0,0 +1,80 @@
+package tui
+
+import "strconv"
+
+// handleMotion applies vim-style movement keys in the list view: count
+// prefixes (5j, 10k), gg/G, and ctrl+d/ctrl+u half-page scrolling.
+// It reports whether the key was consumed.
+func (m *Model) handleMotion(key string) bool {
+	// Digits build up a count; a leading 0 is not a count
+	if len(key) == 1 && key[0] >= '0' && key[0] <= '9' && (key != "0" || m.countPrefix != "") {
+		m.countPrefix += key
+		return true
+	}
+
+	count, err := strconv.Atoi(m.countPrefix)
+	hasCount := err == nil
+	m.countPrefix = ""
+	pendingG := m.pendingG
+	m.pendingG = false
+	if !hasCount {
+		count = 1
+	}
+
+	switch key {
+	case "j", "down":
+		m.moveSelection(count)
+	case "k", "up":
+		m.moveSelection(-count)
+	case "ctrl+d":
+		m.moveSelection(count * m.halfPage())
+	case "ctrl+u":
+		m.moveSelection(-count * m.halfPage())
+	case "g":
+		if !pendingG {
+			m.pendingG = true
+			return true
+		}
+		m.selectIndex(0)
+	case "G":
+		if hasCount {
+			m.selectIndex(count - 1)
+		} else {
+			m.selectIndex(len(m.notes) - 1)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// halfPage returns the distance moved by ctrl+d and ctrl+u
+func (m *Model) halfPage() int {
+	if m.pageSize < 2 {
+		return 1
+	}
+	return m.pageSize / 2
+}
+
+// moveSelection moves the selection by delta notes, stopping at either end
+func (m *Model) moveSelection(delta int) {
+	m.selectIndex(m.selected + delta)
+}
+
+// selectIndex selects the note at index i, clamped to the list, and scrolls it into view
+func (m *Model) selectIndex(i int) {
+	if i >= len(m.notes) {
+		i = len(m.notes) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	m.selected = i
+
+	if m.selected >= m.startIndex+m.pageSize {
+		m.startIndex = m.selected - m.pageSize + 1
+	}
+	if m.selected < m.startIndex {
+		m.startIndex = m.selected
+	}
+}
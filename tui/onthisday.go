@@ -0,0 +1,24 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// onThisDayBanner names the notes created on today's date in earlier years, or
+// returns "" when there are none
+func (m *Model) onThisDayBanner() string {
+	if len(m.onThisDay) == 0 {
+		return ""
+	}
+	const shown = 3
+	var items []string
+	for i, note := range m.onThisDay {
+		if i == shown {
+			items = append(items, fmt.Sprintf("+%d more", len(m.onThisDay)-shown))
+			break
+		}
+		items = append(items, fmt.Sprintf("%d %s", note.Created.Year(), truncate(note.Title, 30)))
+	}
+	return m.styles.info.Render("  On this day: " + strings.Join(items, " · "))
+}
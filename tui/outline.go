@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"burh/notes"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// outlineSidebarWidth is the width of the heading list in the outline view
+const outlineSidebarWidth = 34
+
+// enterOutline shows the selected note with its headings in a sidebar
+func (m *Model) enterOutline() {
+	if len(m.notes) == 0 || m.selected >= len(m.notes) {
+		return
+	}
+	note := m.notes[m.selected]
+	if !notes.WritableFormat(note.Format) {
+		m.flash = fmt.Sprintf("'%s' has no outline", note.Title)
+		return
+	}
+
+	data, err := os.ReadFile(note.Path())
+	if err != nil {
+		m.flash = err.Error()
+		return
+	}
+
+	// Lines of the file itself, so heading line numbers can be passed to the editor
+	text := string(data)
+	m.outlineNote = note
+	m.outlineHeadings = notes.Outline(text, note.Format)
+	m.outlineLines = strings.Split(text, "\n")
+	m.outlineSelected = 0
+	m.state = "outline"
+}
+
+// handleOutlineKey handles key events in the outline view
+func (m *Model) handleOutlineKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		m.clearImages()
+		return m.quit()
+	case "o", "esc":
+		m.state = "list"
+	case "j", "down":
+		if m.outlineSelected < len(m.outlineHeadings)-1 {
+			m.outlineSelected++
+		}
+	case "k", "up":
+		if m.outlineSelected > 0 {
+			m.outlineSelected--
+		}
+	case "g":
+		m.outlineSelected = 0
+	case "G":
+		m.outlineSelected = max(0, len(m.outlineHeadings)-1)
+	case "enter":
+		line := 0
+		if m.outlineSelected < len(m.outlineHeadings) {
+			line = m.outlineHeadings[m.outlineSelected].Line
+		}
+		m.state = "list"
+		m.clearImages()
+		return m, m.openNoteAt(m.outlineNote, line)
+	}
+	return m, drawImagesCmd()
+}
+
+// renderOutline renders the heading sidebar next to the note, scrolled to the selected heading
+func (m *Model) renderOutline() string {
+	var sb strings.Builder
+
+	terminalWidth := getTerminalWidth()
+	centeredHeader, _ := centerText("BURH - "+m.outlineNote.Title, terminalWidth)
+	sb.WriteString(m.styles.title.Render(centeredHeader))
+	sb.WriteString("\n\n")
+
+	help := m.styles.muted.Render("  j/k: heading | g/G: first/last | enter: edit at heading | o/esc: list | q: quit")
+	sb.WriteString(help)
+	sb.WriteString("\n\n")
+
+	// Sidebar: headings indented by level, scrolled to keep the selection visible
+	var side strings.Builder
+	if len(m.outlineHeadings) == 0 {
+		side.WriteString(m.styles.muted.Render("No headings"))
+	}
+	start := max(0, m.outlineSelected-m.pageSize+1)
+	end := min(len(m.outlineHeadings), start+m.pageSize)
+	for i := start; i < end; i++ {
+		h := m.outlineHeadings[i]
+		row := truncate(strings.Repeat("  ", h.Level-1)+h.Text, outlineSidebarWidth-2)
+		style := m.styles.item
+		if i == m.outlineSelected {
+			style = m.styles.selected
+			row = m.styles.markSelected(row)
+		}
+		side.WriteString(style.Render(row))
+		side.WriteString("\n")
+	}
+	sidebar := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.styles.theme.Primary)).
+		Width(outlineSidebarWidth).
+		Render(side.String())
+
+	// Preview: the note from the selected heading on, with its images
+	previewWidth := max(30, terminalWidth-outlineSidebarWidth-12)
+	first := 0
+	if m.outlineSelected < len(m.outlineHeadings) {
+		first = m.outlineHeadings[m.outlineSelected].Line - 1
+	}
+	var preview strings.Builder
+	m.previewImages = nil
+	for i, rows := first, 0; i < len(m.outlineLines) && rows < m.pageSize; i++ {
+		if image, n := m.renderPreviewImage(m.outlineLines[i], filepath.Dir(m.outlineNote.Path()), previewWidth-2, m.pageSize-rows); n > 0 {
+			preview.WriteString(image)
+			rows += n
+			continue
+		}
+		rows++
+		line := truncate(strings.ReplaceAll(m.outlineLines[i], "\t", "    "), previewWidth-2)
+		if i == first && len(m.outlineHeadings) > 0 {
+			preview.WriteString(m.styles.primary.Render(line))
+		} else {
+			preview.WriteString(line)
+		}
+		preview.WriteString("\n")
+	}
+	previewBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.styles.theme.Muted)).
+		Width(previewWidth).
+		Render(preview.String())
+
+	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, sidebar, " ", previewBox))
+	return m.styles.border.Render(sb.String())
+}
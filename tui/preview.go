@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"burh/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// previewWidth is the width of the sample list in a theme preview
+const previewWidth = 64
+
+// ThemePreview renders a theme's colors as swatches, and a sample of the note list
+// and status bar drawn in them
+func ThemePreview(theme config.Theme) string {
+	styles := NewStyles(&config.Config{Theme: theme})
+	var sb strings.Builder
+
+	swatches := []struct{ name, color string }{
+		{"primary", theme.Primary},
+		{"secondary", theme.Secondary},
+		{"success", theme.Success},
+		{"warning", theme.Warning},
+		{"error", theme.Error},
+		{"info", theme.Info},
+		{"muted", theme.Muted},
+	}
+	for i, swatch := range swatches {
+		if i > 0 && i%3 == 0 {
+			sb.WriteString("\n")
+		}
+		block := lipgloss.NewStyle().Foreground(lipgloss.Color(swatch.color)).Render("██")
+		sb.WriteString(fmt.Sprintf("  %s %-9s %-8s", block, swatch.name, swatch.color))
+	}
+	sb.WriteString("\n\n")
+
+	header := fmt.Sprintf("  %*s%-16s  %-6s  %-20s  %s", numberWidth, "", "Date", "Format", "Title", "Tags")
+	sb.WriteString(styles.primary.Render(header) + "\n")
+	sb.WriteString(styles.muted.Render("  "+strings.Repeat("═", previewWidth)) + "\n")
+	sb.WriteString(styles.info.Render("  ── tags: work (2)") + "\n")
+	rows := []struct{ date, format, title, tags string }{
+		{"2026-03-02 09:15", "md", "Weekly review", "work, review"},
+		{"2026-03-01 17:40", "org", "Release checklist", "work"},
+	}
+	for i, row := range rows {
+		style := styles.item
+		if i == 0 {
+			style = styles.selected
+		}
+		line := fmt.Sprintf("  %*d %-16s  %-6s  %-20s  %s", numberWidth-1, i+1, row.date, row.format, row.title, row.tags)
+		sb.WriteString(style.Render(line) + "\n")
+	}
+	sb.WriteString("\n")
+
+	bar := styles.warning.Render(" [inbox: 3]") + styles.muted.Render(" 2 notes | sort: date ▼ | refreshed 09:20:00")
+	sb.WriteString(bar + styles.info.Render(" | Note saved") + "\n")
+	sb.WriteString(styles.error.Render("  Errors look like this"))
+	return styles.border.Render(sb.String())
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"strconv"
+	"time"
+
+	"burh/i18n"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// numberWidth is the width of the list's row number column, e.g. "12 "
+const numberWidth = 3
+
+// rowKeyDelay is how long two typed digits wait for a motion before they open that row
+const rowKeyDelay = 400 * time.Millisecond
+
+// rowKeyMsg fires when two digits were not followed by a motion
+type rowKeyMsg struct {
+	seq int
+}
+
+// rowKeyCmd waits for rowKeyDelay, then reports the pending digits as a row to open
+func rowKeyCmd(seq int) tea.Cmd {
+	return tea.Tick(rowKeyDelay, func(time.Time) tea.Msg {
+		return rowKeyMsg{seq: seq}
+	})
+}
+
+// openRow selects and opens the note on row n of the page, counting from 1
+func (m *Model) openRow(n int) tea.Cmd {
+	i := m.startIndex + n - 1
+	if n < 1 || n > m.pageSize || i >= len(m.notes) {
+		m.flash = i18n.T("No row %d on this page", n)
+		return nil
+	}
+	m.selected = i
+	return m.openNoteAt(m.notes[i], m.matchLine(m.notes[i]))
+}
+
+// handleJumpKey handles keys at the ":" prompt: digits make the row number and
+// enter opens that row
+func (m *Model) handleJumpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.jumping = false
+		if n, err := strconv.Atoi(m.jumpInput); err == nil {
+			return m, m.openRow(n)
+		}
+	case tea.KeyEsc:
+		m.jumping = false
+	case tea.KeyCtrlC:
+		return m.quit()
+	case tea.KeyBackspace:
+		if m.jumpInput == "" {
+			m.jumping = false
+		} else {
+			m.jumpInput = m.jumpInput[:len(m.jumpInput)-1]
+		}
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if r >= '0' && r <= '9' {
+				m.jumpInput += string(r)
+			}
+		}
+	}
+	return m, nil
+}
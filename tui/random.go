@@ -0,0 +1,18 @@
+package tui
+
+import (
+	"math/rand"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openRandom selects a random note from the list, so with filters active it picks
+// among the matching notes, and opens it
+func (m *Model) openRandom() tea.Cmd {
+	if len(m.notes) == 0 {
+		m.flash = "No notes to pick from"
+		return nil
+	}
+	m.selectIndex(rand.Intn(len(m.notes)))
+	return m.openNoteAt(m.notes[m.selected], 0)
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// enterRefile opens the directory picker for moving the selected note out of the inbox
+func (m *Model) enterRefile() {
+	if len(m.notes) == 0 || m.selected >= len(m.notes) {
+		return
+	}
+	note := m.notes[m.selected]
+	if note.Locked {
+		m.flash = fmt.Sprintf("'%s' is locked", note.Title)
+		return
+	}
+
+	m.refileNote = note
+	m.refileTargets = m.noteManager.RefileTargets()
+	m.refileSelected = 0
+	for i, dir := range m.refileTargets {
+		if dir == note.Dir {
+			m.refileSelected = i
+			break
+		}
+	}
+	m.state = "refile"
+}
+
+// handleRefileKey handles key events in the refile picker
+func (m *Model) handleRefileKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m.quit()
+	case "esc":
+		m.state = "list"
+	case "j", "down":
+		if m.refileSelected < len(m.refileTargets)-1 {
+			m.refileSelected++
+		}
+	case "k", "up":
+		if m.refileSelected > 0 {
+			m.refileSelected--
+		}
+	case "enter":
+		m.state = "list"
+		dir := m.refileTargets[m.refileSelected]
+		if err := m.noteManager.RefileNote(m.refileNote, dir); err != nil {
+			m.flash = err.Error()
+			return m, nil
+		}
+		m.flash = fmt.Sprintf("Refiled '%s' to %s", m.refileNote.Title, dir)
+		return m, m.loadNotesCmd()
+	}
+	return m, nil
+}
+
+// renderRefile renders the list of directories the note can be refiled to
+func (m *Model) renderRefile() string {
+	var sb strings.Builder
+
+	terminalWidth := getTerminalWidth()
+	centeredHeader, _ := centerText("BURH - REFILE", terminalWidth)
+	sb.WriteString(m.styles.title.Render(centeredHeader))
+	sb.WriteString("\n\n")
+
+	help := m.styles.muted.Render("  j/k: directory | enter: refile | esc: cancel | q: quit")
+	sb.WriteString(help)
+	sb.WriteString("\n\n")
+
+	sb.WriteString(m.styles.primary.Render(fmt.Sprintf("  Refile '%s' to:", m.refileNote.Title)))
+	sb.WriteString("\n\n")
+
+	start := max(0, m.refileSelected-m.pageSize+1)
+	end := min(len(m.refileTargets), start+m.pageSize)
+	for i := start; i < end; i++ {
+		row := "  " + m.refileTargets[i]
+		if m.refileTargets[i] == m.refileNote.Dir {
+			row += " (current)"
+		}
+		style := m.styles.item
+		if i == m.refileSelected {
+			style = m.styles.selected
+			row = m.styles.markSelected(row)
+		}
+		sb.WriteString(style.Render(row))
+		sb.WriteString("\n")
+	}
+
+	return m.styles.border.Render(sb.String())
+}
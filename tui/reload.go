@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"burh/config"
+	"burh/i18n"
+	"burh/notes"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// configSettleDelay gathers the several events an editor's save can cause into
+// one reload
+const configSettleDelay = 100 * time.Millisecond
+
+// configChangedMsg reports that the config file was written, replaced, or removed
+type configChangedMsg struct{}
+
+// configLoadedMsg carries the config read again after it changed
+type configLoadedMsg struct {
+	cfg *config.Config
+	err error
+}
+
+// SetManagerFactory sets how a note manager is made from a reloaded config, so
+// changes to the notes directories and their settings apply without restarting.
+// Without it, a reload only applies the theme and display settings.
+func (m *Model) SetManagerFactory(newManager func(*config.Config) *notes.Manager) {
+	m.newManager = newManager
+}
+
+// TryTheme shows the TUI in a theme for this run only, without changing the config
+func (m *Model) TryTheme(theme config.Theme) {
+	m.themeOverride = &theme
+	m.styles = m.newStyles()
+}
+
+// newStyles builds the styles from the config's theme, or the one being tried
+func (m *Model) newStyles() *Styles {
+	if m.themeOverride == nil {
+		return NewStyles(m.config)
+	}
+	cfg := *m.config
+	cfg.Theme = *m.themeOverride
+	cfg.Accessibility.HighContrast = false
+	return NewStyles(&cfg)
+}
+
+// watchConfig starts watching the config file for changes. The folder holding it
+// is watched, since editors often save by replacing the file.
+func (m *Model) watchConfig() tea.Cmd {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		m.flash = i18n.T("Config changes won't be reloaded: %v", err)
+		return nil
+	}
+	if err := watcher.Add(filepath.Dir(config.Path())); err != nil {
+		watcher.Close()
+		m.flash = i18n.T("Config changes won't be reloaded: %v", err)
+		return nil
+	}
+	m.configWatcher = watcher
+	return m.nextConfigChangeCmd()
+}
+
+// nextConfigChangeCmd waits for the next change to the config file
+func (m *Model) nextConfigChangeCmd() tea.Cmd {
+	watcher := m.configWatcher
+	path := filepath.Clean(config.Path())
+	return func() tea.Msg {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return nil // Stopped on quit
+				}
+				if filepath.Clean(event.Name) != path || event.Op == fsnotify.Chmod {
+					continue
+				}
+				// Let the rest of the save land
+				time.Sleep(configSettleDelay)
+				for len(watcher.Events) > 0 {
+					<-watcher.Events
+				}
+				return configChangedMsg{}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// stopWatchingConfig stops watching the config file when the TUI quits
+func (m *Model) stopWatchingConfig() {
+	if m.configWatcher != nil {
+		m.configWatcher.Close()
+		m.configWatcher = nil
+	}
+}
+
+// configModTime returns when the config file was last changed, zero if it is missing
+func configModTime() time.Time {
+	info, err := os.Stat(config.Path())
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// handleConfigChanged reads the config again after its file changed, in the
+// background so a slow disk doesn't hold up the TUI. A removed config file keeps
+// the current settings.
+func (m *Model) handleConfigChanged() tea.Cmd {
+	next := m.nextConfigChangeCmd()
+	modTime := configModTime()
+	if modTime.IsZero() {
+		m.flash = i18n.T("Config file is missing; keeping the current settings")
+		return next
+	}
+	if modTime.Equal(m.configModTime) {
+		return next
+	}
+	m.configModTime = modTime
+
+	load := func() tea.Msg {
+		cfg, err := config.ReloadConfig()
+		return configLoadedMsg{cfg: cfg, err: err}
+	}
+	return tea.Batch(load, next)
+}
+
+// handleConfigLoaded switches to a reloaded config, keeping the current one when
+// the new one is invalid
+func (m *Model) handleConfigLoaded(msg configLoadedMsg) tea.Cmd {
+	switch {
+	case errors.Is(msg.err, config.ErrNoConfigFile):
+		m.flash = i18n.T("Config file is missing; keeping the current settings")
+	case msg.err != nil:
+		// Keep the status bar to one line
+		m.flash = i18n.T("Config not reloaded: %v", strings.Join(strings.Fields(msg.err.Error()), " "))
+	default:
+		return m.applyConfig(msg.cfg)
+	}
+	return nil
+}
+
+// applyConfig switches to a reloaded config. The sort order stays as it is, since
+// it may come from the default view or a key pressed in this session.
+func (m *Model) applyConfig(cfg *config.Config) tea.Cmd {
+	cfg.SortColumn = m.config.SortColumn
+	cfg.SortDescending = m.config.SortDescending
+	dirsChanged := !slices.Equal(cfg.NotesDirs, m.config.NotesDirs)
+	*m.config = *cfg
+
+	i18n.SetLocale(i18n.Detect(cfg.Locale))
+	m.styles = m.newStyles()
+	m.fitPageSize(0)
+	m.flash = i18n.T("Config reloaded")
+	if m.newManager == nil {
+		return nil
+	}
+
+	m.cancelLoad()
+	m.noteManager = m.newManager(m.config)
+	m.routeWarnings()
+	if m.showAll {
+		m.hiddenTags, m.hiddenDirs = m.noteManager.Exclusions()
+		m.noteManager.SetExclusions(nil, nil)
+	}
+	if dirsChanged {
+		m.flash = i18n.T("Config reloaded; notes directories changed")
+	}
+	if m.filterKind == "keyword" && len(m.filters) > 0 {
+		return m.streamSearchCmd(filterQuery(m.filters))
+	}
+	return m.loadNotesCmd()
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"burh/i18n"
+	"burh/notes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// setDeleteTarget remembers the note the delete prompt is for, so a list
+// reload while the prompt is open can't change which note gets deleted
+func (m *Model) setDeleteTarget(note *notes.Note) {
+	m.deleteTarget = note.ID
+	m.deleteTitle = note.Title
+	m.deletePinned = m.config.Safety.IsPinned(note.Tags)
+	m.deleteTyped = ""
+}
+
+// clearDeleteTarget forgets the note the delete prompt was for
+func (m *Model) clearDeleteTarget() {
+	m.deleteTarget = ""
+	m.deleteTitle = ""
+	m.deletePinned = false
+	m.deleteTyped = ""
+}
+
+// confirmDelete deletes the note the prompt was for if it is still in the list
+func (m *Model) confirmDelete() {
+	if m.deleteTarget == "" {
+		return
+	}
+	for _, note := range m.notes {
+		if note.ID == m.deleteTarget {
+			m.deleteNote(m.deleteTarget)
+			return
+		}
+	}
+	m.flash = i18n.T("'%s' is no longer in the list; nothing deleted", m.deleteTitle)
+}
+
+// handleTypedDeleteKey takes the title typed to confirm deleting a pinned note
+func (m *Model) handleTypedDeleteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		if m.deleteTyped == m.deleteTitle {
+			m.confirmDelete()
+		} else {
+			m.flash = "Title did not match; note kept"
+		}
+	case tea.KeyEsc:
+		// Cancelled
+	case tea.KeyBackspace:
+		if runes := []rune(m.deleteTyped); len(runes) > 0 {
+			m.deleteTyped = string(runes[:len(runes)-1])
+		}
+		return m, nil
+	case tea.KeySpace:
+		m.deleteTyped += " "
+		return m, nil
+	case tea.KeyRunes:
+		m.deleteTyped += string(msg.Runes)
+		return m, nil
+	default:
+		return m, nil
+	}
+	m.state = "list"
+	m.clearDeleteTarget()
+	return m, nil
+}
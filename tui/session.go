@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"burh/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quit saves the session and exits
+func (m *Model) quit() (tea.Model, tea.Cmd) {
+	m.cancelLoad()
+	m.stopWatchingConfig()
+	m.saveSession()
+	m.saveSort()
+	if m.keyCounts != nil {
+		_ = config.RecordKeys(m.keyCounts)
+	}
+	return m, tea.Quit
+}
+
+// saveSession records the selection, scroll position, search, and grouping for the next start.
+// The sort order is kept in the config instead (see saveSort).
+func (m *Model) saveSession() {
+	session := &config.Session{
+		StartIndex: m.startIndex,
+		GroupBy:    m.groupBy,
+	}
+	if len(m.notes) > 0 && m.selected < len(m.notes) {
+		session.SelectedID = m.notes[m.selected].ID
+	}
+	if len(m.filters) > 0 {
+		session.SearchType = m.filterKind
+		session.Query = filterQuery(m.filters)
+		if m.filterKind != "keyword" {
+			session.Query = m.filters[0].value
+		}
+	}
+	_ = config.SaveSession(session)
+}
+
+// restoreSession reruns the saved search once the first load is done.
+// The saved selection is applied when the search finishes.
+func (m *Model) restoreSession() tea.Cmd {
+	switch m.restore.SearchType {
+	case "keyword":
+		return m.streamSearchCmd(m.restore.Query)
+	case "tag":
+		m.searchType = "tag"
+		m.tagQuery = m.restore.Query
+		m.performSearch()
+	case "date":
+		m.searchType = "date"
+		m.dateQuery = m.restore.Query
+		m.performSearch()
+	}
+	m.restoreSelection()
+	return nil
+}
+
+// restoreSelection selects the saved note, if it is still listed, and restores the scroll position
+func (m *Model) restoreSelection() {
+	session := m.restore
+	m.restore = nil
+
+	for i, note := range m.notes {
+		if note.ID == session.SelectedID {
+			m.selectIndex(i)
+			if session.StartIndex <= i && i < session.StartIndex+m.pageSize {
+				m.startIndex = session.StartIndex
+			}
+			return
+		}
+	}
+}
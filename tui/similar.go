@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// saveCreate saves the create form as a new note, first asking what to do when
+// notes with a similar title exist
+func (m *Model) saveCreate() (tea.Model, tea.Cmd) {
+	if m.titleInput != "" {
+		if similar, err := m.noteManager.SimilarTitles(m.titleInput); err == nil && len(similar) > 0 {
+			m.similarNotes = similar
+			m.state = "similar_title"
+			return m, nil
+		}
+	}
+	return m.finishCreate()
+}
+
+// finishCreate creates the note and goes back to the list
+func (m *Model) finishCreate() (tea.Model, tea.Cmd) {
+	m.createNote()
+	m.similarNotes = nil
+	m.state = "list"
+	m.currentField = 0
+	return m, m.loadNotesCmd()
+}
+
+// handleSimilarTitleKey answers the prompt about notes with a title like the new one's
+func (m *Model) handleSimilarTitleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	existing := m.similarNotes[0]
+	switch msg.String() {
+	case "o":
+		// The existing note takes the new one's place
+		m.clearDraft()
+		m.similarNotes = nil
+		m.state = "list"
+		m.currentField = 0
+		return m, tea.Batch(m.openNote(existing), m.loadNotesCmd())
+	case "a":
+		if strings.TrimSpace(m.contentInput) == "" {
+			m.flash = "There is no content to append"
+			return m, nil
+		}
+		if _, err := m.noteManager.AppendContent(existing, m.contentInput); err != nil {
+			m.flash = err.Error()
+			return m, nil
+		}
+		m.clearDraft()
+		m.flash = fmt.Sprintf("Appended to '%s'", existing.Title)
+		m.similarNotes = nil
+		m.state = "list"
+		m.currentField = 0
+		return m, m.loadNotesCmd()
+	case "c", "enter":
+		return m.finishCreate()
+	case "esc":
+		m.similarNotes = nil
+		m.state = "create"
+	case "ctrl+c":
+		m.state = "create"
+		return m.requestQuit()
+	}
+	return m, nil
+}
+
+// renderSimilarTitle renders the prompt about notes with a title like the new one's
+func (m *Model) renderSimilarTitle() string {
+	var sb strings.Builder
+
+	sb.WriteString(m.styles.title.Render("SIMILAR TITLE"))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.styles.warn(fmt.Sprintf("  '%s' is like the title of:", m.titleInput)))
+	sb.WriteString("\n\n")
+	for _, note := range m.similarNotes {
+		sb.WriteString(fmt.Sprintf("  %s  %s\n", note.Created.Format("2006-01-02"), note.Title))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(m.styles.muted.Render(fmt.Sprintf("  o: open '%s' | a: append the content to it | c: create anyway | esc: back to the form", m.similarNotes[0].Title)))
+
+	return m.styles.border.Render(sb.String())
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"strings"
+
+	"burh/config"
+	"burh/notes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sortColumns are the list columns in display order; 1-4 after the sort key select them
+var sortColumns = []string{"date", "format", "title", "tags"}
+
+// Column widths of the list view, shared by rendering and mouse hit-testing
+const (
+	dateWidth   = 16
+	formatWidth = 7
+	titleWidth  = 40
+)
+
+// isSortKey checks if a key selects a sort column
+func isSortKey(key string) bool {
+	return len(key) == 1 && key[0] >= '1' && key[0] <= byte('0'+len(sortColumns))
+}
+
+// handleSortKey handles the key pressed after the sort key: 1-4 sort by that
+// column, and any other key cancels
+func (m *Model) handleSortKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.sorting = false
+	if key := msg.String(); isSortKey(key) {
+		m.setSortColumn(sortColumns[key[0]-'1'])
+	}
+	return m, nil
+}
+
+// setSortColumn sorts by the given column, flipping the direction if it is already active.
+// The choice is saved to the config on quit (see saveSort).
+func (m *Model) setSortColumn(column string) {
+	if m.config.SortColumn == column {
+		m.config.SortDescending = !m.config.SortDescending
+	} else {
+		m.config.SortColumn = column
+		m.config.SortDescending = false
+	}
+	m.sortChanged = true
+
+	m.applyGrouping()
+	m.selected = 0
+	m.startIndex = 0
+}
+
+// saveSort saves the sort order chosen in this run to the config, so the
+// config file is written once rather than on every change
+func (m *Model) saveSort() {
+	if !m.sortChanged {
+		return
+	}
+	cfg, err := config.ReloadConfig()
+	if err != nil {
+		return
+	}
+	cfg.SortColumn = m.config.SortColumn
+	cfg.SortDescending = m.config.SortDescending
+	_ = config.SaveConfig(cfg)
+}
+
+// sortColumn returns the active sort column, falling back to date for unknown values.
+// Besides the list columns it may be "modified", which default_view can set.
+func (m *Model) sortColumn() string {
+	for _, column := range notes.SortKeys {
+		if column == m.config.SortColumn {
+			return column
+		}
+	}
+	return "date"
+}
+
+// sortNotes orders the notes by the active sort column
+func (m *Model) sortNotes() {
+	notes.SortNotes(m.notes, m.sortColumn(), m.config.SortDescending)
+}
+
+// columnLabel returns a header label with an arrow on the active sort column
+func (m *Model) columnLabel(column, label string) string {
+	if column != m.sortColumn() {
+		return label
+	}
+	if m.config.SortDescending {
+		return label + " ▼"
+	}
+	return label + " ▲"
+}
+
+// listHeaderY returns the screen row of the list's column header: the top border
+// comes first, then everything the list renders above the header
+func (m *Model) listHeaderY() int {
+	return 1 + strings.Count(m.renderListTop(), "\n")
+}
+
+// columnAt returns the sort column under screen column x of the list header
+func (m *Model) columnAt(x int) string {
+	// The border takes one cell, each row is indented by two, and row numbers come first
+	x -= 3 + numberWidth
+	if x < dateWidth+1 {
+		return "date"
+	}
+	// The "Edited" column sorts by date too
+	x -= m.editedColumnWidth()
+	switch {
+	case x < dateWidth+1:
+		return "date"
+	case x < dateWidth+2+formatWidth+1:
+		return "format"
+	case x < dateWidth+2+formatWidth+2+m.titleColumnWidth()+1:
+		return "title"
+	default:
+		return "tags"
+	}
+}
@@ -0,0 +1,294 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"burh/notes"
+	"burh/paths"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pane is one of the two independent note lists in split mode
+type pane struct {
+	dir      string // Notes directory shown, "" for all of them
+	query    string // Search query applied to the pane
+	notes    []*notes.Note
+	selected int
+	start    int
+	seq      int // Identifies the pane's most recent load
+}
+
+// paneLoadedMsg carries the notes for one pane
+type paneLoadedMsg struct {
+	pane  int
+	seq   int
+	notes []*notes.Note
+	err   error
+}
+
+// enterSplit switches to split mode, starting the panes on the first two notes directories
+func (m *Model) enterSplit() tea.Cmd {
+	m.state = "split"
+	if m.panes[0] == nil {
+		dirs := m.noteManager.GetNotesDirs()
+		m.panes[0] = &pane{dir: dirs[0]}
+		m.panes[1] = &pane{dir: dirs[0]}
+		if len(dirs) > 1 {
+			m.panes[1].dir = dirs[1]
+		}
+	}
+	return tea.Batch(m.loadPaneCmd(0), m.loadPaneCmd(1))
+}
+
+// loadPaneCmd loads the notes for a pane in the background
+func (m *Model) loadPaneCmd(i int) tea.Cmd {
+	p := m.panes[i]
+	p.seq++
+	seq, dir, query := p.seq, p.dir, p.query
+
+	return func() tea.Msg {
+		results, err := m.noteManager.SearchNotesContext(context.Background(), query)
+		if err != nil {
+			return paneLoadedMsg{pane: i, seq: seq, err: err}
+		}
+
+		var filtered []*notes.Note
+		for _, note := range results {
+			if dir == "" || paths.Within(note.Dir, dir) {
+				filtered = append(filtered, note)
+			}
+		}
+		return paneLoadedMsg{pane: i, seq: seq, notes: filtered}
+	}
+}
+
+// handlePaneLoaded stores a pane's notes unless a newer load has started
+func (m *Model) handlePaneLoaded(msg paneLoadedMsg) {
+	p := m.panes[msg.pane]
+	if p == nil || msg.seq != p.seq {
+		return
+	}
+	if msg.err != nil {
+		m.splitStatus = msg.err.Error()
+		return
+	}
+	p.notes = msg.notes
+	if p.selected >= len(p.notes) {
+		p.selected = len(p.notes) - 1
+	}
+	if p.selected < 0 {
+		p.selected = 0
+	}
+	p.scroll(m.pageSize)
+}
+
+// scroll keeps the selected note inside the visible page
+func (p *pane) scroll(pageSize int) {
+	if p.selected >= p.start+pageSize {
+		p.start = p.selected - pageSize + 1
+	}
+	if p.selected < p.start {
+		p.start = p.selected
+	}
+}
+
+// current returns the selected note of the pane, or nil if it is empty
+func (p *pane) current() *notes.Note {
+	if p.selected < len(p.notes) {
+		return p.notes[p.selected]
+	}
+	return nil
+}
+
+// handleSplitKey handles key events in split mode
+func (m *Model) handleSplitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	active := m.panes[m.activePane]
+	other := m.panes[1-m.activePane]
+
+	// Typing a pane query
+	if m.paneQuerying {
+		switch msg.String() {
+		case "esc":
+			m.paneQuerying = false
+		case "enter":
+			m.paneQuerying = false
+			active.query = m.paneQueryInput
+			active.selected, active.start = 0, 0
+			return m, m.loadPaneCmd(m.activePane)
+		case "backspace":
+			if len(m.paneQueryInput) > 0 {
+				m.paneQueryInput = m.paneQueryInput[:len(m.paneQueryInput)-1]
+			}
+		case " ":
+			m.paneQueryInput += " "
+		default:
+			if len(msg.String()) == 1 {
+				m.paneQueryInput += msg.String()
+			}
+		}
+		return m, nil
+	}
+
+	m.splitStatus = ""
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m.quit()
+	case "w", "esc":
+		m.state = "list"
+		return m, m.loadNotesCmd()
+	case "tab":
+		m.activePane = 1 - m.activePane
+	case "j", "down":
+		if active.selected < len(active.notes)-1 {
+			active.selected++
+		}
+	case "k", "up":
+		if active.selected > 0 {
+			active.selected--
+		}
+	case "f":
+		// Cycle the pane through all directories and each notes directory
+		dirs := append([]string{""}, m.noteManager.GetNotesDirs()...)
+		for i, dir := range dirs {
+			if dir == active.dir {
+				active.dir = dirs[(i+1)%len(dirs)]
+				break
+			}
+		}
+		active.selected, active.start = 0, 0
+		return m, m.loadPaneCmd(m.activePane)
+	case "/":
+		m.paneQuerying = true
+		m.paneQueryInput = active.query
+	case "enter":
+		if note := active.current(); note != nil {
+			line, _ := m.noteManager.MatchLine(note, active.query)
+			return m, m.openNoteAt(note, line)
+		}
+	case "m", "c":
+		note := active.current()
+		if note == nil {
+			break
+		}
+		if other.dir == "" {
+			m.splitStatus = "Pick a directory for the other pane first (f)"
+			break
+		}
+		var err error
+		if msg.String() == "m" {
+			err = m.noteManager.MoveNote(note, other.dir)
+		} else {
+			_, err = m.noteManager.CopyNote(note, other.dir)
+		}
+		if err != nil {
+			m.splitStatus = err.Error()
+			break
+		}
+		if msg.String() == "m" {
+			m.splitStatus = fmt.Sprintf("Moved '%s' to %s", note.Title, other.dir)
+		} else {
+			m.splitStatus = fmt.Sprintf("Copied '%s' to %s", note.Title, other.dir)
+		}
+		return m, tea.Batch(m.loadPaneCmd(0), m.loadPaneCmd(1))
+	case "r":
+		return m, tea.Batch(m.loadPaneCmd(0), m.loadPaneCmd(1))
+	}
+
+	active.scroll(m.pageSize)
+	return m, nil
+}
+
+// renderSplit renders the two panes side by side
+func (m *Model) renderSplit() string {
+	var sb strings.Builder
+
+	terminalWidth := getTerminalWidth()
+	centeredHeader, _ := centerText("BURH - SPLIT VIEW", terminalWidth)
+	sb.WriteString(m.styles.title.Render(centeredHeader))
+	sb.WriteString("\n\n")
+
+	help := m.styles.muted.Render("  tab: switch pane | f: directory | /: filter | m: move | c: copy | enter: edit | w/esc: list | q: quit")
+	sb.WriteString(help)
+	sb.WriteString("\n\n")
+
+	paneWidth := (terminalWidth - 8) / 2
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+	left := m.renderPane(0, paneWidth)
+	right := m.renderPane(1, paneWidth)
+	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, " ", right))
+	sb.WriteString("\n")
+
+	if m.paneQuerying {
+		sb.WriteString(m.styles.primary.Render("  Filter: " + m.paneQueryInput + "█"))
+	} else if m.splitStatus != "" {
+		sb.WriteString(m.styles.warn("  " + m.splitStatus))
+	}
+
+	return m.styles.border.Render(sb.String())
+}
+
+// renderPane renders one pane of the split view
+func (m *Model) renderPane(i, width int) string {
+	p := m.panes[i]
+	var sb strings.Builder
+
+	dir := p.dir
+	if dir == "" {
+		dir = "all directories"
+	}
+	title := fmt.Sprintf("%s (%d)", dir, len(p.notes))
+	if p.query != "" {
+		title += " / " + p.query
+	}
+	sb.WriteString(m.styles.primary.Render(truncate(title, width-2)))
+	sb.WriteString("\n\n")
+
+	if len(p.notes) == 0 {
+		sb.WriteString(m.styles.muted.Render("No notes"))
+	}
+	end := p.start + m.pageSize
+	if end > len(p.notes) {
+		end = len(p.notes)
+	}
+	for j := p.start; j < end; j++ {
+		note := p.notes[j]
+		row := truncate(note.Created.Format("2006-01-02")+"  "+note.Title, width-2)
+		style := m.styles.item
+		if j == p.selected && i == m.activePane {
+			style = m.styles.selected
+			row = m.styles.markSelected(row)
+		} else if j == p.selected {
+			style = m.styles.info
+		}
+		sb.WriteString(style.Render(row))
+		sb.WriteString("\n")
+	}
+
+	borderColor := m.styles.theme.Muted
+	if i == m.activePane {
+		borderColor = m.styles.theme.Primary
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(borderColor)).
+		Width(width).
+		Render(sb.String())
+}
+
+// truncate shortens s to at most width characters, marking the cut with "..."
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	if width <= 3 {
+		return string(r[:width])
+	}
+	return string(r[:width-3]) + "..."
+}
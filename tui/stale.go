@@ -0,0 +1,26 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleStaleFilter adds a stale:PERIOD filter, using stale_after from the config,
+// to the current keyword search, or removes it if it is already active
+func (m *Model) toggleStaleFilter() tea.Cmd {
+	for i, chip := range m.filters {
+		if chip.key == "stale" {
+			return m.removeFilter(i)
+		}
+	}
+
+	period := m.config.StaleAfter
+	if period == "" {
+		period = "1y"
+	}
+	var chips []filterChip
+	if m.filterKind == "keyword" {
+		chips = append(chips, m.filters...)
+	}
+	chips = append(chips, filterChip{key: "stale", value: period})
+	return m.streamSearchCmd(filterQuery(chips))
+}
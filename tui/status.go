@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"burh/i18n"
+)
+
+// renderStatusBar renders the bar below every view: the inbox badge, note count,
+// active search, sort and grouping, the selected note's directory, and the last refresh time
+func (m *Model) renderStatusBar() string {
+	if m.jumping {
+		return " :" + m.jumpInput + "█" + m.styles.muted.Render("  "+i18n.T("enter: open row | esc: cancel"))
+	}
+	if m.sorting {
+		return " " + i18n.T("sort by: 1 date | 2 format | 3 title | 4 tags") + m.styles.muted.Render("  "+i18n.T("other keys: cancel"))
+	}
+	var parts []string
+
+	count := i18n.T("%d notes", len(m.notes))
+	if len(m.filters) > 0 {
+		count = i18n.T("%d of %d notes", len(m.notes), m.totalNotes)
+	}
+	parts = append(parts, count)
+
+	if len(m.filters) > 0 {
+		parts = append(parts, i18n.T("filter:")+" "+m.filterText())
+	}
+
+	sortLabel := m.sortColumn()
+	if m.config.SortDescending {
+		sortLabel += " ▼"
+	} else {
+		sortLabel += " ▲"
+	}
+	parts = append(parts, i18n.T("sort:")+" "+sortLabel)
+
+	if m.groupBy != "" {
+		parts = append(parts, i18n.T("group:")+" "+m.groupBy)
+	}
+
+	if tags, dirs := m.noteManager.Exclusions(); len(tags) > 0 || len(dirs) > 0 {
+		parts = append(parts, i18n.T("some notes hidden (A: all)"))
+	} else if m.showAll {
+		parts = append(parts, i18n.T("all notes (A: default view)"))
+	}
+
+	if edited := m.editedStatus(); edited != "" {
+		parts = append(parts, edited)
+	}
+
+	if dir := m.selectedDir(); dir != "" {
+		if m.noteManager.IsReadOnlyDir(dir) {
+			dir += " " + i18n.T("(read-only)")
+		}
+		parts = append(parts, i18n.T("dir:")+" "+dir)
+	}
+
+	switch {
+	case m.loadCancel != nil:
+		parts = append(parts, i18n.T("loading..."))
+	case !m.refreshedAt.IsZero():
+		parts = append(parts, i18n.T("refreshed")+" "+m.refreshedAt.Format("15:04:05"))
+	}
+
+	bar := m.styles.muted.Render(" " + strings.Join(parts, " | "))
+	if m.inboxCount > 0 {
+		// Badge for captures waiting to be refiled
+		bar = m.styles.warning.Render(fmt.Sprintf(" [inbox: %d]", m.inboxCount)) + bar
+	}
+	if m.flash != "" {
+		bar += m.styles.info.Render(" | " + m.flash)
+	}
+	return bar
+}
+
+// selectedDir returns the directory of the selected folder in the tree view,
+// or of the selected note elsewhere
+func (m *Model) selectedDir() string {
+	if m.state == "split" {
+		p := m.panes[m.activePane]
+		if note := p.current(); note != nil {
+			return note.Dir
+		}
+		return p.dir
+	}
+	if m.state == "tree" {
+		rows := m.treeRows()
+		if m.treeSelected >= 0 && m.treeSelected < len(rows) {
+			row := rows[m.treeSelected]
+			if row.node != nil {
+				return row.node.path
+			}
+			return row.note.Dir
+		}
+		return ""
+	}
+	if len(m.notes) > 0 && m.selected < len(m.notes) {
+		return m.notes[m.selected].Dir
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"burh/notes"
+)
+
+// maxTagSuggestions caps the existing tags suggested for the tag being typed
+const maxTagSuggestions = 5
+
+// loadTagCounts counts the notes carrying each tag, for the badges in the create
+// and edit forms
+func (m *Model) loadTagCounts() {
+	noteList, err := m.noteManager.ListNotes()
+	if err != nil {
+		m.tagCounts = nil
+		return
+	}
+	m.tagCounts = notes.TagCounts(noteList)
+}
+
+// renderTagBadges renders, under the tags field, how many notes carry each tag
+// typed so far, e.g. "work (132)" or "wrok (new)", and the existing tags the one
+// being typed could complete to, most used first
+func (m *Model) renderTagBadges() string {
+	if m.currentField != 1 || m.tagCounts == nil {
+		return ""
+	}
+	tags := strings.Split(m.tagsInput, ",")
+	partial := strings.ToLower(strings.TrimSpace(tags[len(tags)-1]))
+
+	var badges []string
+	for _, tag := range tags[:len(tags)-1] {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		badges = append(badges, m.tagBadge(tag))
+	}
+
+	var suggestions []string
+	if partial != "" {
+		for tag := range m.tagCounts {
+			if strings.HasPrefix(tag, partial) {
+				suggestions = append(suggestions, tag)
+			}
+		}
+		sort.Slice(suggestions, func(i, j int) bool {
+			a, b := suggestions[i], suggestions[j]
+			if m.tagCounts[a] != m.tagCounts[b] {
+				return m.tagCounts[a] > m.tagCounts[b]
+			}
+			return a < b
+		})
+		if len(suggestions) > maxTagSuggestions {
+			suggestions = suggestions[:maxTagSuggestions]
+		}
+		if len(suggestions) == 0 {
+			badges = append(badges, m.tagBadge(partial))
+		}
+	}
+
+	var sb strings.Builder
+	if len(badges) > 0 {
+		sb.WriteString(m.styles.muted.Render("        " + strings.Join(badges, "  ")))
+		sb.WriteString("\n")
+	}
+	if len(suggestions) > 0 {
+		for i, tag := range suggestions {
+			suggestions[i] = fmt.Sprintf("%s (%d)", tag, m.tagCounts[tag])
+		}
+		sb.WriteString(m.styles.muted.Render("        ↳ " + strings.Join(suggestions, "  ")))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// tagBadge renders a tag with the number of notes carrying it
+func (m *Model) tagBadge(tag string) string {
+	if n := m.tagCounts[tag]; n > 0 {
+		return fmt.Sprintf("%s (%d)", tag, n)
+	}
+	return tag + " (new)"
+}
@@ -0,0 +1,236 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"burh/notes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// treeNode is a folder in the tree view
+type treeNode struct {
+	name     string
+	path     string
+	children []*treeNode
+	notes    []*notes.Note
+	count    int // Notes in this folder and all of its subfolders
+}
+
+// treeRow is one visible line of the tree view: either a folder or a note
+type treeRow struct {
+	node  *treeNode
+	note  *notes.Note
+	depth int
+}
+
+// buildTree arranges the loaded notes into a folder hierarchy, one root per notes directory
+func (m *Model) buildTree() []*treeNode {
+	var roots []*treeNode
+	byPath := make(map[string]*treeNode)
+
+	for _, dir := range m.noteManager.GetNotesDirs() {
+		dir = filepath.Clean(dir)
+		if _, ok := byPath[dir]; ok {
+			continue
+		}
+		root := &treeNode{name: dir, path: dir}
+		byPath[dir] = root
+		roots = append(roots, root)
+	}
+
+	var folder func(path string) *treeNode
+	folder = func(path string) *treeNode {
+		if node, ok := byPath[path]; ok {
+			return node
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			// Not under any notes directory; show it as its own root
+			node := &treeNode{name: path, path: path}
+			byPath[path] = node
+			roots = append(roots, node)
+			return node
+		}
+		node := &treeNode{name: filepath.Base(path), path: path}
+		byPath[path] = node
+		p := folder(parent)
+		p.children = append(p.children, node)
+		return node
+	}
+
+	for _, note := range m.notes {
+		node := folder(filepath.Clean(note.Dir))
+		node.notes = append(node.notes, note)
+	}
+
+	for _, root := range roots {
+		countTree(root)
+	}
+	return roots
+}
+
+// countTree fills in recursive note counts and sorts folders by name
+func countTree(node *treeNode) int {
+	sort.Slice(node.children, func(i, j int) bool {
+		return node.children[i].name < node.children[j].name
+	})
+	node.count = len(node.notes)
+	for _, child := range node.children {
+		node.count += countTree(child)
+	}
+	return node.count
+}
+
+// treeRows flattens the expanded parts of the tree into visible rows
+func (m *Model) treeRows() []treeRow {
+	var rows []treeRow
+	var walk func(node *treeNode, depth int)
+	walk = func(node *treeNode, depth int) {
+		rows = append(rows, treeRow{node: node, depth: depth})
+		if !m.treeExpanded[node.path] {
+			return
+		}
+		for _, child := range node.children {
+			walk(child, depth+1)
+		}
+		for _, note := range node.notes {
+			rows = append(rows, treeRow{note: note, depth: depth + 1})
+		}
+	}
+	for _, root := range m.buildTree() {
+		walk(root, 0)
+	}
+	return rows
+}
+
+// handleTreeKey handles key events in tree mode
+func (m *Model) handleTreeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := m.treeRows()
+	if m.treeSelected >= len(rows) {
+		m.treeSelected = len(rows) - 1
+	}
+	if m.treeSelected < 0 {
+		m.treeSelected = 0
+	}
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m.quit()
+	case "t", "esc":
+		m.state = "list"
+	case "j", "down":
+		if m.treeSelected < len(rows)-1 {
+			m.treeSelected++
+		}
+	case "k", "up":
+		if m.treeSelected > 0 {
+			m.treeSelected--
+		}
+	case "enter", " ", "l", "right":
+		if len(rows) == 0 {
+			break
+		}
+		row := rows[m.treeSelected]
+		if row.note != nil {
+			if msg.String() == "enter" {
+				return m, m.openNote(row.note)
+			}
+			break
+		}
+		m.treeExpanded[row.node.path] = !m.treeExpanded[row.node.path]
+	case "h", "left":
+		if len(rows) == 0 {
+			break
+		}
+		row := rows[m.treeSelected]
+		if row.node != nil && m.treeExpanded[row.node.path] {
+			m.treeExpanded[row.node.path] = false
+			break
+		}
+		// Move to the enclosing folder
+		for i := m.treeSelected - 1; i >= 0; i-- {
+			if rows[i].node != nil && rows[i].depth < row.depth {
+				m.treeSelected = i
+				break
+			}
+		}
+	case "r":
+		return m, m.loadNotesCmd()
+	}
+
+	m.scrollTree()
+	return m, nil
+}
+
+// scrollTree keeps the selected row inside the visible page
+func (m *Model) scrollTree() {
+	if m.treeSelected >= m.treeStart+m.pageSize {
+		m.treeStart = m.treeSelected - m.pageSize + 1
+	}
+	if m.treeSelected < m.treeStart {
+		m.treeStart = m.treeSelected
+	}
+}
+
+// renderTree renders the folder tree view
+func (m *Model) renderTree() string {
+	var sb strings.Builder
+
+	terminalWidth := getTerminalWidth()
+	centeredHeader, _ := centerText("BURH - FOLDERS", terminalWidth)
+	sb.WriteString(m.styles.title.Render(centeredHeader))
+	sb.WriteString("\n\n")
+
+	help := m.styles.muted.Render("  enter/l: open | h: collapse | r: refresh | t/esc: list | q: quit")
+	sb.WriteString(help)
+	sb.WriteString("\n\n")
+
+	rows := m.treeRows()
+	if len(rows) == 0 {
+		sb.WriteString(m.styles.muted.Render("  No notes directories configured."))
+		return m.styles.border.Render(sb.String())
+	}
+
+	endIndex := m.treeStart + m.pageSize
+	if endIndex > len(rows) {
+		endIndex = len(rows)
+	}
+	for i := m.treeStart; i < endIndex; i++ {
+		row := rows[i]
+		indent := "  " + strings.Repeat("  ", row.depth)
+
+		var line string
+		rowStyle := m.styles.item
+		if row.note != nil {
+			title := row.note.Title
+			if len(title) > 50 {
+				title = title[:47] + "..."
+			}
+			line = fmt.Sprintf("%s• %s", indent, title)
+		} else {
+			marker := "▸"
+			if m.treeExpanded[row.node.path] {
+				marker = "▾"
+			}
+			line = fmt.Sprintf("%s%s %s/ (%d)", indent, marker, row.node.name, row.node.count)
+			rowStyle = m.styles.info
+		}
+		if i == m.treeSelected {
+			rowStyle = m.styles.selected
+			line = m.styles.markSelected(line)
+		}
+		sb.WriteString(rowStyle.Render(line))
+		sb.WriteString("\n")
+	}
+
+	if len(rows) > m.pageSize {
+		sb.WriteString("\n")
+		sb.WriteString(m.styles.muted.Render(fmt.Sprintf("  Showing %d-%d of %d rows", m.treeStart+1, endIndex, len(rows))))
+	}
+
+	return m.styles.border.Render(sb.String())
+}
@@ -1,18 +1,24 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
-	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"burh/config"
+	"burh/editor"
+	"burh/i18n"
 	"burh/notes"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
+	"github.com/muesli/termenv"
 	"golang.org/x/term"
 )
 
@@ -24,9 +30,10 @@ type Model struct {
 	searching    bool
 	editing      bool
 	noteManager  *notes.Manager
+	warnings     chan error // Warnings from the note manager, shown in the status line
 	config       *config.Config
 	styles       *Styles
-	state        string // "list", "edit", "create", "search", "confirm_delete"
+	state        string // "list", "edit", "create", "search", "confirm_delete", "tree", "split", "outline", "refile", "kanban", "focus", "restore_draft", "confirm_quit", "similar_title"
 	currentNote  *notes.Note
 	titleInput   string
 	contentInput string
@@ -34,6 +41,9 @@ type Model struct {
 	formatInput  string
 	currentField int    // 0=title, 1=tags, 2=format, 3=content
 	deleteTarget string // ID of note to be deleted
+	deleteTitle  string // Title of note to be deleted
+	deletePinned bool   // Deleting the note takes its title typed
+	deleteTyped  string // Title typed to confirm deleting a pinned note
 
 	// Enhanced search fields
 	searchType   string // "keyword", "tag", "date"
@@ -42,9 +52,103 @@ type Model struct {
 	dateQuery    string
 	searchField  int // 0=type, 1=keyword, 2=tag, 3=date
 
+	// Filter and status bar fields
+	filters     []filterChip  // Active search filters, empty when all notes are shown
+	filterKind  string        // Search type that produced the filters
+	chipFocus   int           // Filter chip that x removes
+	totalNotes  int           // Number of notes in the last full load
+	refreshedAt time.Time     // When the last full load finished
+	flash       string        // One-off message shown in the status bar until the next key
+	pending     []*notes.Note // Search hits held back until the search is done, for reduced motion
+
+	// Default view fields
+	showAll    bool     // Every note is shown, the exclusions below set aside
+	hiddenTags []string // Tags the set-aside exclusions leave out
+	hiddenDirs []string // Directories the set-aside exclusions leave out
+
+	// Config reload fields
+	configModTime time.Time                           // When the config file had changed when last read
+	configWatcher *fsnotify.Watcher                   // Watches the config file for changes
+	newManager    func(*config.Config) *notes.Manager // Makes the note manager for a reloaded config
+	themeOverride *config.Theme                       // Theme tried with 'burh themes try', kept out of the config
+
 	// Pagination fields
-	pageSize   int // Number of notes to show per page (29)
+	pageSize   int // Number of notes to show per page (see fitPageSize)
 	startIndex int // Starting index for current page
+
+	// Vim-style motion state
+	countPrefix string // Digits typed before a motion, e.g. "10" in 10j
+	pendingG    bool   // First g of gg has been typed
+	rowSeq      int    // Identifies the digits a pending rowKeyMsg belongs to
+	jumping     bool   // The ":" prompt for a row number is open
+	sorting     bool   // The sort key was pressed and 1-4 pick the column
+	sortChanged bool   // The sort order was changed and is saved on quit
+	jumpInput   string // Row number typed at the ":" prompt
+
+	// Grouping fields
+	groupBy     string         // "", "tag", "month", "dir", or "format"
+	groupCounts map[string]int // Number of notes in each group
+
+	// Tree view fields
+	treeExpanded map[string]bool // Expanded folders, keyed by path
+	treeSelected int             // Selected row in the tree view
+	treeStart    int             // First visible row in the tree view
+
+	// In-flight load tracking
+	loadCancel context.CancelFunc // Cancels the current background load
+	loadSeq    int                // Sequence number of the most recent load
+
+	restore *config.Session // Saved session still being restored, nil once done
+	draft   *config.Draft   // Unsaved form input from an earlier run, until restored or discarded
+
+	quitFrom     string        // View to return to when quitting is not confirmed
+	similarNotes []*notes.Note // Notes with a title like the create form's, while asking about them
+
+	// Split view fields
+	panes          [2]*pane // Left and right note lists
+	activePane     int      // Pane that keys apply to
+	paneQuerying   bool     // Typing a filter for the active pane
+	paneQueryInput string
+	splitStatus    string // Result of the last move or copy
+
+	// Inline edit fields
+	inlineField string // "title" or "tags" while the inline prompt is open
+	inlineInput string
+	inlineError string // Why the last inline save failed
+
+	// Outline view fields
+	outlineNote     *notes.Note
+	outlineHeadings []notes.Heading // Headings, with line numbers of the note file
+	outlineLines    []string        // Lines of the note file
+	outlineSelected int
+	previewImages   []previewImage    // Images in the preview as last rendered, by marker
+	drawnImages     string            // Where images were last drawn, "" if none are shown
+	imageCache      map[string]string // Escape sequences that draw each image, by protocol and size
+
+	// Inbox fields
+	inboxCount     int // Notes in the inbox as of the last full load
+	refileNote     *notes.Note
+	refileTargets  []string // Directories the note can be refiled to
+	refileSelected int
+
+	onThisDay []*notes.Note // Notes created on today's date in earlier years, for the banner
+
+	lastEditors map[string]string // Who last changed each note, by ID, when modified_column is on
+
+	tagCounts map[string]int // Notes carrying each tag, for the create and edit forms
+
+	keyCounts map[string]int // List keys pressed this session, for usage metrics; nil when usage_metrics is off
+
+	// Focus mode fields
+	focusNote    *notes.Note
+	focusText    string    // The note's content as written so far
+	focusDirty   bool      // Whether focusText has changes not yet saved
+	focusSavedAt time.Time // Time of the last save, zero before the first
+	focusSeq     int       // Identifies the focus session autosave ticks belong to
+
+	// Kanban board fields
+	kanbanColumn int // Selected column
+	kanbanRow    int // Selected note within the column
 }
 
 // Styles contains all the styling for the TUI
@@ -60,28 +164,34 @@ type Styles struct {
 	item      lipgloss.Style
 	selected  lipgloss.Style
 	border    lipgloss.Style
+
+	theme   config.Theme // Colors the styles are drawn in
+	markers bool         // Mark the selection and warnings besides coloring them
 }
 
 // NewStyles creates new styles based on config
 func NewStyles(cfg *config.Config) *Styles {
+	theme := cfg.ActiveTheme()
 	return &Styles{
-		primary:   lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.Primary)).Bold(true),
-		secondary: lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.Secondary)),
-		success:   lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.Success)),
-		warning:   lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.Warning)),
-		error:     lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.Error)),
-		info:      lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.Info)),
-		muted:     lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.Muted)),
+		primary:   lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Primary)).Bold(true),
+		secondary: lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Secondary)),
+		success:   lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Success)),
+		warning:   lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Warning)),
+		error:     lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Error)),
+		info:      lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Info)),
+		muted:     lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Muted)),
 		title:     lipgloss.NewStyle().Bold(true),
 		item:      lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true),
-		selected:  lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.Success)),
-		border:    lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).BorderForeground(lipgloss.Color(cfg.Theme.Primary)),
+		selected:  lipgloss.NewStyle().Foreground(lipgloss.Color(theme.Success)),
+		border:    lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).BorderForeground(lipgloss.Color(theme.Primary)),
+		theme:     theme,
+		markers:   cfg.Accessibility.Markers || lipgloss.ColorProfile() == termenv.Ascii,
 	}
 }
 
 // NewModel creates a new TUI model
 func NewModel(noteManager *notes.Manager, cfg *config.Config) *Model {
-	return &Model{
+	m := &Model{
 		notes:        []*notes.Note{},
 		selected:     0,
 		searchQuery:  "",
@@ -106,14 +216,37 @@ func NewModel(noteManager *notes.Manager, cfg *config.Config) *Model {
 		searchField:  0,
 
 		// Pagination fields
-		pageSize:   29, // Changed from 15 to 29 notes per page
 		startIndex: 0,
+
+		// Tree view fields
+		treeExpanded: map[string]bool{},
+	}
+
+	m.fitPageSize(0)
+	m.configModTime = configModTime()
+
+	// Pick up where the last session left off
+	if session, err := config.LoadSession(); err == nil && *session != (config.Session{}) {
+		m.restore = session
+		for _, field := range notes.GroupFields {
+			if field == session.GroupBy {
+				m.groupBy = field
+			}
+		}
+	}
+
+	// Offer to restore a form left unsaved
+	if draft, err := config.LoadDraft(); err == nil && draft != nil {
+		m.draft = draft
+		m.state = "restore_draft"
 	}
+	return m
 }
 
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
-	return tea.Cmd(m.loadNotes)
+	m.routeWarnings()
+	return tea.Batch(m.loadNotesCmd(), m.watchConfig(), m.nextWarningCmd())
 }
 
 // Update handles user input and updates the model
@@ -126,20 +259,131 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "search":
 			return m.handleSearchKey(msg)
 		case "edit":
-			return m.handleEditKey(msg)
+			return m.keepDraft(m.handleEditKey(msg))
 		case "create":
-			return m.handleCreateKey(msg)
+			return m.keepDraft(m.handleCreateKey(msg))
 		case "confirm_delete":
 			return m.handleConfirmDeleteKey(msg)
+		case "tree":
+			return m.handleTreeKey(msg)
+		case "split":
+			return m.handleSplitKey(msg)
+		case "outline":
+			return m.handleOutlineKey(msg)
+		case "refile":
+			return m.handleRefileKey(msg)
+		case "kanban":
+			return m.handleKanbanKey(msg)
+		case "focus":
+			return m.handleFocusKey(msg)
+		case "restore_draft":
+			return m.handleRestoreDraftKey(msg)
+		case "confirm_quit":
+			return m.handleConfirmQuitKey(msg)
+		case "similar_title":
+			return m.handleSimilarTitleKey(msg)
+		case "help":
+			return m.handleHelpKey(msg)
 		}
 	case notesLoadedMsg:
+		if msg.seq != m.loadSeq {
+			// Result of a load that was superseded or cancelled
+			return m, nil
+		}
+		m.loadCancel = nil
 		m.notes = msg.notes
+		m.inboxCount = m.noteManager.InboxCount(msg.notes)
+		m.onThisDay = notes.OnThisDay(msg.notes, time.Now())
+		if m.config.ModifiedColumn {
+			m.lastEditors, _ = m.noteManager.LastEditors()
+		}
+		m.setFilters("", nil)
+		m.totalNotes = len(msg.notes)
+		m.refreshedAt = time.Now()
+		m.applyGrouping()
 		// Reset pagination when notes are loaded
 		m.selected = 0
 		m.startIndex = 0
+		if m.restore != nil {
+			// Then go back to the saved search and selection
+			return m, m.restoreSession()
+		}
+		return m, nil
+	case searchResultMsg:
+		if msg.seq != m.loadSeq {
+			return m, nil
+		}
+		if m.config.Accessibility.ReducedMotion {
+			// Hold the hits back so the list changes once
+			m.pending = append(m.pending, msg.note)
+		} else {
+			m.notes = append(m.notes, msg.note)
+		}
+		return m, waitForSearchResult(msg.stream, msg.seq)
+	case searchDoneMsg:
+		if msg.seq == m.loadSeq {
+			m.loadCancel = nil
+			if m.pending != nil {
+				m.notes, m.pending = m.pending, nil
+			}
+			m.applyGrouping()
+			if m.restore != nil {
+				m.restoreSelection()
+			}
+			if failures := notes.ExtractorFailures(); len(failures) > 0 {
+				m.flash = i18n.T("Text could not be extracted from %d files: %v", len(failures), failures[0])
+			}
+			if msg.err != nil {
+				m.flash = i18n.T("Search failed: %v", msg.err)
+			}
+		}
+		return m, nil
+	case focusTickMsg:
+		return m, m.handleFocusTick(msg)
+	case configChangedMsg:
+		return m, m.handleConfigChanged()
+	case configLoadedMsg:
+		return m, m.handleConfigLoaded(msg)
+	case copiedMsg:
+		m.flash = msg.flash
+		return m, nil
+	case imageDrawMsg:
+		return m, m.drawImages()
+	case tea.WindowSizeMsg:
+		m.handleWindowSize(msg)
+		if m.drawnImages != "" {
+			// The resize repaints over the images
+			m.drawnImages = ""
+			return m, drawImagesCmd()
+		}
+		return m, nil
+	case rowKeyMsg:
+		if msg.seq != m.rowSeq || m.state != "list" {
+			return m, nil
+		}
+		if len(m.countPrefix) == 2 {
+			row, _ := strconv.Atoi(m.countPrefix)
+			m.countPrefix = ""
+			return m, m.openRow(row)
+		}
+		return m, nil
+	case tea.MouseMsg:
+		// Clicking a column header sorts by that column
+		if m.state == "list" && msg.Type == tea.MouseLeft && msg.Y == m.listHeaderY() && len(m.notes) > 0 {
+			m.setSortColumn(m.columnAt(msg.X))
+		}
+		return m, nil
+	case paneLoadedMsg:
+		m.handlePaneLoaded(msg)
 		return m, nil
 	case editorClosedMsg:
-		return m, tea.Cmd(m.loadNotes)
+		if m.state == "split" {
+			return m, tea.Batch(m.loadPaneCmd(0), m.loadPaneCmd(1))
+		}
+		return m, m.loadNotesCmd()
+	case warningMsg:
+		m.flash = i18n.T("Warning: %v", msg.err)
+		return m, m.nextWarningCmd()
 	case errorMsg:
 		// Handle error - could show a notification
 		return m, nil
@@ -149,6 +393,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the TUI
 func (m *Model) View() string {
+	if m.state == "focus" {
+		// Nothing but the writing
+		return m.asciiOnly(m.renderFocus())
+	}
+	return m.asciiOnly(hideImageMarkers(m.renderState() + "\n" + m.renderStatusBar()))
+}
+
+// renderState renders the view for the current state
+func (m *Model) renderState() string {
 	switch m.state {
 	case "list":
 		return m.renderList()
@@ -160,6 +413,26 @@ func (m *Model) View() string {
 		return m.renderCreate()
 	case "confirm_delete":
 		return m.renderConfirmDelete()
+	case "tree":
+		return m.renderTree()
+	case "split":
+		return m.renderSplit()
+	case "outline":
+		return m.renderOutline()
+	case "refile":
+		return m.renderRefile()
+	case "kanban":
+		return m.renderKanban()
+	case "focus":
+		return m.renderFocus()
+	case "restore_draft":
+		return m.renderRestoreDraft()
+	case "confirm_quit":
+		return m.renderConfirmQuit()
+	case "similar_title":
+		return m.renderSimilarTitle()
+	case "help":
+		return m.renderHelp()
 	default:
 		return m.renderList()
 	}
@@ -167,25 +440,46 @@ func (m *Model) View() string {
 
 // handleListKey handles key events in list mode
 func (m *Model) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "ctrl+c":
-		return m, tea.Quit
-	case "j", "down":
-		if m.selected < len(m.notes)-1 {
-			m.selected++
-			// Adjust page if needed
-			if m.selected >= m.startIndex+m.pageSize {
-				m.startIndex = m.selected - m.pageSize + 1
-			}
+	if m.inlineField != "" {
+		return m.handleInlineKey(msg)
+	}
+	if m.jumping {
+		return m.handleJumpKey(msg)
+	}
+	if m.sorting {
+		return m.handleSortKey(msg)
+	}
+	m.flash = ""
+	if m.config.UsageMetrics {
+		if m.keyCounts == nil {
+			m.keyCounts = map[string]int{}
 		}
-	case "k", "up":
-		if m.selected > 0 {
-			m.selected--
-			// Adjust page if needed
-			if m.selected < m.startIndex {
-				m.startIndex = m.selected
-			}
+		m.keyCounts[msg.String()]++
+	}
+
+	// Keys from the config come before the built-in sort keys and motions
+	key, bound := m.listKey(msg.String())
+
+	if !bound && m.handleMotion(msg.String()) {
+		if len(m.countPrefix) == 2 {
+			// Two digits open that row unless a motion follows
+			m.rowSeq++
+			return m, rowKeyCmd(m.rowSeq)
 		}
+		return m, nil
+	}
+
+	switch key {
+	case ":":
+		m.jumping = true
+		m.jumpInput = ""
+	case ",":
+		m.sorting = true
+	case "?":
+		// List every key
+		m.state = "help"
+	case "q", "ctrl+c":
+		return m.requestQuit()
 	case "J":
 		// Jump to bottom of list
 		if len(m.notes) > 0 {
@@ -207,18 +501,19 @@ func (m *Model) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.startIndex = 0
 	case "enter":
 		if len(m.notes) > 0 && m.selected < len(m.notes) {
-			n := m.notes[m.selected]
-			fullPath := filepath.Join(m.noteManager.GetNotesDir(), n.Filename)
-			return m, openEditorCmd(fullPath)
+			return m, m.openNoteAt(m.notes[m.selected], m.matchLine(m.notes[m.selected]))
 		}
 	case "n":
+		m.cancelLoad()
 		m.state = "create"
 		m.titleInput = ""
 		m.contentInput = ""
 		m.tagsInput = ""
 		m.formatInput = "txt"
 		m.currentField = 0
+		m.loadTagCounts()
 	case "s":
+		m.cancelLoad()
 		m.state = "search"
 		m.searchQuery = ""
 		m.searchType = "keyword"
@@ -226,13 +521,103 @@ func (m *Model) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.tagQuery = ""
 		m.dateQuery = ""
 		m.searchField = 0
+	case "S":
+		return m, m.toggleStaleFilter()
+	case "F":
+		// Show only notes in one format, then the next, then all
+		return m, m.cycleFormatFilter()
+	case "A":
+		return m, m.toggleAllNotes()
 	case "d":
 		if len(m.notes) > 0 && m.selected < len(m.notes) {
-			m.deleteTarget = m.notes[m.selected].ID
+			if note := m.notes[m.selected]; note.Locked {
+				m.flash = fmt.Sprintf("'%s' is locked (L to unlock)", note.Title)
+				break
+			}
+			if note := m.notes[m.selected]; m.noteManager.IsReadOnlyDir(note.Dir) {
+				m.flash = fmt.Sprintf("'%s' is in a read-only directory", note.Title)
+				break
+			}
+			m.cancelLoad()
+			m.setDeleteTarget(m.notes[m.selected])
+			if !m.config.Confirmations.Delete && !m.deletePinned {
+				// Pinned notes still take their title typed
+				m.deleteNote(m.deleteTarget)
+				m.clearDeleteTarget()
+				break
+			}
 			m.state = "confirm_delete"
 		}
 	case "r":
-		return m, tea.Cmd(m.loadNotes)
+		return m, m.loadNotesCmd()
+	case "v":
+		// Cycle through grouping modes
+		m.cycleGrouping()
+	case "t":
+		// Switch to the folder tree view
+		m.state = "tree"
+	case "o":
+		// Show the selected note's headings
+		m.enterOutline()
+		return m, drawImagesCmd()
+	case "b":
+		// Show the listed notes on the kanban board
+		m.enterKanban()
+	case "%":
+		// Open a random note from the list
+		return m, m.openRandom()
+	case "z":
+		// Write in the selected note full screen
+		return m, m.enterFocus()
+	case "u":
+		// Revert the last create, edit, delete, or move
+		return m, m.undoLast(false)
+	case "U":
+		// Apply the last undone operation again
+		return m, m.undoLast(true)
+	case "L":
+		// Lock or unlock the selected note
+		m.toggleLock()
+	case "y":
+		return m, m.copySelected("content")
+	case "Y":
+		return m, m.copySelected("path")
+	case "ctrl+r":
+		// Move the selected note to a directory and out of the inbox
+		m.enterRefile()
+	case "ctrl+y":
+		return m, m.copySelected("ID")
+	case "P":
+		// Add the clipboard's image, such as a screenshot, to the selected note
+		if m.pasteImage() {
+			return m, m.loadNotesCmd()
+		}
+	case "c":
+		// Duplicate the selected note and select the copy
+		return m, m.cloneSelected()
+	case "R":
+		// Rename the selected note in place
+		m.startInline("title")
+	case "#":
+		// Edit the selected note's tags in place
+		m.startInline("tags")
+	case "w":
+		// Switch to the two-pane view
+		m.cancelLoad()
+		return m, m.enterSplit()
+	case "tab":
+		// Move focus to the next filter chip
+		if len(m.filters) > 0 {
+			m.chipFocus = (m.chipFocus + 1) % len(m.filters)
+		}
+	case "x":
+		// Remove the focused filter chip
+		return m, m.removeFilter(m.chipFocus)
+	case "X":
+		// Clear every filter
+		if len(m.filters) > 0 {
+			return m, m.loadNotesCmd()
+		}
 	}
 	return m, nil
 }
@@ -249,9 +634,13 @@ func (m *Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.dateQuery = ""
 		m.searchField = 0
 	case "enter":
+		m.state = "list"
+		// Keyword results stream in as they are found
+		if m.searchType == "keyword" && m.keywordQuery != "" {
+			return m, m.streamSearchCmd(m.keywordQuery)
+		}
 		// Perform search based on current search type and fields
 		m.performSearch()
-		m.state = "list"
 	case "tab":
 		// Cycle through search fields
 		m.searchField = (m.searchField + 1) % 4
@@ -327,12 +716,14 @@ func (m *Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleEditKey handles key events in edit mode
 func (m *Model) handleEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
+	case "ctrl+c":
+		return m.requestQuit()
 	case "esc":
 		m.state = "list"
 	case "ctrl+s":
 		m.saveNote()
 		m.state = "list"
-		return m, tea.Cmd(m.loadNotes)
+		return m, m.loadNotesCmd()
 	case "tab":
 		// Cycle through input fields
 		// This is a simplified version - in a real app you'd have more sophisticated field management
@@ -343,14 +734,16 @@ func (m *Model) handleEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleCreateKey handles key events in create mode
 func (m *Model) handleCreateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
+	case "ctrl+c":
+		return m.requestQuit()
 	case "esc":
 		m.state = "list"
 		m.currentField = 0
+		if m.titleInput != "" || m.contentInput != "" {
+			m.flash = "Draft kept; it will be offered when burh next starts"
+		}
 	case "ctrl+s":
-		m.createNote()
-		m.state = "list"
-		m.currentField = 0
-		return m, tea.Cmd(m.loadNotes)
+		return m.saveCreate()
 	case "tab":
 		// Cycle through input fields
 		m.currentField = (m.currentField + 1) % 4
@@ -380,10 +773,7 @@ func (m *Model) handleCreateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		// Move to next field or save if on content field
 		if m.currentField == 3 {
-			m.createNote()
-			m.state = "list"
-			m.currentField = 0
-			return m, tea.Cmd(m.loadNotes)
+			return m.saveCreate()
 		} else {
 			m.currentField = (m.currentField + 1) % 4
 		}
@@ -407,16 +797,17 @@ func (m *Model) handleCreateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleConfirmDeleteKey handles key events in confirm delete mode
 func (m *Model) handleConfirmDeleteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.deletePinned {
+		return m.handleTypedDeleteKey(msg)
+	}
 	switch msg.String() {
 	case "y":
-		if m.deleteTarget != "" {
-			m.deleteNote(m.deleteTarget)
-		}
+		m.confirmDelete()
 		m.state = "list"
-		m.deleteTarget = ""
+		m.clearDeleteTarget()
 	case "n":
 		m.state = "list"
-		m.deleteTarget = ""
+		m.clearDeleteTarget()
 	}
 	return m, nil
 }
@@ -443,26 +834,23 @@ func centerText(text string, width int) (string, int) {
 // renderList renders the note list view
 func (m *Model) renderList() string {
 	var sb strings.Builder
-
-	// Header - centered
 	terminalWidth := getTerminalWidth()
-	headerText := "BURH - NOTE MANAGER"
-	centeredHeader, _ := centerText(headerText, terminalWidth)
-	header := m.styles.title.Render(centeredHeader)
-	sb.WriteString(header)
-	sb.WriteString("\n\n")
-
-	// Help text
-	help := m.styles.muted.Render("  n: new | s: search | enter: edit | d: delete | r: refresh | q: quit | J: bottom | K: top")
-	sb.WriteString(help)
-	sb.WriteString("\n\n")
+	sb.WriteString(m.renderListTop())
 
 	// Notes list
 	if len(m.notes) == 0 {
-		sb.WriteString(m.styles.muted.Render("  No notes found. Press 'n' to create a new note."))
+		sb.WriteString(m.styles.muted.Render("  " + i18n.T("No notes found. Press 'n' to create a new note.")))
 	} else {
 		// Header row
-		header := fmt.Sprintf("  %-16s  %-7s  %-40s  %s", "Date", "Format", "Title", "Tags")
+		edited := ""
+		if m.config.ModifiedColumn {
+			edited = fmt.Sprintf("%-*s  ", editedWidth, i18n.T("Edited"))
+		}
+		header := fmt.Sprintf("  %*s%-*s  %s%-*s  %-*s  %s", numberWidth, "",
+			dateWidth, m.columnLabel("date", i18n.T("Date")), edited,
+			formatWidth, m.columnLabel("format", i18n.T("Format")),
+			m.titleColumnWidth(), m.columnLabel("title", i18n.T("Title")),
+			m.columnLabel("tags", i18n.T("Tags")))
 		sb.WriteString(m.styles.primary.Render(header))
 		sb.WriteString("\n")
 
@@ -483,7 +871,7 @@ func (m *Model) renderList() string {
 
 		// Show pagination info if there are more notes than page size
 		if totalNotes > m.pageSize {
-			paginationInfo := fmt.Sprintf("  Showing %d-%d of %d notes", m.startIndex+1, endIndex, totalNotes)
+			paginationInfo := "  " + i18n.T("Showing %d-%d of %d notes", m.startIndex+1, endIndex, totalNotes)
 			sb.WriteString(m.styles.muted.Render(paginationInfo))
 			sb.WriteString("\n")
 		}
@@ -494,40 +882,100 @@ func (m *Model) renderList() string {
 		// Render only the notes for the current page
 		for i := m.startIndex; i < endIndex; i++ {
 			note := m.notes[i]
+
+			// Section header whenever a new group starts
+			if m.groupBy != "" {
+				key := groupKey(note, m.groupBy)
+				if i == m.startIndex || key != groupKey(m.notes[i-1], m.groupBy) {
+					groupHeader := fmt.Sprintf("  ── %s: %s (%d)", m.groupBy, key, m.groupCounts[key])
+					sb.WriteString(m.styles.info.Render(groupHeader))
+					sb.WriteString("\n")
+				}
+			}
+
 			rowStyle := m.styles.item
+			if m.noteManager.IsReadOnlyDir(note.Dir) {
+				// Notes in read-only directories are dimmed
+				rowStyle = m.styles.muted
+			}
 			if i == m.selected {
 				rowStyle = m.styles.selected
 			}
 
 			dateStr := note.Created.Format("2006-01-02 15:04")
-			formatStr := note.Format
-			titleStr := note.Title
-			if len(titleStr) > 40 {
-				titleStr = titleStr[:37] + "..."
+			formatStr := m.config.Display.FormatLabel(note.Format)
+			// Emoji icons are two cells wide but pad as one rune
+			formatPad := formatWidth - (lipgloss.Width(formatStr) - utf8.RuneCountInString(formatStr))
+			titleCol := m.titleColumnWidth()
+			titleRoom := titleCol
+			if note.Locked {
+				titleRoom -= 3 // Room for the lock icon
+			}
+			titleStr := truncate(note.Title, titleRoom)
+			titleRest := "" // Second line of a wrapped title
+			if m.config.TitleOverflow == "wrap" {
+				titleStr, titleRest = wrapTitle(note.Title, titleRoom)
 			}
 			// Truncate tags to show only first 6
 			tagsToShow := note.Tags
 			if len(note.Tags) > 6 {
 				tagsToShow = note.Tags[:6]
 			}
-			tagsStr := strings.Join(tagsToShow, ", ")
+			tagLabels := make([]string, len(tagsToShow))
+			for j, tag := range tagsToShow {
+				tagLabels[j] = m.config.Display.TagLabel(tag)
+			}
+			tagsStr := strings.Join(tagLabels, ", ")
 			if len(note.Tags) > 6 {
 				tagsStr += "..."
 			}
 
-			row := fmt.Sprintf("  %-16s  %-7s  %-40s  %s", dateStr, formatStr, titleStr, tagsStr)
+			titlePad := titleCol
+			if note.Locked {
+				// The lock icon is two cells wide but pads as one rune
+				titleStr = "🔒 " + titleStr
+				titlePad--
+			}
+
+			// Inline prompt replaces the column being edited
+			if i == m.selected && m.inlineField == "title" {
+				titleStr = m.inlineInput + "█"
+			}
+			if i == m.selected && m.inlineField == "tags" {
+				tagsStr = m.inlineInput + "█"
+			}
+
+			row := fmt.Sprintf("  %*d %-*s  %s%-*s  %-*s  %s", numberWidth-1, i-m.startIndex+1, dateWidth, dateStr, m.editedCell(note), formatPad, formatStr, titlePad, titleStr, tagsStr)
+			if i == m.selected {
+				row = m.styles.markSelected(row)
+			}
 			sb.WriteString(rowStyle.Render(row))
-			sb.WriteString("\n")
+			if titleRest != "" {
+				sb.WriteString("\n")
+				indent := numberWidth + dateWidth + m.editedColumnWidth()
+				sb.WriteString(rowStyle.Render(fmt.Sprintf("  %-*s  %-*s  %s", indent, "", formatWidth, "", titleRest)))
+			}
+			sb.WriteString(strings.Repeat("\n", m.rowHeight()))
 		}
 
 		// Show navigation hints if there are more pages
 		if totalNotes > m.pageSize {
 			sb.WriteString("\n")
 			if m.startIndex > 0 {
-				sb.WriteString(m.styles.muted.Render("  ↑ Previous page (k/up) "))
+				sb.WriteString(m.styles.muted.Render("  ↑ " + i18n.T("Previous page (k/up)") + " "))
 			}
 			if endIndex < totalNotes {
-				sb.WriteString(m.styles.muted.Render("  ↓ Next page (j/down) "))
+				sb.WriteString(m.styles.muted.Render("  ↓ " + i18n.T("Next page (j/down)") + " "))
+			}
+		}
+
+		// Inline prompt help, or why saving failed
+		if m.inlineField != "" {
+			sb.WriteString("\n")
+			if m.inlineError != "" {
+				sb.WriteString(m.styles.fail("  " + m.inlineError))
+			} else {
+				sb.WriteString(m.styles.muted.Render("  " + i18n.T("enter: save | esc: cancel")))
 			}
 		}
 	}
@@ -535,18 +983,50 @@ func (m *Model) renderList() string {
 	return m.styles.border.Render(sb.String())
 }
 
+// renderListTop renders the part of the list view above the column header: the
+// title, help, on-this-day banner, and active filter chips
+func (m *Model) renderListTop() string {
+	var sb strings.Builder
+
+	// Header - centered
+	headerText := i18n.T("BURH - NOTE MANAGER")
+	centeredHeader, _ := centerText(headerText, getTerminalWidth())
+	header := m.styles.title.Render(centeredHeader)
+	sb.WriteString(header)
+	sb.WriteString("\n\n")
+
+	// Help text
+	help := m.styles.muted.Render("  " + i18n.T("%s: new | %s: search | %s: edit | %s: delete | %s: all keys | %s: quit",
+		m.actionKey("new"), m.actionKey("search"), m.actionKey("open"), m.actionKey("delete"), m.actionKey("help"), m.actionKey("quit")))
+	sb.WriteString(help)
+	sb.WriteString("\n\n")
+
+	// Banner for notes from this day in earlier years
+	if banner := m.onThisDayBanner(); banner != "" {
+		sb.WriteString(banner)
+		sb.WriteString("\n\n")
+	}
+
+	// Active filters
+	if len(m.filters) > 0 {
+		sb.WriteString(m.renderChips())
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
 // renderSearch renders the search view
 func (m *Model) renderSearch() string {
 	var sb strings.Builder
 
-	header := m.styles.title.Render("SEARCH NOTES")
+	header := m.styles.title.Render(i18n.T("SEARCH NOTES"))
 	sb.WriteString(header)
 	sb.WriteString("\n\n")
 
 	// Search type field
-	typeLabel := "  Search Type: "
+	typeLabel := "  " + i18n.T("Search Type:") + " "
 	if m.searchField == 0 {
-		typeLabel = m.styles.selected.Render("  Search Type: ")
+		typeLabel = m.styles.selected.Render("  " + i18n.T("Search Type:") + " ")
 	}
 	sb.WriteString(typeLabel)
 	sb.WriteString(m.searchType)
@@ -556,9 +1036,9 @@ func (m *Model) renderSearch() string {
 	sb.WriteString("\n")
 
 	// Keyword field
-	keywordLabel := "  Keyword: "
+	keywordLabel := "  " + i18n.T("Keyword:") + " "
 	if m.searchField == 1 {
-		keywordLabel = m.styles.selected.Render("  Keyword: ")
+		keywordLabel = m.styles.selected.Render("  " + i18n.T("Keyword:") + " ")
 	}
 	sb.WriteString(keywordLabel)
 	sb.WriteString(m.keywordQuery)
@@ -568,9 +1048,9 @@ func (m *Model) renderSearch() string {
 	sb.WriteString("\n")
 
 	// Tag field
-	tagLabel := "  Tag: "
+	tagLabel := "  " + i18n.T("Tag:") + " "
 	if m.searchField == 2 {
-		tagLabel = m.styles.selected.Render("  Tag: ")
+		tagLabel = m.styles.selected.Render("  " + i18n.T("Tag:") + " ")
 	}
 	sb.WriteString(tagLabel)
 	sb.WriteString(m.tagQuery)
@@ -580,9 +1060,9 @@ func (m *Model) renderSearch() string {
 	sb.WriteString("\n")
 
 	// Date field
-	dateLabel := "  Date: "
+	dateLabel := "  " + i18n.T("Date:") + " "
 	if m.searchField == 3 {
-		dateLabel = m.styles.selected.Render("  Date: ")
+		dateLabel = m.styles.selected.Render("  " + i18n.T("Date:") + " ")
 	}
 	sb.WriteString(dateLabel)
 	sb.WriteString(m.dateQuery)
@@ -591,18 +1071,18 @@ func (m *Model) renderSearch() string {
 	}
 	sb.WriteString("\n\n")
 
-	help := m.styles.muted.Render("  Tab: Next field | Shift+Tab: Previous field | Space: Toggle search type | Enter: Search | Esc: Cancel")
+	help := m.styles.muted.Render("  " + i18n.T("Tab: Next field | Shift+Tab: Previous field | Space: Toggle search type | Enter: Search | Esc: Cancel"))
 	sb.WriteString(help)
 	sb.WriteString("\n\n")
 
 	// Show search type help
 	switch m.searchType {
 	case "keyword":
-		sb.WriteString(m.styles.info.Render("  Keyword search: Searches in title, content, and tags"))
+		sb.WriteString(m.styles.info.Render("  " + i18n.T("Keyword search: Searches in title, content, and tags")))
 	case "tag":
-		sb.WriteString(m.styles.info.Render("  Tag search: Searches only in note tags"))
+		sb.WriteString(m.styles.info.Render("  " + i18n.T("Tag search: Searches only in note tags")))
 	case "date":
-		sb.WriteString(m.styles.info.Render("  Date search: Searches by creation date (formats: YYYY-MM-DD, MM/DD/YYYY, etc.)"))
+		sb.WriteString(m.styles.info.Render("  " + i18n.T("Date search: Searches by creation date (formats: YYYY-MM-DD, MM/DD/YYYY, etc.)")))
 	}
 
 	return m.styles.border.Render(sb.String())
@@ -612,14 +1092,14 @@ func (m *Model) renderSearch() string {
 func (m *Model) renderEdit() string {
 	var sb strings.Builder
 
-	header := m.styles.title.Render("EDIT NOTE")
+	header := m.styles.title.Render(i18n.T("EDIT NOTE"))
 	sb.WriteString(header)
 	sb.WriteString("\n\n")
 
 	// Title field
-	titleLabel := "  Title: "
+	titleLabel := "  " + i18n.T("Title:") + " "
 	if m.currentField == 0 {
-		titleLabel = m.styles.selected.Render("  Title: ")
+		titleLabel = m.styles.selected.Render("  " + i18n.T("Title:") + " ")
 	}
 	sb.WriteString(titleLabel)
 	sb.WriteString(m.titleInput)
@@ -629,9 +1109,9 @@ func (m *Model) renderEdit() string {
 	sb.WriteString("\n")
 
 	// Tags field
-	tagsLabel := "  Tags: "
+	tagsLabel := "  " + i18n.T("Tags:") + " "
 	if m.currentField == 1 {
-		tagsLabel = m.styles.selected.Render("  Tags: ")
+		tagsLabel = m.styles.selected.Render("  " + i18n.T("Tags:") + " ")
 	}
 	sb.WriteString(tagsLabel)
 	sb.WriteString(m.tagsInput)
@@ -639,11 +1119,12 @@ func (m *Model) renderEdit() string {
 		sb.WriteString(m.styles.selected.Render("█"))
 	}
 	sb.WriteString("\n")
+	sb.WriteString(m.renderTagBadges())
 
 	// Format field
-	formatLabel := "  Format: "
+	formatLabel := "  " + i18n.T("Format:") + " "
 	if m.currentField == 2 {
-		formatLabel = m.styles.selected.Render("  Format: ")
+		formatLabel = m.styles.selected.Render("  " + i18n.T("Format:") + " ")
 	}
 	sb.WriteString(formatLabel)
 	sb.WriteString(m.formatInput)
@@ -655,9 +1136,9 @@ func (m *Model) renderEdit() string {
 	sb.WriteString("\n")
 
 	// Content field
-	contentLabel := "  Content: "
+	contentLabel := "  " + i18n.T("Content:") + " "
 	if m.currentField == 3 {
-		contentLabel = m.styles.selected.Render("  Content: ")
+		contentLabel = m.styles.selected.Render("  " + i18n.T("Content:") + " ")
 	}
 	sb.WriteString(contentLabel)
 	sb.WriteString("\n")
@@ -667,7 +1148,7 @@ func (m *Model) renderEdit() string {
 	}
 	sb.WriteString("\n\n")
 
-	help := m.styles.muted.Render("  Tab: Next field | Shift+Tab: Previous field | Enter: Next/Save | Ctrl+S: Save | Esc: Cancel")
+	help := m.styles.muted.Render("  " + i18n.T("Tab: Next field | Shift+Tab: Previous field | Enter: Next/Save | Ctrl+S: Save | Esc: Cancel"))
 	sb.WriteString(help)
 
 	return m.styles.border.Render(sb.String())
@@ -677,14 +1158,14 @@ func (m *Model) renderEdit() string {
 func (m *Model) renderCreate() string {
 	var sb strings.Builder
 
-	header := m.styles.title.Render("CREATE NEW NOTE")
+	header := m.styles.title.Render(i18n.T("CREATE NEW NOTE"))
 	sb.WriteString(header)
 	sb.WriteString("\n\n")
 
 	// Title field
-	titleLabel := "  Title: "
+	titleLabel := "  " + i18n.T("Title:") + " "
 	if m.currentField == 0 {
-		titleLabel = m.styles.selected.Render("  Title: ")
+		titleLabel = m.styles.selected.Render("  " + i18n.T("Title:") + " ")
 	}
 	sb.WriteString(titleLabel)
 	sb.WriteString(m.titleInput)
@@ -694,9 +1175,9 @@ func (m *Model) renderCreate() string {
 	sb.WriteString("\n")
 
 	// Tags field
-	tagsLabel := "  Tags: "
+	tagsLabel := "  " + i18n.T("Tags:") + " "
 	if m.currentField == 1 {
-		tagsLabel = m.styles.selected.Render("  Tags: ")
+		tagsLabel = m.styles.selected.Render("  " + i18n.T("Tags:") + " ")
 	}
 	sb.WriteString(tagsLabel)
 	sb.WriteString(m.tagsInput)
@@ -704,11 +1185,12 @@ func (m *Model) renderCreate() string {
 		sb.WriteString(m.styles.selected.Render("█"))
 	}
 	sb.WriteString("\n")
+	sb.WriteString(m.renderTagBadges())
 
 	// Format field
-	formatLabel := "  Format: "
+	formatLabel := "  " + i18n.T("Format:") + " "
 	if m.currentField == 2 {
-		formatLabel = m.styles.selected.Render("  Format: ")
+		formatLabel = m.styles.selected.Render("  " + i18n.T("Format:") + " ")
 	}
 	sb.WriteString(formatLabel)
 	sb.WriteString(m.formatInput)
@@ -720,9 +1202,9 @@ func (m *Model) renderCreate() string {
 	sb.WriteString("\n")
 
 	// Content field
-	contentLabel := "  Content: "
+	contentLabel := "  " + i18n.T("Content:") + " "
 	if m.currentField == 3 {
-		contentLabel = m.styles.selected.Render("  Content: ")
+		contentLabel = m.styles.selected.Render("  " + i18n.T("Content:") + " ")
 	}
 	sb.WriteString(contentLabel)
 	sb.WriteString("\n")
@@ -732,7 +1214,7 @@ func (m *Model) renderCreate() string {
 	}
 	sb.WriteString("\n\n")
 
-	help := m.styles.muted.Render("  Tab: Next field | Shift+Tab: Previous field | Enter: Next/Save | Ctrl+S: Save | Esc: Cancel")
+	help := m.styles.muted.Render("  " + i18n.T("Tab: Next field | Shift+Tab: Previous field | Enter: Next/Save | Ctrl+S: Save | Esc: Cancel"))
 	sb.WriteString(help)
 
 	return m.styles.border.Render(sb.String())
@@ -742,27 +1224,123 @@ func (m *Model) renderCreate() string {
 func (m *Model) renderConfirmDelete() string {
 	var sb strings.Builder
 
-	header := m.styles.title.Render("CONFIRM DELETE")
+	header := m.styles.title.Render(i18n.T("CONFIRM DELETE"))
 	sb.WriteString(header)
 	sb.WriteString("\n\n")
 
-	message := fmt.Sprintf("  Are you sure you want to delete note '%s'? This action cannot be undone.", m.notes[m.selected].Title)
-	sb.WriteString(m.styles.warning.Render(message))
+	if m.deletePinned {
+		message := "  " + i18n.T("'%s' is pinned. Type its title to delete it.", m.deleteTitle)
+		sb.WriteString(m.styles.warn(message))
+		sb.WriteString("\n\n")
+		sb.WriteString("  > " + m.deleteTyped + "█\n\n")
+		sb.WriteString(m.styles.muted.Render("  " + i18n.T("enter: Delete | esc: Cancel")))
+		return m.styles.border.Render(sb.String())
+	}
+
+	message := "  " + i18n.T("Are you sure you want to delete note '%s'? This action cannot be undone.", m.deleteTitle)
+	if m.config.UndoLimit > 0 {
+		message = "  " + i18n.T("Are you sure you want to delete note '%s'? Press u in the list to undo it.", m.deleteTitle)
+	}
+	sb.WriteString(m.styles.warn(message))
 	sb.WriteString("\n\n")
 
-	help := m.styles.muted.Render("  Y: Confirm | N: Cancel")
+	help := m.styles.muted.Render("  " + i18n.T("Y: Confirm | N: Cancel"))
 	sb.WriteString(help)
 
 	return m.styles.border.Render(sb.String())
 }
 
-// loadNotes loads all notes
-func (m *Model) loadNotes() tea.Msg {
-	notes, err := m.noteManager.ListNotes()
-	if err != nil {
-		return errorMsg{err}
+// loadNotesCmd starts loading all notes in the background, cancelling any load already in flight
+func (m *Model) loadNotesCmd() tea.Cmd {
+	m.cancelLoad()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.loadCancel = cancel
+	m.loadSeq++
+	seq := m.loadSeq
+
+	return func() tea.Msg {
+		defer cancel()
+		notes, err := m.noteManager.ListNotesContext(ctx)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return notesLoadedMsg{notes: notes, seq: seq}
+	}
+}
+
+// LoadNotes loads the notes as the TUI does when it starts, without a running
+// program to deliver them, as 'burh bench' times it
+func (m *Model) LoadNotes() error {
+	msg := m.loadNotesCmd()()
+	if err, ok := msg.(errorMsg); ok {
+		return err.err
+	}
+	m.Update(msg)
+	return nil
+}
+
+// streamSearchCmd starts a keyword search that delivers hits one at a time,
+// replacing the list contents as results arrive
+func (m *Model) streamSearchCmd(query string) tea.Cmd {
+	m.cancelLoad()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.loadCancel = cancel
+	m.loadSeq++
+	seq := m.loadSeq
+
+	m.notes = nil
+	m.pending = nil
+	m.selected = 0
+	m.startIndex = 0
+	m.setFilters("keyword", m.queryChips(query))
+
+	stream := &searchStream{results: make(chan *notes.Note)}
+	go func() {
+		defer cancel()
+		defer close(stream.results)
+		err := m.noteManager.SearchNotesStream(ctx, query, func(note *notes.Note) bool {
+			select {
+			case stream.results <- note:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if ctx.Err() == nil {
+			stream.err = err
+		}
+	}()
+
+	return waitForSearchResult(stream, seq)
+}
+
+// searchStream carries a streamed search's hits, then the error that ended it.
+// err is set before results is closed, so it can be read once results is drained.
+type searchStream struct {
+	results chan *notes.Note
+	err     error
+}
+
+// waitForSearchResult waits for the next streamed search hit
+func waitForSearchResult(stream *searchStream, seq int) tea.Cmd {
+	return func() tea.Msg {
+		note, ok := <-stream.results
+		if !ok {
+			return searchDoneMsg{seq: seq, err: stream.err}
+		}
+		return searchResultMsg{note: note, seq: seq, stream: stream}
+	}
+}
+
+// cancelLoad stops the in-flight background load, if any
+func (m *Model) cancelLoad() {
+	if m.loadCancel != nil {
+		m.loadCancel()
+		m.loadCancel = nil
+		m.loadSeq++ // Ignore anything the cancelled load still sends
 	}
-	return notesLoadedMsg{notes}
 }
 
 // searchNotes searches for notes
@@ -778,20 +1356,24 @@ func (m *Model) searchNotes(query string) {
 // performSearch performs search based on current search type and fields
 func (m *Model) performSearch() {
 	var results []*notes.Note
+	var query string
 	var err error
 
 	switch m.searchType {
 	case "keyword":
 		if m.keywordQuery != "" {
-			results, err = m.noteManager.SearchNotes(m.keywordQuery)
+			query = m.keywordQuery
+			results, err = m.noteManager.SearchNotes(query)
 		}
 	case "tag":
 		if m.tagQuery != "" {
-			results, err = m.noteManager.SearchByTag(m.tagQuery)
+			query = m.tagQuery
+			results, err = m.noteManager.SearchByTag(query)
 		}
 	case "date":
 		if m.dateQuery != "" {
-			results, err = m.noteManager.SearchByDate(m.dateQuery)
+			query = m.dateQuery
+			results, err = m.noteManager.SearchByDate(query)
 		}
 	}
 
@@ -801,8 +1383,10 @@ func (m *Model) performSearch() {
 
 	if results != nil {
 		m.notes = results
+		m.setFilters(m.searchType, []filterChip{{key: m.searchType, value: query}})
 		m.selected = 0
 		m.startIndex = 0 // Reset pagination for search results
+		m.applyGrouping()
 	}
 }
 
@@ -817,7 +1401,11 @@ func (m *Model) saveNote() {
 		tags[i] = strings.TrimSpace(tag)
 	}
 
-	m.noteManager.UpdateNote(m.currentNote.ID, m.titleInput, m.contentInput, tags)
+	if _, err := m.noteManager.UpdateNote(m.currentNote.ID, m.titleInput, m.contentInput, tags); err != nil {
+		m.flash = err.Error()
+		return
+	}
+	m.clearDraft()
 }
 
 // createNote creates a new note
@@ -831,14 +1419,18 @@ func (m *Model) createNote() {
 		tags[i] = strings.TrimSpace(tag)
 	}
 
-	m.noteManager.CreateNote(m.titleInput, m.contentInput, tags, m.formatInput)
+	if _, err := m.noteManager.CreateNote(m.titleInput, m.contentInput, tags, m.formatInput); err != nil {
+		m.flash = err.Error()
+		return
+	}
+	m.clearDraft()
 }
 
 // deleteNote deletes a note
 func (m *Model) deleteNote(id string) {
 	err := m.noteManager.DeleteNote(id)
 	if err != nil {
-		// Could show an error message here
+		m.flash = err.Error()
 		return
 	}
 	// Reload notes to reflect the deletion
@@ -851,9 +1443,60 @@ func (m *Model) deleteNote(id string) {
 	m.startIndex = 0
 }
 
+// cycleGrouping switches to the next grouping mode and regroups the list
+func (m *Model) cycleGrouping() {
+	modes := append([]string{""}, notes.GroupFields...)
+	for i, mode := range modes {
+		if mode == m.groupBy {
+			m.groupBy = modes[(i+1)%len(modes)]
+			break
+		}
+	}
+	m.applyGrouping()
+	m.selected = 0
+	m.startIndex = 0
+}
+
+// applyGrouping sorts the notes and orders them by group so each group renders
+// as one section. In the TUI a note is shown once, under its first group key.
+func (m *Model) applyGrouping() {
+	m.sortNotes()
+	m.groupCounts = map[string]int{}
+	if m.groupBy == "" {
+		return
+	}
+
+	sort.SliceStable(m.notes, func(i, j int) bool {
+		return groupKey(m.notes[i], m.groupBy) < groupKey(m.notes[j], m.groupBy)
+	})
+	for _, note := range m.notes {
+		m.groupCounts[groupKey(note, m.groupBy)]++
+	}
+}
+
+// groupKey returns the group a note is listed under
+func groupKey(note *notes.Note, by string) string {
+	return notes.GroupKeys(note, by)[0]
+}
+
 // Message types
 type notesLoadedMsg struct {
 	notes []*notes.Note
+	seq   int // loadSeq of the load that produced this message
+}
+
+// searchResultMsg carries one streamed search hit
+type searchResultMsg struct {
+	note   *notes.Note
+	seq    int
+	stream *searchStream
+}
+
+// searchDoneMsg signals the end of a streamed search, with the error that ended
+// it early if any
+type searchDoneMsg struct {
+	seq int
+	err error
 }
 
 type errorMsg struct {
@@ -863,30 +1506,28 @@ type errorMsg struct {
 // message emitted when the editor closes
 type editorClosedMsg struct{}
 
-// openEditorCmd opens the given file in the user's preferred editor and waits for it to close
-func openEditorCmd(path string) tea.Cmd {
+// openEditorCmd opens a note in the user's preferred editor, at a 1-based line if
+// it is not 0, and waits for it to close
+func openEditorCmd(noteManager *notes.Manager, note *notes.Note, line int) tea.Cmd {
 	return func() tea.Msg {
-		editor := os.Getenv("VISUAL")
-		if editor == "" {
-			editor = os.Getenv("EDITOR")
+		cmd, err := editor.CommandAt(note.Path(), line)
+		if err != nil {
+			// If no editor is available, do nothing gracefully
+			return editorClosedMsg{}
 		}
 
-		var cmd *exec.Cmd
-		if editor != "" {
-			cmd = exec.Command(editor, path)
-		} else {
-			// Fallback to OS default opener
-			switch runtime.GOOS {
-			case "darwin":
-				cmd = exec.Command("open", path)
-			case "linux":
-				cmd = exec.Command("xdg-open", path)
-			case "windows":
-				cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", path)
-			default:
-				// If unknown OS, do nothing gracefully
-				return editorClosedMsg{}
-			}
+		// Journal the edit so it can be undone like changes made in burh
+		_ = noteManager.JournalEdit(note, cmd.Run)
+		return editorClosedMsg{}
+	}
+}
+
+// openFileCmd opens the given file with the OS default application, for notes burh cannot edit
+func openFileCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		cmd, err := editor.OpenCommand(path)
+		if err != nil {
+			return editorClosedMsg{}
 		}
 
 		_ = cmd.Run()
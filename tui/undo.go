@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"fmt"
+
+	"burh/config"
+	"burh/notes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// undoLast reverts the most recent note operation, or with redo applies the last
+// undone one again, then reloads the list with its note selected
+func (m *Model) undoLast(redo bool) tea.Cmd {
+	verb, replay := "Undid", m.noteManager.Undo
+	if redo {
+		verb, replay = "Redid", m.noteManager.Redo
+	}
+	op, err := replay()
+	if err != nil {
+		m.flash = err.Error()
+		return nil
+	}
+	m.flash = fmt.Sprintf("%s %s of '%s'", verb, op.Kind, op.Title)
+	gone := op.Kind == notes.OpCreate && !redo || op.Kind == notes.OpDelete && redo
+	if !gone {
+		m.restore = &config.Session{SelectedID: op.NoteID}
+	}
+	return m.loadNotesCmd()
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"burh/i18n"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleAllNotes sets aside the notes hidden by the default view or by
+// --exclude-tags and --exclude-dir, showing every note, or hides them again
+func (m *Model) toggleAllNotes() tea.Cmd {
+	if m.showAll {
+		m.noteManager.SetExclusions(m.hiddenTags, m.hiddenDirs)
+		m.showAll = false
+		m.flash = i18n.T("Default view restored")
+	} else {
+		tags, dirs := m.noteManager.Exclusions()
+		if len(tags) == 0 && len(dirs) == 0 {
+			m.flash = i18n.T("No notes are hidden")
+			return nil
+		}
+		m.hiddenTags, m.hiddenDirs = tags, dirs
+		m.noteManager.SetExclusions(nil, nil)
+		m.showAll = true
+		m.flash = i18n.T("All notes shown")
+	}
+
+	if m.filterKind == "keyword" && len(m.filters) > 0 {
+		return m.streamSearchCmd(filterQuery(m.filters))
+	}
+	return m.loadNotesCmd()
+}
@@ -0,0 +1,32 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// warningMsg carries a problem the note manager reported without failing, such as
+// a change that could not be journaled
+type warningMsg struct {
+	err error
+}
+
+// routeWarnings sends the note manager's warnings to the status line, since
+// printing them would garble the screen
+func (m *Model) routeWarnings() {
+	if m.warnings == nil {
+		m.warnings = make(chan error, 16)
+	}
+	warnings := m.warnings
+	m.noteManager.SetWarnFunc(func(err error) {
+		select {
+		case warnings <- err:
+		default: // Drop warnings while too many are waiting to be shown
+		}
+	})
+}
+
+// nextWarningCmd waits for the note manager's next warning
+func (m *Model) nextWarningCmd() tea.Cmd {
+	warnings := m.warnings
+	return func() tea.Msg {
+		return warningMsg{err: <-warnings}
+	}
+}
@@ -0,0 +1,221 @@
+// Package update replaces the running burh binary with the latest GitHub release
+package update
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Repo is the GitHub repository releases are published to
+const Repo = "andrewpdawes/burh"
+
+// ChecksumsAsset is the release asset listing the SHA-256 of every binary, in
+// sha256sum's "<hex>  <name>" format
+const ChecksumsAsset = "checksums.txt"
+
+// maxBinarySize caps a download, well above burh's size, so a bad response can't fill the disk
+const maxBinarySize = 200 << 20
+
+// Release is a published release and its downloadable files
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	Assets []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+var client = &http.Client{Timeout: 2 * time.Minute}
+
+// Latest fetches the newest release from the GitHub releases API
+func Latest() (*Release, error) {
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/repos/"+Repo+"/releases/latest", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for releases: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to check for releases: GitHub returned %s", resp.Status)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+	return &release, nil
+}
+
+// AssetName returns the name of the release binary for this platform, e.g.
+// "burh-linux-amd64" or "burh-windows-amd64.exe"
+func AssetName() string {
+	name := "burh-" + runtime.GOOS + "-" + runtime.GOARCH
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Asset returns the release's asset with the name, nil if it has none
+func (r *Release) Asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Newer checks if the release tag is a later version than current. Versions are
+// compared number by number, so "v1.10.0" is newer than "v1.9.2". A current
+// version that isn't a release, such as "dev" or "v1.2.0-3-gabc123", is never up to date.
+func Newer(tag, current string) bool {
+	latest, ok := parseVersion(tag)
+	if !ok {
+		return false
+	}
+	installed, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+	for i := 0; i < len(latest) || i < len(installed); i++ {
+		var a, b int
+		if i < len(latest) {
+			a = latest[i]
+		}
+		if i < len(installed) {
+			b = installed[i]
+		}
+		if a != b {
+			return a > b
+		}
+	}
+	return false
+}
+
+// parseVersion reads a version such as "v1.2.3" into its numbers
+func parseVersion(version string) ([]int, bool) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		numbers[i] = n
+	}
+	return numbers, true
+}
+
+// Download fetches a release asset
+func Download(asset *Asset) ([]byte, error) {
+	resp, err := client.Get(asset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", asset.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", asset.Name, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBinarySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", asset.Name, err)
+	}
+	if len(data) > maxBinarySize {
+		return nil, fmt.Errorf("%s is larger than %d MB", asset.Name, maxBinarySize>>20)
+	}
+	return data, nil
+}
+
+// VerifyChecksum checks data, the asset with the name, against its SHA-256 in a
+// checksums file
+func VerifyChecksum(data []byte, name string, checksums []byte) error {
+	for _, line := range strings.Split(string(checksums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		want, err := hex.DecodeString(fields[0])
+		if err != nil {
+			return fmt.Errorf("bad checksum for %s: %w", name, err)
+		}
+		sum := sha256.Sum256(data)
+		if !bytes.Equal(sum[:], want) {
+			return fmt.Errorf("checksum mismatch for %s: the download may be corrupt or tampered with", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s lists no checksum for %s", ChecksumsAsset, name)
+}
+
+// ManagedBy returns the package manager that installed the executable at path,
+// "" if none did. Those installs are updated with the package manager instead.
+func ManagedBy(path string) string {
+	slashed := strings.ToLower(filepath.ToSlash(path))
+	switch {
+	case strings.Contains(slashed, "/cellar/") || strings.Contains(slashed, "/homebrew/"):
+		return "Homebrew"
+	case strings.Contains(slashed, "/scoop/"):
+		return "Scoop"
+	}
+	return ""
+}
+
+// Replace swaps the executable at path for data. The new binary is written next
+// to it and renamed over it, so a failure leaves the old one working. Windows
+// can't overwrite a running executable but can rename it, so the old one is
+// moved aside first and removed when possible.
+func Replace(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".burh-update-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s: %w", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0111); err != nil {
+		return err
+	}
+
+	old := path + ".old"
+	os.Remove(old) // Left by an earlier update on Windows
+	if err := os.Rename(path, old); err != nil {
+		return fmt.Errorf("cannot replace %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Rename(old, path)
+		return fmt.Errorf("cannot replace %s: %w", path, err)
+	}
+	os.Remove(old) // Fails on Windows while the old binary is running
+	return nil
+}